@@ -41,6 +41,7 @@ var labelsmonitorsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		monitorId := string(args[0])
 		label := string(args[1])
@@ -49,7 +50,7 @@ var labelsmonitorsCmd = &cobra.Command{
 		arr := strings.Split(label, ":")
 		monitorLabel.Category = &arr[0]
 		monitorLabel.Label = &arr[1]
-		err, returnValue := AddLabelToMonitor(monitorId, monitorLabel)
+		err, returnValue := AddLabelToMonitor(ctx, monitorId, monitorLabel)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -66,28 +67,22 @@ var labelsmonitorsCmd = &cobra.Command{
 	},
 }
 
-func AddLabelToMonitor(monitorId string, monitorLabel *newrelic.MonitorLabel) (error, tracker.ReturnValue) {
+func AddLabelToMonitor(ctx context.Context, monitorId string, monitorLabel *newrelic.MonitorLabel) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("labelSynthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, err := client.LabelsSynthetics.AddLabelToMonitor(context.Background(), monitorId, monitorLabel)
+	resp, err := client.LabelsSynthetics.AddLabelToMonitor(ctx, monitorId, monitorLabel)
 	var label = *monitorLabel.Category + ":" + *monitorLabel.Label
 	if err != nil {
+		tracker.AppendRESTCallError(client.LabelsSynthetics, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, err, "label: "+label+", monitor id: "+monitorId)
 		fmt.Println(err)
-		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, err, tracker.ERR_REST_CALL, "label: "+", monitor id: ")
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, err, tracker.ERR_REST_CALL, "label: "+label+", monitor id: "+monitorId)
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.LabelsSynthetics, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, resp.StatusCode, "label: "+", monitor id: ")
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Adding label '%s' to monitor '%s'\n", statusCode, label, monitorId)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.LabelsSynthetics, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, resp.StatusCode, "label: "+label+", monitor id: "+monitorId)
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_ADD_LABEL_MONITOR, nil, nil, "")
 	return nil, ret
 }
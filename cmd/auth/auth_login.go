@@ -0,0 +1,111 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store the API key, and optionally the insert key, of a profile.",
+	Long: `Store the API key, and optionally the insert key, of the profile selected by
+--profile, NEW_RELIC_PROFILE or current_profile, else of the profile "default".
+
+The keys are read from stdin, so they are never in the shell history, and
+stored in the freedesktop Secret Service keyring if secret-tool is installed,
+else in $HOME/.nr/credentials, readable by the user only.`,
+	Example: `* nr auth login
+* nr auth login --profile prod --insert-key
+* nr auth login --store file < api_key.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		storeName, _ := flags.GetString("store")
+		withInsertKey, _ := flags.GetBool("insert-key")
+
+		if storeName == "" {
+			storeName = utils.StoreFile
+			if utils.KeyringAvailable() {
+				storeName = utils.StoreKeyring
+			}
+		}
+		store, err := utils.GetCredentialStore(storeName)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		profile := utils.ProfileName()
+		keys := map[string]string{}
+		keys["api_key"], err = readSecret(fmt.Sprintf("NewRelic admin API key of profile %q: ", profile))
+		if err != nil || keys["api_key"] == "" {
+			fmt.Println("Please give New Relic admin API key.")
+			os.Exit(1)
+			return
+		}
+		if withInsertKey {
+			keys["insert_key"], err = readSecret(fmt.Sprintf("NewRelic insert key of profile %q: ", profile))
+			if err != nil || keys["insert_key"] == "" {
+				fmt.Println("Please give New Relic insert key.")
+				os.Exit(1)
+				return
+			}
+		}
+
+		for _, key := range utils.CredentialKeys {
+			if keys[key] == "" {
+				continue
+			}
+			if err := store.Set(profile, key, keys[key]); err != nil {
+				fmt.Printf("Failed to store %s in %s: %v\n", key, store.Name(), err)
+				os.Exit(1)
+				return
+			}
+		}
+
+		// The profile tells where its keys are.
+		configFile, err := utils.ReadConfigFile()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		configFile.Set("profiles."+profile+".credential_store", storeName)
+		if _, ok := configFile.Get("current_profile"); !ok {
+			configFile.Set("current_profile", profile)
+		}
+		if err := configFile.Write(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		fmt.Printf("Stored the keys of profile %q in %s.\n", profile, store.Name())
+		os.Exit(0)
+	},
+}
+
+func init() {
+	AuthCmd.AddCommand(loginCmd)
+
+	loginCmd.Flags().String("store", "", "Where to store the keys, keyring or file. Default is keyring if secret-tool is installed, else file.")
+	loginCmd.Flags().Bool("insert-key", false, "Also ask for the insert key, used by 'nr insert customevents'.")
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var logoutCmd = &cobra.Command{
+	Use:     "logout",
+	Short:   "Remove the keys of a profile from the keyring and the credentials file.",
+	Example: "nr auth logout --profile prod",
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := utils.ProfileName()
+
+		stores := []utils.CredentialStore{utils.NewFileStore()}
+		if utils.KeyringAvailable() {
+			keyring, _ := utils.GetCredentialStore(utils.StoreKeyring)
+			stores = append(stores, keyring)
+		}
+		for _, store := range stores {
+			for _, key := range utils.CredentialKeys {
+				if err := store.Delete(profile, key); err != nil {
+					fmt.Printf("Failed to remove %s from %s: %v\n", key, store.Name(), err)
+					os.Exit(1)
+					return
+				}
+			}
+		}
+
+		fmt.Printf("Removed the keys of profile %q.\n", profile)
+		os.Exit(0)
+	},
+}
+
+func init() {
+	AuthCmd.AddCommand(logoutCmd)
+}
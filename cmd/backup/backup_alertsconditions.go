@@ -25,6 +25,7 @@ import (
 	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/newrelic"
 	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -57,6 +58,7 @@ var alertsconditionsCmd = &cobra.Command{
 	Short:   "Backup alertsconditions to a directory.",
 	Example: "nr backup alertsconditions -d <<Directory of backup alert conditions files>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var backupFolder string
 		var err error
@@ -109,13 +111,13 @@ var alertsconditionsCmd = &cobra.Command{
 		alertBackup.AlertDependencies = &AlertDependencies{}
 		alertBackup.AlertDependencies.MonitorMap = map[string]*newrelic.Monitor{}
 
-		allChannelList, err, returnValue := get.GetAllAlertsChannels()
+		allChannelList, err, returnValue := get.GetAllAlertsChannels(ctx)
 		if returnValue.IsContinue == false {
 			exitBackupAlertConditionsWithError(returnValue, resultFileName)
 			return
 		}
 
-		allPolicyList, err, returnValue := get.GetAllAlertPolicies()
+		allPolicyList, err, returnValue := get.GetAllAlertPolicies(ctx)
 		if returnValue.IsContinue == false {
 			exitBackupAlertConditionsWithError(returnValue, resultFileName)
 			return
@@ -132,7 +134,7 @@ var alertsconditionsCmd = &cobra.Command{
 				defer close(r)
 				chTaskCtrl <- struct{}{}
 				fmt.Printf("Fetching alert conditions for Policy: %s\n", name)
-				conditionList, _, returnValue := get.GetAllConditionsByAlertPolicyID(alertPolicyID)
+				conditionList, _, returnValue := get.GetAllConditionsByAlertPolicyID(ctx, alertPolicyID)
 				<-chTaskCtrl
 				if returnValue.IsContinue == false {
 					r <- nil
@@ -181,7 +183,7 @@ var alertsconditionsCmd = &cobra.Command{
 				for _, monitor := range syntheticsArray {
 					if monitor.MonitorID != nil {
 						fmt.Printf("Calling  GetMonitorByID() func, monitor id: %s\n", *monitor.MonitorID)
-						m, err, ret := get.GetMonitorByID(*monitor.MonitorID)
+						m, err, ret := get.GetMonitorByID(ctx, *monitor.MonitorID)
 						if err != nil {
 							fmt.Println(err)
 						}
@@ -191,6 +193,21 @@ var alertsconditionsCmd = &cobra.Command{
 						alertBackup.AlertDependencies.MonitorMap[*monitor.MonitorID] = m
 					}
 				}
+
+				// the entities of location conditions are monitor ids too
+				for _, condition := range conditionList.AlertsLocationConditions {
+					for _, monitorID := range condition.Entities {
+						if monitorID == nil || alertBackup.AlertDependencies.MonitorMap[*monitorID] != nil {
+							continue
+						}
+						fmt.Printf("Calling  GetMonitorByID() func, monitor id: %s\n", *monitorID)
+						m, err, _ := get.GetMonitorByID(ctx, *monitorID)
+						if err != nil {
+							fmt.Println(err)
+						}
+						alertBackup.AlertDependencies.MonitorMap[*monitorID] = m
+					}
+				}
 			}
 			alertPolicySet.AlertsConditionList = conditionList
 
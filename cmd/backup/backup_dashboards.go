@@ -23,6 +23,7 @@ import (
 
 	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
 	"github.com/spf13/cobra"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/pretty"
@@ -33,6 +34,7 @@ var dashboardsCmd = &cobra.Command{
 	Short:   "Backup dashboards to a directory.",
 	Example: "nr backup dashboards -d <Directory of backup dashboards files>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		var backupFolder string
 		var err error
 		flags := cmd.Flags()
@@ -80,7 +82,7 @@ var dashboardsCmd = &cobra.Command{
 		var backupDashboardMetaList tracker.BackupDashboardMetaList = tracker.BackupDashboardMetaList{}
 		var allBackupDashboardMeta []tracker.BackupDashboardMeta
 
-		resultStr, err, returnValue := get.GetAllDashboards()
+		resultStr, err, returnValue := get.GetAllDashboards(ctx)
 		if err != nil {
 			fmt.Println(err)
 			exitBackupDashboardWithError(returnValue, resultFileName)
@@ -108,7 +110,7 @@ var dashboardsCmd = &cobra.Command{
 				defer close(r)
 				chTaskCtrl <- struct{}{}
 				fmt.Printf("Fetching dashboard: %s\n", id.String())
-				strDashboard, err, ret := get.GetDashboardByID(id.Int())
+				strDashboard, err, ret := get.GetDashboardByID(ctx, id.Int())
 				<-chTaskCtrl
 				if err != nil || ret.IsContinue == false {
 					if err != nil {
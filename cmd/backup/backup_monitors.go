@@ -23,6 +23,7 @@ import (
 
 	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +32,7 @@ var monitorsCmd = &cobra.Command{
 	Short:   "Backup monitors to a directory.",
 	Example: "nr backup monitors -d <Directory of backup monitors files>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var backupFolder string
 		var err error
@@ -76,7 +78,7 @@ var monitorsCmd = &cobra.Command{
 
 		//
 		//get all monitors
-		monitorArray, err, returnValue := get.GetMonitors()
+		monitorArray, err, returnValue := get.GetMonitors(ctx)
 		// if err != nil {
 		// 	fmt.Println(err)
 		// 	os.Exit(1)
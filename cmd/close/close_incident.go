@@ -0,0 +1,63 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package close
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var incidentCmd = &cobra.Command{
+	Use:     "incident",
+	Short:   "Close an alerts incident, and its open violations, by id.",
+	Aliases: []string{"in"},
+	Example: "nr close incident <id>",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if _, err := utils.ParseIncidentID(args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		id, _ := utils.ParseIncidentID(args)
+		incident, err := utils.UpdateIncident(utils.GetContext(), id, "close", (*newrelic.AlertsIncidentService).Close)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer.Print(incident, os.Stdout)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	CloseCmd.AddCommand(incidentCmd)
+}
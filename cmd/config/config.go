@@ -0,0 +1,41 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ConfigCmd represents the config command
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the account profiles of the config file $HOME/.nr.yaml.",
+	Long: `Manage the account profiles of the config file $HOME/.nr.yaml.
+
+A profile holds the settings of one NewRelic account:
+    current_profile: prod
+    profiles:
+      prod:
+        api_key: <admin API key>
+        insert_key: <insert key>
+        account_id: "1234567"
+        region: EU
+        proxy: http://proxy.example.com:8080
+        proxy_auth: <proxy credentials>
+        retries: 5
+The profile is selected by --profile, NEW_RELIC_PROFILE or current_profile.
+Environment variables like NEW_RELIC_APIKEY still win over the profile.`,
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Display a setting in effect, from the environment or the selected profile.",
+	Example: `* nr config get region
+* nr config get account_id --profile eu-prod`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 - a key")
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		if _, ok := utils.ProfileKeys[args[0]]; !ok {
+			var err = fmt.Errorf("unknown key %q, keys are %s", args[0], strings.Join(profileKeyNames(), ", "))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := utils.LoadProfile(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		value := viper.GetString(args[0])
+		if value == "" {
+			os.Exit(1)
+			return
+		}
+		fmt.Println(value)
+		os.Exit(0)
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(getCmd)
+}
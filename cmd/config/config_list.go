@@ -0,0 +1,97 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// ProfileList is the profiles of the config file, with their keys masked.
+type ProfileList struct {
+	Profiles []*Profile `json:"profiles"`
+}
+
+type Profile struct {
+	Current   string `json:"current"`
+	Name      string `json:"name"`
+	Region    string `json:"region"`
+	AccountID string `json:"account_id"`
+	APIKey    string `json:"api_key"`
+	InsertKey string `json:"insert_key"`
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Display the profiles of the config file, with their keys masked.",
+	Example: `* nr config list
+* nr config list -o yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFile, err := utils.ReadConfigFile()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		current := utils.SelectedProfile()
+		profileList := &ProfileList{}
+		for _, name := range configFile.Profiles() {
+			profile := &Profile{Name: name}
+			if name == current {
+				profile.Current = "*"
+			}
+			profile.Region = profileSetting(configFile, name, "region")
+			profile.AccountID = profileSetting(configFile, name, "account_id")
+			profile.APIKey = profileSetting(configFile, name, "api_key")
+			profile.InsertKey = profileSetting(configFile, name, "insert_key")
+			profileList.Profiles = append(profileList.Profiles, profile)
+		}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer.Print(profileList, os.Stdout)
+
+		os.Exit(0)
+	},
+}
+
+// profileSetting returns the setting key of profile as written in the config
+// file, masked if it is a secret.
+func profileSetting(configFile *utils.ConfigFile, profile string, key string) string {
+	value, ok := configFile.Get("profiles." + profile + "." + key)
+	if !ok || value == nil {
+		return ""
+	}
+	setting := fmt.Sprint(value)
+	if utils.IsSecretProfileKey(key) {
+		return utils.MaskSecret(setting)
+	}
+	return setting
+}
+
+func init() {
+	ConfigCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringP("output", "o", "table", "Output format. table/json/yaml are supported")
+}
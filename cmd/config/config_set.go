@@ -0,0 +1,145 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a setting of the selected profile.",
+	Long: `Set a setting of the profile selected by --profile, NEW_RELIC_PROFILE or
+current_profile, else of the profile "default". The first profile written
+becomes the current one.
+
+Keys: ` + strings.Join(profileKeyNames(), ", "),
+	Example: `* nr config set api_key <admin API key>
+* nr config set region EU --profile eu-prod`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			var err = fmt.Errorf("length of [flags] should be 2 - a key and its value")
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		if _, ok := utils.ProfileKeys[args[0]]; !ok {
+			var err = fmt.Errorf("unknown key %q, keys are %s", args[0], strings.Join(profileKeyNames(), ", "))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		key, rawValue := args[0], args[1]
+
+		var value interface{} = rawValue
+		switch key {
+		case "region":
+			if _, err := newrelic.EndpointsForRegion(rawValue); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+		case "retries", "max_idle_conns", "max_idle_conns_per_host":
+			number, err := strconv.Atoi(rawValue)
+			if err != nil || number < 0 {
+				fmt.Printf("%q looks like a non-number\n", rawValue)
+				os.Exit(1)
+				return
+			}
+			value = number
+		case "idle_conn_timeout", "keep_alive":
+			if _, err := time.ParseDuration(rawValue); err != nil {
+				fmt.Printf("%q looks like a non-duration, e.g. 90s\n", rawValue)
+				os.Exit(1)
+				return
+			}
+		case "disable_keep_alives":
+			disable, err := strconv.ParseBool(rawValue)
+			if err != nil {
+				fmt.Printf("%q looks like a non-boolean\n", rawValue)
+				os.Exit(1)
+				return
+			}
+			value = disable
+		case "credential_store":
+			if rawValue != utils.StoreKeyring && rawValue != utils.StoreFile {
+				fmt.Printf("Unsupported credential store %q, must be %s or %s.\n", rawValue, utils.StoreKeyring, utils.StoreFile)
+				os.Exit(1)
+				return
+			}
+		case "account_id":
+			if _, err := strconv.ParseInt(rawValue, 10, 64); err != nil {
+				fmt.Printf("%q looks like a non-number\n", rawValue)
+				os.Exit(1)
+				return
+			}
+		}
+
+		profile := utils.SelectedProfile()
+		if profile == "" {
+			profile = utils.DefaultProfile
+		}
+		if strings.Contains(profile, ".") {
+			fmt.Printf("Invalid profile name %q, it can not contain '.'.\n", profile)
+			os.Exit(1)
+			return
+		}
+
+		configFile, err := utils.ReadConfigFile()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		configFile.Set("profiles."+profile+"."+key, value)
+		if _, ok := configFile.Get("current_profile"); !ok {
+			configFile.Set("current_profile", profile)
+		}
+		if err := configFile.Write(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		fmt.Printf("Set %s of profile %q in %s.\n", key, profile, configFile.Path)
+		os.Exit(0)
+	},
+}
+
+func profileKeyNames() []string {
+	var names []string
+	for key := range utils.ProfileKeys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	ConfigCmd.AddCommand(setCmd)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/IBM/newrelic-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var useProfileCmd = &cobra.Command{
+	Use:     "use-profile <name>",
+	Short:   "Make a profile the current one.",
+	Example: "nr config use-profile prod",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 - a profile name")
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := args[0]
+
+		configFile, err := utils.ReadConfigFile()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if _, ok := configFile.Get("profiles." + profile); !ok {
+			fmt.Printf("Profile %q not found in %s.\n", profile, configFile.Path)
+			os.Exit(1)
+			return
+		}
+		configFile.Set("current_profile", profile)
+		if err := configFile.Write(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		fmt.Printf("Switched to profile %q.\n", profile)
+		os.Exit(0)
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(useProfileCmd)
+}
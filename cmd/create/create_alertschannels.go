@@ -16,7 +16,6 @@
 package create
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -33,6 +32,7 @@ var alertschannelsCmd = &cobra.Command{
 	Short:   "Create alerts_channels from a file.",
 	Aliases: []string{"ac", "alertchannel", "alertschannel"},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -67,16 +67,12 @@ var alertschannelsCmd = &cobra.Command{
 			os.Exit(1)
 			return
 		}
-		_, resp, err := client.AlertsChannels.Create(context.Background(), c)
+		_, resp, err := client.AlertsChannels.Create(ctx, c)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
-		} else {
-			fmt.Println(resp.Status)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-			}
 		}
+		fmt.Println(resp.Status)
 
 		tracker.PrintStatisticsInfo(tracker.GlobalRESTCallResultList)
 		fmt.Println()
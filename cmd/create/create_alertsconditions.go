@@ -50,6 +50,7 @@ var alertsconditionsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -116,6 +117,22 @@ var alertsconditionsCmd = &cobra.Command{
 				ac.AlertsSyntheticsConditionEntity = ace
 
 				cat = newrelic.ConditionSynthetics
+			case "location":
+				var ace = new(newrelic.AlertsLocationConditionEntity)
+				err = decorder.Decode(ace)
+				if err != nil {
+					fmt.Printf("Unable to decode for location type condition %q: %v\n", file, err)
+					os.Exit(1)
+					return
+				}
+				if reflect.DeepEqual(new(newrelic.AlertsLocationConditionEntity), ace) {
+					fmt.Printf("Error validating for location type condition %q.\n", file)
+					os.Exit(1)
+					return
+				}
+				ac.AlertsLocationConditionEntity = ace
+
+				cat = newrelic.ConditionLocation
 			case "ext":
 				var ace = new(newrelic.AlertsExternalServiceConditionEntity)
 				err = decorder.Decode(ace)
@@ -188,15 +205,12 @@ var alertsconditionsCmd = &cobra.Command{
 				os.Exit(1)
 				return
 			}
-			_, resp, err := client.AlertsConditions.Create(context.Background(), cat, ac, alertPolicyID)
+			_, resp, err := client.AlertsConditions.Create(ctx, cat, ac, alertPolicyID)
 			if err != nil {
 				fmt.Printf("Failed to create condition, %v\n", err)
 			} else {
 				var statusCode = resp.StatusCode
 				fmt.Printf("Response status code: %d. Create alert conditions, alert policy id: '%d'\n", statusCode, alertPolicyID)
-				if resp.StatusCode >= 400 {
-					os.Exit(1)
-				}
 			}
 
 		}
@@ -205,7 +219,7 @@ var alertsconditionsCmd = &cobra.Command{
 	},
 }
 
-func CreateCondition(cat newrelic.ConditionCategory, ac *newrelic.AlertsConditionEntity, alertPolicyID int64) (*newrelic.AlertsConditionEntity, error, tracker.ReturnValue) {
+func CreateCondition(ctx context.Context, cat newrelic.ConditionCategory, ac *newrelic.AlertsConditionEntity, alertPolicyID int64) (*newrelic.AlertsConditionEntity, error, tracker.ReturnValue) {
 	// start to create
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
@@ -213,21 +227,14 @@ func CreateCondition(cat newrelic.ConditionCategory, ac *newrelic.AlertsConditio
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return nil, err, ret
 	}
-	alertsConditionEntity, resp, err := client.AlertsConditions.Create(context.Background(), cat, ac, alertPolicyID)
+	alertsConditionEntity, resp, err := client.AlertsConditions.Create(ctx, cat, ac, alertPolicyID)
 	if err != nil {
 		fmt.Printf("Failed to create alert condition, %v\n", err)
+		tracker.AppendRESTCallError(client.AlertsConditions, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, err, "")
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, err, tracker.ERR_REST_CALL, "")
 		return nil, err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, resp.StatusCode, "")
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Create alert conditions, alert policy id: '%d'\n", statusCode, alertPolicyID)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, resp.StatusCode, "")
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_ALERT_CONDITIION, nil, nil, "")
 	return alertsConditionEntity, err, ret
 }
@@ -34,6 +34,7 @@ var alertspoliciesCmd = &cobra.Command{
 	Aliases: []string{"ap", "alertpolicy", "alertspolicy"},
 	Example: "nr create alertspolicies -f <example.yaml>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -68,7 +69,7 @@ var alertspoliciesCmd = &cobra.Command{
 			os.Exit(1)
 			return
 		}
-		alertsPolicy, resp, err := client.AlertsPolicies.Create(context.Background(), p)
+		alertsPolicy, resp, err := client.AlertsPolicies.Create(ctx, p)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -84,43 +85,35 @@ var alertspoliciesCmd = &cobra.Command{
 		}
 		printer.Print(alertsPolicy, os.Stdout)
 
-		if resp.StatusCode >= 400 {
-			os.Exit(1)
-		}
-
 		os.Exit(0)
 	},
 }
 
-func CreateAlertsPolicyEntity(alertsPolcyEntity *newrelic.AlertsPolicyEntity) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
+func CreateAlertsPolicyEntity(ctx context.Context, alertsPolcyEntity *newrelic.AlertsPolicyEntity) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return nil, err, ret
 	}
-	alertsPolicy, resp, err := client.AlertsPolicies.Create(context.Background(), alertsPolcyEntity)
+	alertsPolicy, resp, err := client.AlertsPolicies.Create(ctx, alertsPolcyEntity)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.AlertsPolicies, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, err, "alert policy name:"+(*alertsPolcyEntity.AlertsPolicy.Name))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS, err, tracker.ERR_REST_CALL, "")
 		return nil, err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, resp.StatusCode, "alert policy name:"+(*alertsPolcyEntity.AlertsPolicy.Name))
-		var statusCode = resp.StatusCode
-		fmt.Printf("Response status code: %d. Create alert policy '%s', alert policy id: '%d'\n", statusCode, *alertsPolicy.AlertsPolicy.Name, *alertsPolicy.AlertsPolicy.ID)
-		if resp.StatusCode >= 400 {
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, resp.StatusCode, "alert policy name:"+(*alertsPolcyEntity.AlertsPolicy.Name))
+	var statusCode = resp.StatusCode
+	fmt.Printf("Response status code: %d. Create alert policy '%s', alert policy id: '%d'\n", statusCode, *alertsPolicy.AlertsPolicy.Name, *alertsPolicy.AlertsPolicy.ID)
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_ALERT_POLICY, nil, nil, "")
 	return alertsPolicy.AlertsPolicy, err, ret
 }
 
-func CreateAlertsPolicy(alertsPolcy *newrelic.AlertsPolicy) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
+func CreateAlertsPolicy(ctx context.Context, alertsPolcy *newrelic.AlertsPolicy) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
 	var alertsPolcyEntity *newrelic.AlertsPolicyEntity = &newrelic.AlertsPolicyEntity{}
 	alertsPolcyEntity.AlertsPolicy = alertsPolcy
-	return CreateAlertsPolicyEntity(alertsPolcyEntity)
+	return CreateAlertsPolicyEntity(ctx, alertsPolcyEntity)
 }
 
 func init() {
@@ -34,6 +34,7 @@ var dashboardCmd = &cobra.Command{
 	Short:   "Create dashboard from a json file.",
 	Example: "nr create dashboard -f <example.yaml>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		fileName, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -53,7 +54,7 @@ var dashboardCmd = &cobra.Command{
 			return
 		}
 		// start to create
-		retMsg, err, ret := CreateDashboard(fileContent)
+		retMsg, err, ret := CreateDashboard(ctx, fileContent)
 		fmt.Printf("response message: %s\n", retMsg)
 		if err != nil {
 			fmt.Print(err)
@@ -73,7 +74,7 @@ var dashboardCmd = &cobra.Command{
 	},
 }
 
-func CreateDashboard(dashboard string) (string, error, tracker.ReturnValue) {
+func CreateDashboard(ctx context.Context, dashboard string) (string, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -82,23 +83,19 @@ func CreateDashboard(dashboard string) (string, error, tracker.ReturnValue) {
 	}
 	title := gjson.Parse(dashboard).Get("dashboard.title").String()
 
-	resp, bytes, err := client.Dashboards.Create(context.Background(), dashboard)
+	resp, bytes, err := client.Dashboards.Create(ctx, dashboard)
 
 	var retMsg string
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.Dashboards, tracker.OPERATION_NAME_CREATE_DASHBOARD, err, "dashboard title:"+title)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_DASHBOARD, err, tracker.ERR_REST_CALL, "")
 		return "", err, ret
-	} else {
-		retMsg = string(bytes)
-		tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_CREATE_DASHBOARD, resp.StatusCode, "dashboard title:"+title)
-		var statusCode = resp.StatusCode
-		fmt.Printf("Response status code: %d. Create dashboard '%s''\n", statusCode, title)
-		if resp.StatusCode >= 400 {
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_DASHBOARD, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, retMsg)
-			return retMsg, err, ret
-		}
 	}
+	retMsg = string(bytes)
+	tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_CREATE_DASHBOARD, resp.StatusCode, "dashboard title:"+title)
+	var statusCode = resp.StatusCode
+	fmt.Printf("Response status code: %d. Create dashboard '%s''\n", statusCode, title)
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_DASHBOARD, nil, nil, "")
 	return retMsg, err, ret
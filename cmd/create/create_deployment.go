@@ -0,0 +1,138 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package create
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/cmd/get"
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var deploymentCmd = &cobra.Command{
+	Use:   "deployment",
+	Short: "Record a deployment of an APM application.",
+	Long: `Record a deployment of an APM application, shown as a marker on its charts.
+
+The flags override the fields of the file given by -f.`,
+	Example: "nr create deployment --app <name|id> --revision v1.2.3 --changelog 'Fix login' --user jdoe",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			var err = fmt.Errorf("length of [flags] should be 0 instead of %d", len(args))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		var d = &newrelic.DeploymentEntity{}
+		if file, _ := utils.GetArg(cmd, "file"); file != "" {
+			f, err := os.Open(file)
+			defer f.Close()
+			if err != nil {
+				fmt.Printf("Unable to open file '%v': %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			// validation
+			decorder := utils.NewYAMLOrJSONDecoder(f, 4096)
+			err = decorder.Decode(d)
+			if err != nil {
+				fmt.Printf("Unable to decode %q: %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			if reflect.DeepEqual(new(newrelic.DeploymentEntity), d) {
+				fmt.Printf("Error validating %q.\n", file)
+				os.Exit(1)
+				return
+			}
+		}
+		if d.Deployment == nil {
+			d.Deployment = new(newrelic.Deployment)
+		}
+		for _, field := range []struct {
+			flag  string
+			value **string
+		}{
+			{"revision", &d.Deployment.Revision},
+			{"changelog", &d.Deployment.Changelog},
+			{"description", &d.Deployment.Description},
+			{"user", &d.Deployment.User},
+		} {
+			if cmd.Flags().Changed(field.flag) {
+				value, _ := cmd.Flags().GetString(field.flag)
+				*field.value = &value
+			}
+		}
+		if d.Deployment.Revision == nil || *d.Deployment.Revision == "" {
+			fmt.Println("A revision is required, set --revision.")
+			os.Exit(1)
+			return
+		}
+
+		// start to create
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		app, _ := cmd.Flags().GetString("app")
+		appID, err := get.GetApplicationID(ctx, client, app)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		deployment, resp, err := client.Deployments.Create(ctx, appID, d)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		fmt.Println(resp.Status)
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		printer.Print(deployment, os.Stdout)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	CreateCmd.AddCommand(deploymentCmd)
+
+	// The deployment may be given by flags instead of a file.
+	deploymentCmd.Flags().StringP("file", "f", "", "Filename to create the deployment with, yaml/json format is supported. Instead of the flags.")
+	deploymentCmd.Flags().String("app", "", "Name or id of the application")
+	deploymentCmd.MarkFlagRequired("app")
+	deploymentCmd.Flags().String("revision", "", "Revision deployed, like a version or a commit")
+	deploymentCmd.Flags().String("changelog", "", "Changes of the deployment")
+	deploymentCmd.Flags().String("description", "", "Description of the deployment")
+	deploymentCmd.Flags().String("user", "", "User who deployed")
+}
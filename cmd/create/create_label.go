@@ -0,0 +1,163 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package create
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Create a label and link it to applications and servers.",
+	Long: `Create a label and link it to applications and servers.
+
+If the label exists, the applications and servers are linked to it too.`,
+	Example: "nr create label <category:name> --apps <id>,<id> --servers <id>\nnr create label -f <example.yaml>",
+	Args: func(cmd *cobra.Command, args []string) error {
+		file, _ := utils.GetArg(cmd, "file")
+		var err error
+		if file != "" && len(args) != 0 {
+			err = fmt.Errorf("no label can be given with -f, got %d", len(args))
+		} else if file == "" && len(args) != 1 {
+			err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+		} else if file == "" && !isLabelKey(args[0]) {
+			err = fmt.Errorf("invalid label %q, must be like category:name", args[0])
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return err
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		var l = new(newrelic.LabelEntity)
+		if file, _ := utils.GetArg(cmd, "file"); file != "" {
+			f, err := os.Open(file)
+			defer f.Close()
+			if err != nil {
+				fmt.Printf("Unable to open file '%v': %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			// validation
+			decorder := utils.NewYAMLOrJSONDecoder(f, 4096)
+			err = decorder.Decode(l)
+			if err != nil {
+				fmt.Printf("Unable to decode %q: %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			if reflect.DeepEqual(new(newrelic.LabelEntity), l) {
+				fmt.Printf("Error validating %q.\n", file)
+				os.Exit(1)
+				return
+			}
+		} else {
+			i := strings.Index(args[0], ":")
+			category, name := args[0][:i], args[0][i+1:]
+			l.Label = &newrelic.Label{Category: &category, Name: &name}
+		}
+
+		apps, err := labelLinkIDs(cmd, "apps")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		servers, err := labelLinkIDs(cmd, "servers")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if len(apps) > 0 || len(servers) > 0 {
+			if l.Label.LabelLinks == nil {
+				l.Label.LabelLinks = new(newrelic.LabelLinks)
+			}
+			l.Label.LabelLinks.Applications = append(l.Label.LabelLinks.Applications, apps...)
+			l.Label.LabelLinks.Servers = append(l.Label.LabelLinks.Servers, servers...)
+		}
+
+		// start to create
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		label, resp, err := client.Labels.Create(ctx, l)
+		if err != nil {
+			tracker.AppendRESTCallError(client.Labels, tracker.OPERATION_NAME_CREATE_LABEL, err, "")
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_CREATE_LABEL, resp.StatusCode, "")
+		fmt.Println(resp.Status)
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		printer.Print(label, os.Stdout)
+
+		os.Exit(0)
+	},
+}
+
+// isLabelKey reports whether key is a label key like category:name.
+func isLabelKey(key string) bool {
+	i := strings.Index(key, ":")
+	return i > 0 && i < len(key)-1
+}
+
+// labelLinkIDs returns the ids of the --apps or --servers flag.
+func labelLinkIDs(cmd *cobra.Command, flag string) ([]*int64, error) {
+	values, err := cmd.Flags().GetStringSlice(flag)
+	if err != nil {
+		return nil, err
+	}
+	var ids []*int64
+	for _, value := range values {
+		id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s id %q", flag, value)
+		}
+		ids = append(ids, &id)
+	}
+	return ids, nil
+}
+
+func init() {
+	CreateCmd.AddCommand(labelCmd)
+
+	// The label may be given as an argument instead of a file.
+	labelCmd.Flags().StringP("file", "f", "", "Filename to create the label with, yaml/json format is supported. Instead of <category:name>.")
+	labelCmd.Flags().StringSlice("apps", nil, "Ids of the applications to link the label to, separated by commas")
+	labelCmd.Flags().StringSlice("servers", nil, "Ids of the servers to link the label to, separated by commas")
+}
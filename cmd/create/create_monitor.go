@@ -37,6 +37,7 @@ var monitorCmd = &cobra.Command{
 	Short:   "Create monitor from a file.",
 	Example: "nr create monitor -f <example.yaml>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -99,7 +100,7 @@ var monitorCmd = &cobra.Command{
 			}
 		}
 
-		_, err, returnValue := CreateMonitor(p, scriptTextEncoded)
+		_, err, returnValue := CreateMonitor(ctx, p, scriptTextEncoded)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -119,7 +120,7 @@ var monitorCmd = &cobra.Command{
 	},
 }
 
-func CreateMonitor(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (string, error, tracker.ReturnValue) {
+func CreateMonitor(ctx context.Context, p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (string, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
@@ -129,45 +130,33 @@ func CreateMonitor(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (str
 
 	p.ID = nil
 
-	createdMonitor, resp, err := client.SyntheticsMonitors.Create(context.Background(), p)
+	createdMonitor, resp, err := client.SyntheticsMonitors.Create(ctx, p)
 	if err != nil {
 		fmt.Println(err)
-		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_MONITOR, err, tracker.ERR_REST_CALL, "")
+		tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_CREATE_MONITOR, err, "monitor name :"+(*p.Name))
+		var ret tracker.ReturnValue
+		if newrelic.IsValidation(err) {
+			// NewRelic refused this monitor only, others can still be created.
+			ret = tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_MONITOR, err, tracker.ERR_REST_CALL_400, "")
+		} else {
+			ret = tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_MONITOR, err, tracker.ERR_REST_CALL, "")
+		}
 		return "", err, ret
 	} else {
 		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_CREATE_MONITOR, resp.StatusCode, "monitor name :"+(*p.Name))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Creating monitor '%s'\n", statusCode, *createdMonitor.Name)
-			var ret tracker.ReturnValue
-			if resp.StatusCode == 400 {
-				ret = tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_400, "")
-			} else {
-				ret = tracker.ToReturnValue(false, tracker.OPERATION_NAME_CREATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			}
-
-			return "", err, ret
-		}
-
 	}
 
 	if *p.Type == "SCRIPT_BROWSER" || *p.Type == "SCRIPT_API" {
 		if scriptTextEncoded != nil && scriptTextEncoded.ScriptText != nil {
 			id := *createdMonitor.ID
-			resp, err := client.SyntheticsScript.UpdateByID(context.Background(), scriptTextEncoded, id)
+			resp, err := client.SyntheticsScript.UpdateByID(ctx, scriptTextEncoded, id)
 			if err != nil {
 				fmt.Println(err)
+				tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, err, "monitor name :"+(*p.Name))
 				ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, err, tracker.ERR_REST_CALL, "")
 				return id, err, ret
-			} else {
-				tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, resp.StatusCode, "monitor name :"+(*p.Name))
-				if resp.StatusCode >= 400 {
-					var statusCode = resp.StatusCode
-					fmt.Printf("Response status code: %d. Update script to monitor '%s', monitor id: '%s'\n", statusCode, *createdMonitor.Name, id)
-					ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-					return "", err, ret
-				}
 			}
+			tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, resp.StatusCode, "monitor name :"+(*p.Name))
 		}
 	}
 
@@ -181,7 +170,7 @@ func CreateMonitor(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (str
 			arr := strings.Split(*label, ":")
 			monitorLabel.Category = &arr[0]
 			monitorLabel.Label = &arr[1]
-			err, returnValue := add.AddLabelToMonitor(*id, monitorLabel)
+			err, returnValue := add.AddLabelToMonitor(ctx, *id, monitorLabel)
 			// if err != nil {
 			// 	fmt.Println(err)
 			// 	return *id, "failed to add labels to monitor", err
@@ -16,7 +16,13 @@
 package delete
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
 )
 
 // DeleteCmd represents the delete/delete command
@@ -30,7 +36,7 @@ func init() {
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
-	DeleteCmd.PersistentFlags().StringP("type-condition", "t", "default", "Alert condition type. Only used for 'alertsconditions' command. default|synthetics|ext|plugin|nrql|infrastructure are supported")
+	DeleteCmd.PersistentFlags().StringP("type-condition", "t", "default", "Alert condition type. Only used for 'alertsconditions' command. default|synthetics|location|ext|plugin|nrql|infrastructure are supported")
 
 	DeleteCmd.PersistentFlags().StringP("output", "o", "json", "Output format. json/yaml are supported")
 	// DeleteCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -39,3 +45,83 @@ func init() {
 	// is called directly, e.g.:
 	// DeleteCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// addSelectorFlags adds the selector flags and --dry-run to a delete command
+// taking an id, which then deletes all the matching resources instead.
+func addSelectorFlags(cmd *cobra.Command) {
+	utils.AddSelectorFlags(cmd.Flags())
+	cmd.Flags().Bool("dry-run", false, "Only print the resources matching the selector, without deleting them")
+}
+
+// checkIDArgs checks that args holds one id, or none if a selector is set.
+func checkIDArgs(cmd *cobra.Command, args []string) error {
+	var err error
+	if utils.HasSelector(cmd) {
+		if len(args) != 0 {
+			err = fmt.Errorf("no id can be given with --selector, --label or --tag, got %d", len(args))
+		}
+	} else if len(args) != 1 {
+		err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return err
+}
+
+// selectItems returns the items of list matching the selector of cmd.
+func selectItems(cmd *cobra.Command, list interface{}) []interface{} {
+	selector, err := utils.NewSelector(cmd)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	items, err := selector.Filter(list)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return items
+}
+
+// deleteSelected deletes the selected items with deleteItem, given their
+// index and id, or only prints them with --dry-run, then exits.
+func deleteSelected(cmd *cobra.Command, kind string, items []interface{}, deleteItem func(i int, id string) error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if len(items) == 0 {
+		fmt.Printf("No %s matches.\n", kind)
+		os.Exit(0)
+	}
+
+	failed := 0
+	for i, item := range items {
+		id, name := utils.ItemID(item), utils.ItemName(item)
+		if dryRun {
+			fmt.Printf("Would delete %s %s, %q\n", kind, id, name)
+			continue
+		}
+		if err := deleteItem(i, id); err != nil {
+			fmt.Printf("Failed to delete %s %s, %q: %v\n", kind, id, name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted %s %s, %q\n", kind, id, name)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// retError returns the error of a delete helper, its typical one if it
+// failed without an error, like on a 4xx response.
+func retError(err error, ret tracker.ReturnValue) error {
+	if ret.IsContinue {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ret.TypicalError
+}
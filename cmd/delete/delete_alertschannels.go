@@ -16,7 +16,6 @@
 package delete
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -29,14 +28,14 @@ var alertschannelsCmd = &cobra.Command{
 	Use:     "alertschannels",
 	Short:   "Delete alerts_channel by id.",
 	Aliases: []string{"ac", "alertchannel", "alertschannel"},
-	Example: "nr delete alertschannels <id>",
+	Example: "nr delete alertschannels <id>\nnr delete alertschannels --selector type=email --dry-run",
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
-			fmt.Println(err)
-			os.Exit(1)
+		if err := checkIDArgs(cmd, args); err != nil {
 			return err
 		}
+		if len(args) == 0 {
+			return nil
+		}
 		if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
 			var err = fmt.Errorf("%q looks like a non-number.\n", args[0])
 			fmt.Println(err)
@@ -46,25 +45,35 @@ var alertschannelsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
+		if utils.HasSelector(cmd) {
+			channels, err := client.AlertsChannels.ListAllPages(ctx, nil)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			deleteSelected(cmd, "alert channel", selectItems(cmd, channels), func(i int, id string) error {
+				channelID, _ := strconv.ParseInt(id, 10, 64)
+				_, err := client.AlertsChannels.DeleteByID(ctx, channelID)
+				return err
+			})
+			return
+		}
 		id, _ := strconv.ParseInt(args[0], 10, 64)
-		resp, err := client.AlertsChannels.DeleteByID(context.Background(), id)
+		resp, err := client.AlertsChannels.DeleteByID(ctx, id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			fmt.Println(resp.Status)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
+		fmt.Println(resp.Status)
 
 		os.Exit(0)
 	},
@@ -72,6 +81,7 @@ var alertschannelsCmd = &cobra.Command{
 
 func init() {
 	DeleteCmd.AddCommand(alertschannelsCmd)
+	addSelectorFlags(alertschannelsCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -31,14 +31,14 @@ var alertsconditionsCmd = &cobra.Command{
 	Use:     "alertsconditions",
 	Short:   "Delete alerts_conditions by id.",
 	Aliases: []string{"ac", "alertcondition", "alertscondition"},
-	Example: "nr delete alertsconditions <id>",
+	Example: "nr delete alertsconditions <id>\nnr delete alertsconditions --policy-id <policy_id> --selector enabled=false --dry-run",
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
-			fmt.Println(err)
-			os.Exit(1)
+		if err := checkIDArgs(cmd, args); err != nil {
 			return err
 		}
+		if len(args) == 0 {
+			return nil
+		}
 		if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
 			var err = fmt.Errorf("%q looks like a non-number.\n", args[0])
 			fmt.Println(err)
@@ -48,6 +48,11 @@ var alertsconditionsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		if utils.HasSelector(cmd) {
+			deleteSelectedConditions(ctx, cmd)
+			return
+		}
 
 		conditionPolicyID, _ := strconv.ParseInt(args[0], 10, 64)
 
@@ -62,20 +67,7 @@ var alertsconditionsCmd = &cobra.Command{
 				return
 			}
 
-			var cat newrelic.ConditionCategory
-			if conditionType == "plugins" {
-				cat = newrelic.ConditionPlugins
-			} else if conditionType == "synthetics" {
-				cat = newrelic.ConditionSynthetics
-			} else if conditionType == "ext" {
-				cat = newrelic.ConditionExternalService
-			} else if conditionType == "nrql" {
-				cat = newrelic.ConditionNRQL
-			} else if conditionType == "infrastructure" {
-				cat = newrelic.ConditionInfrastructure
-			} else {
-				cat = newrelic.ConditionDefault
-			}
+			cat := conditionCategory(conditionType)
 			// start to delete
 			client, err := utils.GetNewRelicClient()
 			if err != nil {
@@ -83,18 +75,13 @@ var alertsconditionsCmd = &cobra.Command{
 				os.Exit(1)
 				return
 			}
-			resp, err := client.AlertsConditions.DeleteByID(context.Background(), cat, conditionPolicyID)
+			resp, err := client.AlertsConditions.DeleteByID(ctx, cat, conditionPolicyID)
 			if err != nil {
 				fmt.Printf("Failed to delete condition, %v\n", err)
 				os.Exit(1)
 				return
-			} else {
-				fmt.Println(resp.Status)
-				if resp.StatusCode >= 400 {
-					os.Exit(1)
-					return
-				}
 			}
+			fmt.Println(resp.Status)
 		} else {
 			fmt.Println("Can not find type-condition argument.")
 			os.Exit(1)
@@ -105,34 +92,86 @@ var alertsconditionsCmd = &cobra.Command{
 	},
 }
 
-func DeleteCondition(cat newrelic.ConditionCategory, conditionPolicyID int64) (error, tracker.ReturnValue) {
+func conditionCategory(conditionType string) newrelic.ConditionCategory {
+	if conditionType == "plugins" {
+		return newrelic.ConditionPlugins
+	} else if conditionType == "synthetics" {
+		return newrelic.ConditionSynthetics
+	} else if conditionType == "location" {
+		return newrelic.ConditionLocation
+	} else if conditionType == "ext" {
+		return newrelic.ConditionExternalService
+	} else if conditionType == "nrql" {
+		return newrelic.ConditionNRQL
+	} else if conditionType == "infrastructure" {
+		return newrelic.ConditionInfrastructure
+	}
+	return newrelic.ConditionDefault
+}
+
+// deleteSelectedConditions deletes the conditions of the --policy-id policy
+// matching the selector, of the -t type if set, else of all types.
+func deleteSelectedConditions(ctx context.Context, cmd *cobra.Command) {
+	policyID, _ := cmd.Flags().GetInt64("policy-id")
+	if policyID == 0 {
+		fmt.Println("--policy-id is required with --selector, --label or --tag.")
+		os.Exit(1)
+	}
+	var cats []newrelic.ConditionCategory
+	if flag := cmd.Flags().Lookup("type-condition"); flag != nil && flag.Changed {
+		cats = append(cats, conditionCategory(flag.Value.String()))
+	}
+
+	client, err := utils.GetNewRelicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	// itemCats holds the category of every selected condition.
+	var items []interface{}
+	var itemCats []newrelic.ConditionCategory
+	opt := &newrelic.AlertsConditionsOptions{PolicyIDOptions: strconv.FormatInt(policyID, 10)}
+	err = client.AlertsConditions.ListCategoriesPages(ctx, opt, func(cat newrelic.ConditionCategory, list *newrelic.AlertsConditionList, resp *newrelic.Response) error {
+		for _, item := range selectItems(cmd, list) {
+			items = append(items, item)
+			itemCats = append(itemCats, cat)
+		}
+		return nil
+	}, cats...)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	deleteSelected(cmd, "alert condition", items, func(i int, id string) error {
+		conditionID, _ := strconv.ParseInt(id, 10, 64)
+		return retError(DeleteCondition(ctx, itemCats[i], conditionID))
+	})
+}
+
+func DeleteCondition(ctx context.Context, cat newrelic.ConditionCategory, conditionPolicyID int64) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, err := client.AlertsConditions.DeleteByID(context.Background(), cat, conditionPolicyID)
+	resp, err := client.AlertsConditions.DeleteByID(ctx, cat, conditionPolicyID)
 	if err != nil {
 		fmt.Printf("Failed to delete condition, %v\n", err)
+		tracker.AppendRESTCallError(client.AlertsConditions, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, err, "monitor id: "+strconv.FormatInt(conditionPolicyID, 10))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, resp.StatusCode, "monitor id: "+strconv.FormatInt(conditionPolicyID, 10))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Remove alert condition id '%d'\n", statusCode, conditionPolicyID)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, resp.StatusCode, "monitor id: "+strconv.FormatInt(conditionPolicyID, 10))
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_DELETE_ALERT_CONDITION, nil, nil, "")
 	return nil, ret
 }
 
 func init() {
 	DeleteCmd.AddCommand(alertsconditionsCmd)
+	addSelectorFlags(alertsconditionsCmd)
+	alertsconditionsCmd.Flags().Int64("policy-id", 0, "Alert policy of the conditions to delete with --selector, --label or --tag")
 
 	// Here you will define your flags and configuration settings.
 
@@ -32,74 +32,77 @@ var alertspoliciesCmd = &cobra.Command{
 	Use:     "alertspolicies",
 	Short:   "Delete alerts_policy by id.",
 	Aliases: []string{"ap", "alertpolicy", "alertspolicy"},
-	Example: "nr delete alertspolicies <id>",
+	Example: "nr delete alertspolicies <id>\nnr delete alertspolicies --selector 'name=~^test-' --dry-run",
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
-			fmt.Println(err)
-			os.Exit(1)
+		if err := checkIDArgs(cmd, args); err != nil {
 			return err
 		}
+		if len(args) == 0 {
+			return nil
+		}
 		if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
 			return fmt.Errorf("%q looks like a non-number.\n", args[0])
 		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
+		if utils.HasSelector(cmd) {
+			policies, err := client.AlertsPolicies.ListAllPages(ctx, nil)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			deleteSelected(cmd, "alert policy", selectItems(cmd, policies), func(i int, id string) error {
+				policyID, _ := strconv.ParseInt(id, 10, 64)
+				return retError(DeletePolicyByID(ctx, policyID))
+			})
+			return
+		}
 		id, _ := strconv.ParseInt(args[0], 10, 64)
-		resp, err := client.AlertsPolicies.DeleteByID(context.Background(), id)
+		resp, err := client.AlertsPolicies.DeleteByID(ctx, id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			fmt.Println(resp.Status)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
+		fmt.Println(resp.Status)
 
 		os.Exit(0)
 	},
 }
 
-func DeletePolicyByID(alertPolicyID int64) (error, tracker.ReturnValue) {
+func DeletePolicyByID(ctx context.Context, alertPolicyID int64) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, err := client.AlertsPolicies.DeleteByID(context.Background(), alertPolicyID)
+	resp, err := client.AlertsPolicies.DeleteByID(ctx, alertPolicyID)
 
 	if err != nil {
 		fmt.Printf("Failed to delete alert policy, %v\n", err)
+		tracker.AppendRESTCallError(client.AlertsPolicies, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, err, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return err, ret
 	}
 
-	tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, resp.StatusCode, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10))
-
-	if resp.StatusCode >= 400 {
-		var statusCode = resp.StatusCode
-		fmt.Printf("response status code: %d. Remove alert condition id '%d'\n", statusCode, alertPolicyID)
-		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-		return err, ret
-	}
+	tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, resp.StatusCode, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10))
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID, nil, nil, "")
 	return nil, ret
 }
 
-func DeletePolicyByName(alertPolicyName string) (error, tracker.ReturnValue) {
-	list, err, ret := get.GetAllAlertPolicies()
+func DeletePolicyByName(ctx context.Context, alertPolicyName string) (error, tracker.ReturnValue) {
+	list, err, ret := get.GetAllAlertPolicies(ctx)
 	if err != nil {
 		fmt.Println(err)
 		ret.IsContinue = false
@@ -110,7 +113,7 @@ func DeletePolicyByName(alertPolicyName string) (error, tracker.ReturnValue) {
 	}
 	for _, policy := range list.AlertsPolicies {
 		if *policy.Name == alertPolicyName {
-			err, ret := DeletePolicyByID(*policy.ID)
+			err, ret := DeletePolicyByID(ctx, *policy.ID)
 			if err != nil {
 				ret.IsContinue = false
 				return err, ret
@@ -126,6 +129,7 @@ func DeletePolicyByName(alertPolicyName string) (error, tracker.ReturnValue) {
 
 func init() {
 	DeleteCmd.AddCommand(alertspoliciesCmd)
+	addSelectorFlags(alertspoliciesCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -17,6 +17,7 @@ package delete
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -32,70 +33,68 @@ var dashboardCmd = &cobra.Command{
 	Use:     "dashboard",
 	Short:   "Delete one dashboard by id.",
 	Aliases: []string{"m"},
-	Example: "nr delete dashboard <id>",
+	Example: "nr delete dashboard <id>\nnr delete dashboard --selector 'title=~^Copy of' --dry-run",
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
-			fmt.Println(err)
-			os.Exit(1)
+		if err := checkIDArgs(cmd, args); err != nil {
 			return err
 		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
+		if utils.HasSelector(cmd) {
+			dashboards, err, ret := get.GetAllDashboards(ctx)
+			if !ret.IsContinue {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			deleteSelected(cmd, "dashboard", selectItems(cmd, json.RawMessage(dashboards)), func(i int, id string) error {
+				dashboardID, _ := strconv.ParseInt(id, 10, 64)
+				return retError(DeleteDashboardByID(ctx, dashboardID))
+			})
+			return
+		}
 		id, _ := strconv.ParseInt(args[0], 10, 64)
-		resp, _, err := client.Dashboards.DeleteByID(context.Background(), id)
+		_, _, err = client.Dashboards.DeleteByID(ctx, id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			fmt.Println(resp.StatusCode)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
 
 		os.Exit(0)
 	},
 }
 
-func DeleteDashboardByID(id int64) (error, tracker.ReturnValue) {
+func DeleteDashboardByID(ctx context.Context, id int64) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, bytes, err := client.Dashboards.DeleteByID(context.Background(), id)
+	resp, _, err := client.Dashboards.DeleteByID(ctx, id)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.Dashboards, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, err, "dashboard id: "+strconv.FormatInt(id, 10))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		retMsg := string(bytes)
-		tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, resp.StatusCode, "dashboard id: "+strconv.FormatInt(id, 10))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Remove dashboard id '%d'\n", statusCode, id)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, retMsg)
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, resp.StatusCode, "dashboard id: "+strconv.FormatInt(id, 10))
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_DELETE_DASHBOARD_BY_ID, nil, nil, "")
 	return nil, ret
 }
 
-func DeleteByDashboardTitle(title string) (error, tracker.ReturnValue) {
-	resultStr, err, ret := get.GetAllDashboards()
+func DeleteByDashboardTitle(ctx context.Context, title string) (error, tracker.ReturnValue) {
+	resultStr, err, ret := get.GetAllDashboards(ctx)
 	if err != nil {
 		fmt.Println(err)
 		return err, ret
@@ -110,7 +109,7 @@ func DeleteByDashboardTitle(title string) (error, tracker.ReturnValue) {
 		if t == title {
 			id := gjson.Parse(existDashboard.String()).Get("id")
 			dashboardId, _ := strconv.ParseInt(id.String(), 10, 64)
-			err, ret := DeleteDashboardByID(dashboardId)
+			err, ret := DeleteDashboardByID(ctx, dashboardId)
 			if err != nil {
 				fmt.Println(err)
 				return err, ret
@@ -128,6 +127,7 @@ func DeleteByDashboardTitle(title string) (error, tracker.ReturnValue) {
 
 func init() {
 	DeleteCmd.AddCommand(dashboardCmd)
+	addSelectorFlags(dashboardCmd)
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package delete
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/cmd/get"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var deploymentCmd = &cobra.Command{
+	Use:     "deployment",
+	Short:   "Delete a deployment of an APM application by id.",
+	Example: "nr delete deployment <id> --app <name|id>",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
+			var err = fmt.Errorf("%q looks like a non-number.\n", args[0])
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		app, _ := cmd.Flags().GetString("app")
+		appID, err := get.GetApplicationID(ctx, client, app)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		id, _ := strconv.ParseInt(args[0], 10, 64)
+		resp, err := client.Deployments.DeleteByID(ctx, appID, id)
+		if err != nil {
+			fmt.Printf("Failed to delete deployment %v, %v\n", id, err)
+			os.Exit(1)
+			return
+		}
+		fmt.Println(resp.Status)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	DeleteCmd.AddCommand(deploymentCmd)
+
+	deploymentCmd.Flags().String("app", "", "Name or id of the application")
+	deploymentCmd.MarkFlagRequired("app")
+}
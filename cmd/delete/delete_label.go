@@ -0,0 +1,65 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package delete
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var labelCmd = &cobra.Command{
+	Use:     "label",
+	Short:   "Delete a label by key, and its links to applications and servers.",
+	Example: "nr delete label <category:name>",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		resp, err := client.Labels.DeleteByKey(ctx, args[0])
+		if err != nil {
+			tracker.AppendRESTCallError(client.Labels, tracker.OPERATION_NAME_DELETE_LABEL, err, "label key:"+args[0])
+			fmt.Printf("Failed to delete label %q, %v\n", args[0], err)
+			os.Exit(1)
+			return
+		}
+		tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_DELETE_LABEL, resp.StatusCode, "label key:"+args[0])
+		fmt.Println(resp.Status)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	DeleteCmd.AddCommand(labelCmd)
+}
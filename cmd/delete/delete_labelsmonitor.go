@@ -43,9 +43,10 @@ var labelsmonitorsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		monitorId := string(args[0])
 		label := string(args[1])
-		err, returnValue := DeleteLabelFromMonitor(monitorId, label)
+		err, returnValue := DeleteLabelFromMonitor(ctx, monitorId, label)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -62,28 +63,21 @@ var labelsmonitorsCmd = &cobra.Command{
 	},
 }
 
-func DeleteLabelFromMonitor(monitorId string, label string) (error, tracker.ReturnValue) {
+func DeleteLabelFromMonitor(ctx context.Context, monitorId string, label string) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("labelSynthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, err := client.LabelsSynthetics.DeleteLabelFromMonitor(context.Background(), monitorId, label)
+	resp, err := client.LabelsSynthetics.DeleteLabelFromMonitor(ctx, monitorId, label)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, err, "label:"+label+",monitor id:"+monitorId)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, resp.StatusCode, "label:"+label+",monitor id:"+monitorId)
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Remove label '%s' to monitor '%s'\n", statusCode, label, monitorId)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, resp.StatusCode, "label:"+label+",monitor id:"+monitorId)
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_DELETE_LABEL_FROM_MONITOR, nil, nil, "")
 	return nil, ret
 }
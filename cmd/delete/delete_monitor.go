@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/tracker"
 	"github.com/IBM/newrelic-cli/utils"
 )
@@ -30,17 +31,27 @@ var monitorCmd = &cobra.Command{
 	Use:     "monitor",
 	Short:   "Delete one synthetics monitor by id.",
 	Aliases: []string{"m"},
-	Example: "nr delete monitor <id>",
+	Example: "nr delete monitor <id>\nnr delete monitor --selector 'name=~^tmp-' --dry-run",
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 1 {
-			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
-			fmt.Println(err)
-			os.Exit(1)
+		if err := checkIDArgs(cmd, args); err != nil {
 			return err
 		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		if utils.HasSelector(cmd) {
+			monitors, err, ret := get.GetMonitors(ctx)
+			if !ret.IsContinue {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			deleteSelected(cmd, "monitor", selectItems(cmd, monitors), func(i int, id string) error {
+				return retError(DeleteMonitorByID(ctx, id))
+			})
+			return
+		}
 		client, err := utils.GetNewRelicClient("synthetics")
 		if err != nil {
 			fmt.Println(err)
@@ -48,44 +59,32 @@ var monitorCmd = &cobra.Command{
 			return
 		}
 		id := string(args[0])
-		resp, err := client.SyntheticsMonitors.DeleteByID(context.Background(), &id)
+		_, err = client.SyntheticsMonitors.DeleteByID(ctx, &id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			fmt.Println(resp.StatusCode)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
 
 		os.Exit(0)
 	},
 }
 
-func DeleteMonitorByID(id string) (error, tracker.ReturnValue) {
+func DeleteMonitorByID(ctx context.Context, id string) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_MONITOR, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return err, ret
 	}
-	resp, err := client.SyntheticsMonitors.DeleteByID(context.Background(), &id)
+	resp, err := client.SyntheticsMonitors.DeleteByID(ctx, &id)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_MONITOR, err, "monitor id: "+id)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_MONITOR, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_MONITOR, resp.StatusCode, "monitor id: "+id)
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Remove monitor id '%s'\n", statusCode, id)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_DELETE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_DELETE_MONITOR, resp.StatusCode, "monitor id: "+id)
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_DELETE_MONITOR, nil, nil, "")
 	return nil, ret
@@ -93,6 +92,8 @@ func DeleteMonitorByID(id string) (error, tracker.ReturnValue) {
 
 func init() {
 	DeleteCmd.AddCommand(monitorCmd)
+	addSelectorFlags(monitorCmd)
+	utils.AddLabelFlag(monitorCmd.Flags())
 
 	// Here you will define your flags and configuration settings.
 
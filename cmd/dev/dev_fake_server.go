@@ -0,0 +1,121 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/IBM/newrelic-cli/newrelic/nrtest"
+	"github.com/spf13/cobra"
+)
+
+var fakeServerCmd = &cobra.Command{
+	Use:   "fake-server",
+	Short: "Run an in-memory fake of the NewRelic APIs.",
+	Long: `Run an in-memory fake of the NewRelic APIs until interrupted.
+
+It prints the environment variables pointing the nr CLI at the fake, e.g.
+    nr dev fake-server > fake.env &
+    sleep 1; set -a; . ./fake.env; set +a
+    nr get alertspolicies
+All objects are lost when the fake stops.
+
+--seed loads objects the CLI can't create, like incidents, from a JSON file
+mapping collections to their objects, e.g.
+    {"alerts_incidents": [{"id": 1, "opened_at": 1528794000000}]}`,
+	Example: "nr dev fake-server --listen 127.0.0.1:8080",
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		listen, _ := flags.GetString("listen")
+		apiKey, _ := flags.GetString("api-key")
+		insertKey, _ := flags.GetString("insert-key")
+		pageSize, _ := flags.GetInt("page-size")
+		seed, _ := flags.GetString("seed")
+
+		listener, err := net.Listen("tcp", listen)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		server := nrtest.NewUnstartedServer()
+		server.Listener.Close()
+		server.Listener = listener
+		server.APIKey = apiKey
+		server.InsertKey = insertKey
+		server.PageSize = pageSize
+		if seed != "" {
+			if err := seedServer(server, seed); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+		}
+		server.Start()
+		defer server.Close()
+
+		if apiKey == "" {
+			apiKey = "fake"
+		}
+		endpoints := server.Endpoints()
+		fmt.Printf("export NEW_RELIC_APIKEY=%s\n", apiKey)
+		fmt.Printf("export NEW_RELIC_API_URL=%s\n", endpoints.API)
+		fmt.Printf("export NEW_RELIC_SYNTHETICS_URL=%s\n", endpoints.Synthetics)
+		fmt.Printf("export NEW_RELIC_LABEL_SYNTHETICS_URL=%s\n", endpoints.LabelSynthetics)
+		fmt.Printf("export NEW_RELIC_INSIGHTS_URL=%s\n", endpoints.Insights)
+		fmt.Printf("export NEW_RELIC_INFRASTRUCTURE_URL=%s\n", endpoints.Infrastructure)
+		fmt.Printf("export NEW_RELIC_GRAPHQL_URL=%s\n", endpoints.GraphQL)
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+	},
+}
+
+// seedServer seeds server with the collections of the JSON file.
+func seedServer(server *nrtest.Server, file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var collections map[string][]interface{}
+	if err := json.Unmarshal(data, &collections); err != nil {
+		return fmt.Errorf("Unable to decode %q: %v", file, err)
+	}
+	for collection, objs := range collections {
+		if err := server.Seed(collection, objs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	DevCmd.AddCommand(fakeServerCmd)
+
+	fakeServerCmd.Flags().StringP("listen", "l", "127.0.0.1:0", "Address to listen on, a random port by default.")
+	fakeServerCmd.Flags().String("api-key", "", "API key the fake requires as X-Api-Key. Any key is accepted if not set.")
+	fakeServerCmd.Flags().String("insert-key", "", "Insert key the fake requires to insert custom events. Any key is accepted if not set.")
+	fakeServerCmd.Flags().String("seed", "", "JSON file of objects to start with, by collection, e.g. users or alerts_incidents.")
+	fakeServerCmd.Flags().Int("page-size", nrtest.DefaultPageSize, "Number of items per page of the lists.")
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+// alertNames resolves the names of alert policies, conditions and APM
+// applications by id, listing them on first use. A listing error is printed once and the names
+// are left empty.
+type alertNames struct {
+	ctx    context.Context
+	client *newrelic.Client
+
+	policies map[int64]string
+	// conditions holds the condition names by policy id.
+	conditions map[int64]map[int64]string
+	// incidentPolicies holds the policy ids by incident id.
+	incidentPolicies map[int64]*int64
+	applications     map[string]string
+}
+
+func newAlertNames(ctx context.Context, client *newrelic.Client) *alertNames {
+	return &alertNames{ctx: ctx, client: client, conditions: map[int64]map[int64]string{}}
+}
+
+// policy returns the name of the policy id.
+func (n *alertNames) policy(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	if n.policies == nil {
+		n.policies = map[int64]string{}
+		policyList, err := n.client.AlertsPolicies.ListAllPages(n.ctx, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to get the alert policy names: %v\n", err)
+			return ""
+		}
+		for _, policy := range policyList.AlertsPolicies {
+			if policy.ID != nil && policy.Name != nil {
+				n.policies[*policy.ID] = *policy.Name
+			}
+		}
+	}
+	return n.policies[*id]
+}
+
+// condition returns the name of the condition id of the policy policyID,
+// of any category.
+func (n *alertNames) condition(policyID *int64, id *int64) string {
+	if policyID == nil || id == nil {
+		return ""
+	}
+	names, ok := n.conditions[*policyID]
+	if !ok {
+		names = map[int64]string{}
+		n.conditions[*policyID] = names
+		opt := &newrelic.AlertsConditionsOptions{PolicyIDOptions: strconv.FormatInt(*policyID, 10)}
+		conditionList, err := n.client.AlertsConditions.ListAllPages(n.ctx, opt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to get the alert condition names of policy %d: %v\n", *policyID, err)
+			return ""
+		}
+		// Every category has its own list, like conditions or
+		// nrql_conditions, of objects with an id and a name.
+		data, _ := json.Marshal(conditionList)
+		gjson.ParseBytes(data).ForEach(func(key, list gjson.Result) bool {
+			if !list.IsArray() {
+				return true
+			}
+			for _, condition := range list.Array() {
+				names[condition.Get("id").Int()] = condition.Get("name").String()
+			}
+			return true
+		})
+	}
+	return names[*id]
+}
+
+// incidentPolicy returns the name of the policy of the incident id.
+func (n *alertNames) incidentPolicy(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	if n.incidentPolicies == nil {
+		n.incidentPolicies = map[int64]*int64{}
+		incidentList, err := n.client.AlertsIncidents.ListAllPages(n.ctx, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to get the alert incidents: %v\n", err)
+			return ""
+		}
+		for _, incident := range incidentList.Incidents {
+			if incident.ID != nil && incident.Links != nil {
+				n.incidentPolicies[*incident.ID] = incident.Links.PolicyID
+			}
+		}
+	}
+	return n.policy(n.incidentPolicies[*id])
+}
+
+// application returns the name of the APM application id, given as a
+// condition entity.
+func (n *alertNames) application(id string) string {
+	if n.applications == nil {
+		n.applications = map[string]string{}
+		applicationList, err := n.client.Applications.ListAllPages(n.ctx, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to get the application names: %v\n", err)
+			return ""
+		}
+		for _, application := range applicationList.Applications {
+			if application.ID != nil && application.Name != nil {
+				n.applications[strconv.FormatInt(*application.ID, 10)] = *application.Name
+			}
+		}
+	}
+	return n.applications[id]
+}
+
+// applicationConditionTypes are the types of the conditions and external
+// service conditions whose entities are APM or browser application ids.
+var applicationConditionTypes = map[string]bool{
+	"apm_app_metric":       true,
+	"apm_jvm_metric":       true,
+	"browser_metric":       true,
+	"apm_external_service": true,
+}
+
+// nameConditionEntities replaces the application ids in the entities of the
+// conditions of list with the application names, keeping the ids it cannot
+// resolve.
+func nameConditionEntities(names *alertNames, list *newrelic.AlertsConditionList) {
+	nameEntities := func(conditionType *string, entities []*string) {
+		if conditionType == nil || !applicationConditionTypes[*conditionType] {
+			return
+		}
+		for i, entity := range entities {
+			if entity == nil {
+				continue
+			}
+			if name := names.application(*entity); name != "" {
+				entities[i] = &name
+			}
+		}
+	}
+	if list.AlertsDefaultConditionList != nil {
+		for _, condition := range list.AlertsDefaultConditions {
+			nameEntities(condition.Type, condition.Entities)
+		}
+	}
+	if list.AlertsExternalServiceConditionList != nil {
+		for _, condition := range list.AlertsExternalServiceConditions {
+			nameEntities(condition.Type, condition.Entities)
+		}
+	}
+}
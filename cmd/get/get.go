@@ -17,12 +17,43 @@ package get
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/utils"
 )
 
 // GetCmd represents the get command
 var GetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Display one or many NewRelic resources.",
+	Long: `Display one or many NewRelic resources.
+
+The paths of -o jsonpath and -o go-template start at the root of the -o json
+output, which is an object holding the resources for all the commands but
+monitor and monitors:
+
+  alertschannels     {"channels": [...]}
+  alertsconditions   {"conditions": [...], "synthetics_conditions": [...], ...}
+  alertsevents       {"recent_events": [...]}
+  alertspolicies     {"policies": [...]}
+  application        {"application": {...}}, {"application_hosts": [...]} or {"application_instances": [...]}
+  applications       {"applications": [...]}
+  dashboard          {"dashboard": {...}}
+  dashboards         {"dashboards": [...]}
+  deployments        {"deployments": [...]}
+  entities           {"entities": [...]}
+  incidents          {"incidents": [...]}
+  label              {"label": {...}}
+  labels             {"labels": [...]}
+  labelsmonitors     {"monitorRefs": [...]}
+  metrics            {"metrics": [...]} or {"metric_data": {...}}
+  monitor            {...}
+  monitors           [...]
+  user               {"user": {...}}
+  users              {"users": [...]}
+  violations         {"violations": [...]}
+
+e.g. -o jsonpath='{.policies[*].name}' for alertspolicies, but
+-o jsonpath='{[*].name}' for monitors. A path matching nothing is an error.`,
 }
 
 func init() {
@@ -31,9 +62,11 @@ func init() {
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// GetCmd.PersistentFlags().String("foo", "", "A help for foo")
-	GetCmd.PersistentFlags().StringP("output", "o", "json", "Output format. table/json/yaml are supported")
+	GetCmd.PersistentFlags().StringP("output", "o", "json", "Output format. "+utils.OutputFormats+" are supported")
+	GetCmd.PersistentFlags().String("sort-by", "", "Sort the items by a field, given as a JSONPath like .name or {.options.frequency}")
+	utils.AddSelectorFlags(GetCmd.PersistentFlags())
 
-	GetCmd.PersistentFlags().StringP("type-condition", "t", "all", "Alert condition type. Only used for 'alertsconditions' command. all|conditions|synthetics|ext|plugin|nrql|infrastructure are supported")
+	GetCmd.PersistentFlags().StringP("type-condition", "t", "all", "Alert condition type. Only used for 'alertsconditions' command. all|conditions|synthetics|location|ext|plugin|nrql|infrastructure are supported")
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// GetCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
@@ -32,32 +32,32 @@ var alertschannelsCmd = &cobra.Command{
 	Short:   "Display all alerts_channels.",
 	Aliases: []string{"ac", "alertchannel", "alertschannel"},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-		alertsChannelList, resp, err := client.AlertsChannels.ListAll(context.Background(), nil)
-		if err != nil || resp.StatusCode >= 400 {
-			fmt.Printf("%v:%v", resp.Status, err)
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-
-		var printer utils.Printer
-
-		printer = &utils.JSONPrinter{}
-
-		var output string
-		flags := cmd.Flags()
-		if flags.Lookup("output") != nil {
-			output, err = cmd.Flags().GetString("output")
-			if output == "yaml" {
-				printer = &utils.YAMLPrinter{}
+		if utils.Streams(printer) {
+			err = client.AlertsChannels.ListPages(ctx, nil, func(alertsChannelList *newrelic.AlertsChannelList, resp *newrelic.Response) error {
+				printer.Print(alertsChannelList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
 			}
+			os.Exit(0)
 		}
-
+		alertsChannelList, err := client.AlertsChannels.ListAllPages(ctx, nil)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -69,7 +69,7 @@ var alertschannelsCmd = &cobra.Command{
 	},
 }
 
-func GetAllAlertsChannels() (*newrelic.AlertsChannelList, error, tracker.ReturnValue) {
+func GetAllAlertsChannels(ctx context.Context) (*newrelic.AlertsChannelList, error, tracker.ReturnValue) {
 	var opt *newrelic.AlertsChannelListOptions = &newrelic.AlertsChannelListOptions{}
 
 	client, err := utils.GetNewRelicClient()
@@ -81,32 +81,15 @@ func GetAllAlertsChannels() (*newrelic.AlertsChannelList, error, tracker.ReturnV
 
 	var allChannelList *newrelic.AlertsChannelList = &newrelic.AlertsChannelList{}
 
-	var pageCount = 1
-	for {
-		opt.Page = pageCount
-		alertsChannelList, resp, err := client.AlertsChannels.ListAll(context.Background(), opt)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.AlertsChannels, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, resp.StatusCode, "pageCount:"+strconv.Itoa(pageCount))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get alert channels, pageCount '%d'\n", statusCode, pageCount)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-
-		var channelListLen = len(alertsChannelList.AlertsChannels)
-		if channelListLen == 0 {
-			break
-		} else {
-			allChannelList.AlertsChannels = utils.MergeAlertChannelList(allChannelList.AlertsChannels, alertsChannelList.AlertsChannels)
-			pageCount++
-		}
+	err = client.AlertsChannels.ListPages(ctx, opt, func(alertsChannelList *newrelic.AlertsChannelList, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.AlertsChannels, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, resp.StatusCode, "pageCount:"+strconv.Itoa(opt.Page))
+		allChannelList.AlertsChannels = append(allChannelList.AlertsChannels, alertsChannelList.AlertsChannels...)
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, err, tracker.ERR_REST_CALL, "")
+		return nil, err, ret
 	}
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_ALERT_CHANNELS, nil, nil, "")
@@ -114,8 +97,8 @@ func GetAllAlertsChannels() (*newrelic.AlertsChannelList, error, tracker.ReturnV
 	return allChannelList, err, ret
 }
 
-func IsChannelNameExists(channelName string) (bool, *newrelic.AlertsChannel, error, tracker.ReturnValue) {
-	list, err, returnValue := GetAllAlertsChannels()
+func IsChannelNameExists(ctx context.Context, channelName string) (bool, *newrelic.AlertsChannel, error, tracker.ReturnValue) {
+	list, err, returnValue := GetAllAlertsChannels(ctx)
 	if returnValue.IsContinue == false {
 		return false, nil, err, returnValue
 	}
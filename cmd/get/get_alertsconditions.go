@@ -49,6 +49,7 @@ var alertsconditionsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
@@ -60,86 +61,55 @@ var alertsconditionsCmd = &cobra.Command{
 		conditionsOptions = new(newrelic.AlertsConditionsOptions)
 		conditionsOptions.PolicyIDOptions = strconv.FormatInt(id, 10)
 
-		var alertsConditionList *newrelic.AlertsConditionList
-
-		flags := cmd.Flags()
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
 
-		var conditionType string
-		var errConditionType error
-		if flags.Lookup("type-condition") != nil {
-			conditionType, errConditionType = cmd.Flags().GetString("type-condition")
-			if errConditionType != nil {
-				fmt.Printf("error accessing flag %s for command %s: %v\n", "type-condition", cmd.Name(), errConditionType)
-				os.Exit(1)
-				return
-			}
-			if conditionType == "plugins" || conditionType == "synthetics" || conditionType == "ext" || conditionType == "nrql" || conditionType == "conditions" || conditionType == "infrastructure" {
-				var cat newrelic.ConditionCategory
-				switch conditionType {
-				case "plugins":
-					cat = newrelic.ConditionPlugins
-				case "synthetics":
-					cat = newrelic.ConditionSynthetics
-				case "ext":
-					cat = newrelic.ConditionExternalService
-				case "nrql":
-					cat = newrelic.ConditionNRQL
-				case "infrastructure":
-					cat = newrelic.ConditionInfrastructure
-				default:
-					cat = newrelic.ConditionDefault
-				}
-				list, resp, err := client.AlertsConditions.List(context.Background(), conditionsOptions, cat)
-				if err != nil || resp.StatusCode >= 400 {
-					fmt.Printf("%v\n", err)
-					os.Exit(1)
-					return
-				}
-				alertsConditionList = list
-			} else {
-				list, err := client.AlertsConditions.ListAll(context.Background(), conditionsOptions)
-				if err != nil {
-					fmt.Printf("%v\n", err)
-					os.Exit(1)
-					return
-				}
-				alertsConditionList = list
-				// location_failure_conditions uses different methods for pagination,
-				// if a page requested doesn't exist, it returns the first page instead of empty entires.
-				// use seperate logic to get location_failure_conditions, instead of adding it in ListAll
-				list, resp, err := client.AlertsConditions.List(context.Background(), conditionsOptions, newrelic.ConditionLocation)
-				if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-					alertsConditionList.AlertsLocationConditionList = list.AlertsLocationConditionList
-				}
-			}
-		} else {
-			list, err := client.AlertsConditions.ListAll(context.Background(), conditionsOptions)
-			if err != nil {
-				fmt.Printf("%v", err)
+		conditionType, err := utils.GetArg(cmd, "type-condition")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		// No category lists all of them.
+		var cats []newrelic.ConditionCategory
+		switch conditionType {
+		case "plugins":
+			cats = append(cats, newrelic.ConditionPlugins)
+		case "synthetics":
+			cats = append(cats, newrelic.ConditionSynthetics)
+		case "location":
+			cats = append(cats, newrelic.ConditionLocation)
+		case "ext":
+			cats = append(cats, newrelic.ConditionExternalService)
+		case "nrql":
+			cats = append(cats, newrelic.ConditionNRQL)
+		case "infrastructure":
+			cats = append(cats, newrelic.ConditionInfrastructure)
+		case "conditions":
+			cats = append(cats, newrelic.ConditionDefault)
+		}
+
+		if utils.Streams(printer) {
+			if err := printConditionsPages(ctx, client, printer, conditionsOptions, cats...); err != nil {
+				fmt.Printf("%v\n", err)
 				os.Exit(1)
 				return
 			}
-			alertsConditionList = list
-			// location_failure_conditions uses different methods for pagination,
-			// if a page requested doesn't exist, it returns the first page instead of empty entires.
-			// use seperate logic to get location_failure_conditions, instead of adding it in ListAll
-			list, resp, err := client.AlertsConditions.List(context.Background(), conditionsOptions, newrelic.ConditionLocation)
-			if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-				alertsConditionList.AlertsLocationConditionList = list.AlertsLocationConditionList
-			}
+			os.Exit(0)
 		}
 
+		alertsConditionList, err := client.AlertsConditions.ListAllPages(ctx, conditionsOptions, cats...)
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			os.Exit(1)
 			return
 		}
-		printer, err := utils.NewPriter(cmd)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-			return
+		if utils.IsTabular(printer) {
+			nameConditionEntities(newAlertNames(ctx, client), alertsConditionList)
 		}
 		printer.Print(alertsConditionList, os.Stdout)
 
@@ -147,7 +117,17 @@ var alertsconditionsCmd = &cobra.Command{
 	},
 }
 
-func GetAllConditionsByAlertPolicyID(id int64) (*newrelic.AlertsConditionList, error, tracker.ReturnValue) {
+// printConditionsPages prints the conditions of the given categories page by
+// page, as they are fetched, the ones of all categories if none is given.
+func printConditionsPages(ctx context.Context, client *newrelic.Client, printer utils.Printer, opt *newrelic.AlertsConditionsOptions, cats ...newrelic.ConditionCategory) error {
+	err := client.AlertsConditions.ListCategoriesPages(ctx, opt, func(cat newrelic.ConditionCategory, list *newrelic.AlertsConditionList, resp *newrelic.Response) error {
+		printer.Print(list, os.Stdout)
+		return nil
+	}, cats...)
+	return err
+}
+
+func GetAllConditionsByAlertPolicyID(ctx context.Context, id int64) (*newrelic.AlertsConditionList, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -155,61 +135,23 @@ func GetAllConditionsByAlertPolicyID(id int64) (*newrelic.AlertsConditionList, e
 		return nil, err, ret
 	}
 
-	var allList *newrelic.AlertsConditionList = &newrelic.AlertsConditionList{}
-	allList.AlertsDefaultConditionList = &newrelic.AlertsDefaultConditionList{}
-	allList.AlertsExternalServiceConditionList = &newrelic.AlertsExternalServiceConditionList{}
-	allList.AlertsNRQLConditionList = &newrelic.AlertsNRQLConditionList{}
-	allList.AlertsPluginsConditionList = &newrelic.AlertsPluginsConditionList{}
-	allList.AlertsSyntheticsConditionList = &newrelic.AlertsSyntheticsConditionList{}
-
 	var conditionsOptions *newrelic.AlertsConditionsOptions
 	conditionsOptions = new(newrelic.AlertsConditionsOptions)
 	conditionsOptions.PolicyIDOptions = strconv.FormatInt(id, 10)
 
-	var pageCount = 1
-
-	for {
-		conditionsOptions.Page = pageCount
-
-		list, err := client.AlertsConditions.ListAll(context.Background(), conditionsOptions)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		var defaultConditionsLen = len(list.AlertsDefaultConditions)
-		var externalServiceConditionsLen = len(list.AlertsExternalServiceConditions)
-		var nrqlConditionsLen = len(list.AlertsNRQLConditions)
-		var pluginsConditionsLen = len(list.AlertsPluginsConditions)
-		var syntheticsConditionsLen = len(list.AlertsSyntheticsConditions)
-
-		if defaultConditionsLen == 0 && externalServiceConditionsLen == 0 && nrqlConditionsLen == 0 && pluginsConditionsLen == 0 && syntheticsConditionsLen == 0 {
-			break
-		} else {
-			//merge conditions list
-			allList = utils.MergeAlertConditionList(allList, list)
-			pageCount++
-		}
-	}
-
-	// location_failure_conditions uses different methods for pagination,
-	// if a page requested doesn't exist, it returns the first page instead of empty entires.
-	// use seperate logic to get location_failure_conditions
-	list, resp, err := client.AlertsConditions.List(context.Background(), conditionsOptions, newrelic.ConditionLocation)
-	if err != nil || resp.StatusCode >= 400 {
-		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, fmt.Errorf("%v.Response: %v. Error: %v.", newrelic.ConditionLocation, resp, err), tracker.ERR_REST_CALL, "")
+	allList, err := client.AlertsConditions.ListAllPages(ctx, conditionsOptions)
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, err, tracker.ERR_REST_CALL, "")
 		return nil, err, ret
 	}
-	allList.AlertsLocationConditionList = &newrelic.AlertsLocationConditionList{}
-	allList.AlertsLocationConditions = append(allList.AlertsLocationConditions, list.AlertsLocationConditions...)
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, nil, nil, "")
 
 	return allList, err, ret
 }
 
-func GetConditionsByAlertPolicyIDAndConditionType(id int64, cat newrelic.ConditionCategory) (*newrelic.AlertsConditionList, error, tracker.ReturnValue) {
+func GetConditionsByAlertPolicyIDAndConditionType(ctx context.Context, id int64, cat newrelic.ConditionCategory) (*newrelic.AlertsConditionList, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -222,53 +164,22 @@ func GetConditionsByAlertPolicyIDAndConditionType(id int64, cat newrelic.Conditi
 	alertsConditionList.AlertsNRQLConditionList = &newrelic.AlertsNRQLConditionList{}
 	alertsConditionList.AlertsPluginsConditionList = &newrelic.AlertsPluginsConditionList{}
 	alertsConditionList.AlertsSyntheticsConditionList = &newrelic.AlertsSyntheticsConditionList{}
+	alertsConditionList.AlertsLocationConditionList = &newrelic.AlertsLocationConditionList{}
 	alertsConditionList.AlertsInfrastructureConditionList = &newrelic.AlertsInfrastructureConditionList{}
 
 	var conditionsOptions *newrelic.AlertsConditionsOptions
 	conditionsOptions = new(newrelic.AlertsConditionsOptions)
 	conditionsOptions.PolicyIDOptions = strconv.FormatInt(id, 10)
 
-	var pageCount = 1
-
-	for {
-		conditionsOptions.Page = pageCount
-
-		list, resp, err := client.AlertsConditions.List(context.Background(), conditionsOptions, cat)
-		if err != nil {
-			fmt.Printf("%v\n", err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, resp.StatusCode, "pageCount:"+strconv.Itoa(pageCount))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get alert conditions, pageCount '%d'\n", statusCode, pageCount)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-
-		var size = 0
-		if cat == newrelic.ConditionDefault {
-			size = len(list.AlertsDefaultConditions)
-		} else if cat == newrelic.ConditionExternalService {
-			size = len(list.AlertsExternalServiceConditions)
-		} else if cat == newrelic.ConditionNRQL {
-			size = len(list.AlertsNRQLConditions)
-		} else if cat == newrelic.ConditionPlugins {
-			size = len(list.AlertsPluginsConditions)
-		} else if cat == newrelic.ConditionSynthetics {
-			size = len(list.AlertsSyntheticsConditions)
-		}
-
-		if size == 0 {
-			break
-		} else {
-			//merge conditions list
-			alertsConditionList = utils.MergeAlertConditionList(alertsConditionList, list)
-			pageCount++
-		}
+	err = client.AlertsConditions.ListPages(ctx, conditionsOptions, cat, func(list *newrelic.AlertsConditionList, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, resp.StatusCode, "pageCount:"+strconv.Itoa(conditionsOptions.Page))
+		alertsConditionList.Append(cat, list)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, err, tracker.ERR_REST_CALL, "")
+		return nil, err, ret
 	}
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_CONDITIONS_BY_POLICY_ID, nil, nil, "")
@@ -276,8 +187,8 @@ func GetConditionsByAlertPolicyIDAndConditionType(id int64, cat newrelic.Conditi
 	return alertsConditionList, err, ret
 }
 
-func IsConditionNameExists(alertPolicyID int64, condtionName string, cat newrelic.ConditionCategory) (bool, int64, error, tracker.ReturnValue) {
-	list, err, ret := GetConditionsByAlertPolicyIDAndConditionType(alertPolicyID, cat)
+func IsConditionNameExists(ctx context.Context, alertPolicyID int64, condtionName string, cat newrelic.ConditionCategory) (bool, int64, error, tracker.ReturnValue) {
+	list, err, ret := GetConditionsByAlertPolicyIDAndConditionType(ctx, alertPolicyID, cat)
 	if ret.IsContinue == false {
 		return false, -1, err, ret
 	}
@@ -312,6 +223,12 @@ func IsConditionNameExists(alertPolicyID int64, condtionName string, cat newreli
 				return true, *condition.ID, err, ret
 			}
 		}
+	} else if cat == newrelic.ConditionLocation {
+		for _, condition := range list.AlertsLocationConditions {
+			if *condition.Name == condtionName {
+				return true, *condition.ID, err, ret
+			}
+		}
 	} else if cat == newrelic.ConditionInfrastructure {
 		for _, condition := range list.AlertsInfrastructureConditions {
 			if *condition.Name == condtionName {
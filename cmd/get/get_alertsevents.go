@@ -0,0 +1,154 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var alertseventsCmd = &cobra.Command{
+	Use:     "alertsevents",
+	Short:   "Display the recent alerts events.",
+	Aliases: []string{"ae", "alertevent", "alertsevent"},
+	Example: "nr get alertsevents --incident <id>\nnr get alertsevents --entity-type Application --since 2h -o table",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		timeRange, err := utils.NewTimeRange(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		flags := cmd.Flags()
+		opt := &newrelic.AlertsEventListOptions{}
+		opt.IncidentID, _ = flags.GetInt64("incident")
+		opt.Product, _ = flags.GetString("product")
+		opt.EntityType, _ = flags.GetString("entity-type")
+		opt.EntityID, _ = flags.GetInt64("entity-id")
+		opt.EntityGroupID, _ = flags.GetInt64("entity-group-id")
+		opt.EventType, _ = flags.GetString("event-type")
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		// The API has no time filter, the events are filtered here.
+		if utils.Streams(printer) {
+			err = client.AlertsEvents.ListPages(ctx, opt, func(eventList *newrelic.AlertsEventList, resp *newrelic.Response) error {
+				printer.Print(eventsInRange(eventList, timeRange), os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		eventList, err := client.AlertsEvents.ListAllPages(ctx, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		eventList = eventsInRange(eventList, timeRange)
+		if utils.IsTabular(printer) {
+			printer.Print(eventRows(ctx, client, eventList), os.Stdout)
+		} else {
+			printer.Print(eventList, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// eventsInRange returns the events of eventList in timeRange.
+func eventsInRange(eventList *newrelic.AlertsEventList, timeRange utils.TimeRange) *newrelic.AlertsEventList {
+	if timeRange.IsZero() {
+		return eventList
+	}
+	inRange := &newrelic.AlertsEventList{RecentEvents: []*newrelic.RecentEvent{}}
+	for _, event := range eventList.RecentEvents {
+		if event.Timestamp != nil && timeRange.Contains(*event.Timestamp) {
+			inRange.RecentEvents = append(inRange.RecentEvents, event)
+		}
+	}
+	return inRange
+}
+
+// eventRow is an event as printed by -o table, wide and csv, with the name
+// of the policy of its incident.
+type eventRow struct {
+	ID          *int64  `json:"id"`
+	Timestamp   string  `json:"timestamp"`
+	EventType   *string `json:"event_type,omitempty"`
+	Priority    *string `json:"priority,omitempty"`
+	Product     *string `json:"product,omitempty"`
+	EntityType  *string `json:"entity_type,omitempty"`
+	EntityID    *int64  `json:"entity_id,omitempty"`
+	IncidentID  *int64  `json:"incident_id,omitempty"`
+	PolicyName  string  `json:"policy_name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// eventRows returns the rows of the events, naming the policies of their
+// incidents if the incidents and policies can be listed.
+func eventRows(ctx context.Context, client *newrelic.Client, eventList *newrelic.AlertsEventList) map[string][]eventRow {
+	names := newAlertNames(ctx, client)
+	rows := []eventRow{}
+	for _, event := range eventList.RecentEvents {
+		rows = append(rows, eventRow{
+			ID:          event.ID,
+			Timestamp:   formatEpochMillis(event.Timestamp),
+			EventType:   event.EventType,
+			Priority:    event.Priority,
+			Product:     event.Product,
+			EntityType:  event.EntityType,
+			EntityID:    event.EntityID,
+			IncidentID:  event.IncidentID,
+			PolicyName:  names.incidentPolicy(event.IncidentID),
+			Description: event.Description,
+		})
+	}
+	return map[string][]eventRow{"recent_events": rows}
+}
+
+func init() {
+	GetCmd.AddCommand(alertseventsCmd)
+
+	alertseventsCmd.Flags().Int64("incident", 0, "Only the events of this incident id")
+	alertseventsCmd.Flags().String("product", "", "Only the events of this product, e.g. APM, BROWSER, SYNTHETICS or NRQL")
+	alertseventsCmd.Flags().String("entity-type", "", "Only the events of this entity type, e.g. Application, Server or MonitoringLocation")
+	alertseventsCmd.Flags().Int64("entity-id", 0, "Only the events of this entity id")
+	alertseventsCmd.Flags().Int64("entity-group-id", 0, "Only the events of this entity group id")
+	alertseventsCmd.Flags().String("event-type", "", "Only the events of this type, e.g. NOTIFICATION, VIOLATION_OPEN or INCIDENT_CLOSE")
+	utils.AddTimeRangeFlags(alertseventsCmd.Flags())
+}
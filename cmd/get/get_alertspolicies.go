@@ -33,6 +33,7 @@ var alertspoliciesCmd = &cobra.Command{
 	Short:   "Display all alerts_policies.",
 	Aliases: []string{"ap", "alertpolicy", "alertspolicy"},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
@@ -45,13 +46,25 @@ var alertspoliciesCmd = &cobra.Command{
 				NameOptions: filter,
 			}
 		}
-		alertsPolicyList, resp, err := client.AlertsPolicies.ListAll(context.Background(), opt)
-		if err != nil || resp.StatusCode >= 400 {
-			fmt.Printf("%v:%v\n", resp.Status, err)
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-		printer, err := utils.NewPriter(cmd)
+		if utils.Streams(printer) {
+			err = client.AlertsPolicies.ListPages(ctx, opt, func(alertsPolicyList *newrelic.AlertsPolicyList, resp *newrelic.Response) error {
+				printer.Print(alertsPolicyList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		alertsPolicyList, err := client.AlertsPolicies.ListAllPages(ctx, opt)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -63,7 +76,7 @@ var alertspoliciesCmd = &cobra.Command{
 	},
 }
 
-func GetAllAlertPolicies() (*newrelic.AlertsPolicyList, error, tracker.ReturnValue) {
+func GetAllAlertPolicies(ctx context.Context) (*newrelic.AlertsPolicyList, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -76,40 +89,23 @@ func GetAllAlertPolicies() (*newrelic.AlertsPolicyList, error, tracker.ReturnVal
 	var opt *newrelic.AlertsPolicyListOptions
 	opt = &newrelic.AlertsPolicyListOptions{}
 
-	var pageCount = 1
-	for {
-		opt.Page = pageCount
-		alertsPolicyList, resp, err := client.AlertsPolicies.ListAll(context.Background(), opt)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_POLICIES, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_GET_ALERT_POLICIES, resp.StatusCode, "pageCount:"+strconv.Itoa(pageCount))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get one page alert policies, pageCount '%d'\n", statusCode, pageCount)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_POLICIES, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-		var policiesListLen = len(alertsPolicyList.AlertsPolicies)
-
-		if policiesListLen == 0 {
-			break
-		} else {
-			allAlertList.AlertsPolicies = utils.MergeAlertPolicyList(allAlertList.AlertsPolicies, alertsPolicyList.AlertsPolicies)
-
-			pageCount++
-		}
+	err = client.AlertsPolicies.ListPages(ctx, opt, func(alertsPolicyList *newrelic.AlertsPolicyList, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_GET_ALERT_POLICIES, resp.StatusCode, "pageCount:"+strconv.Itoa(opt.Page))
+		allAlertList.AlertsPolicies = append(allAlertList.AlertsPolicies, alertsPolicyList.AlertsPolicies...)
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_ALERT_POLICIES, err, tracker.ERR_REST_CALL, "")
+		return nil, err, ret
 	}
+
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_ALERT_POLICIES, nil, nil, "")
 	return allAlertList, err, ret
 }
 
-func IsPolicyNameExists(policyName string) (bool, *newrelic.AlertsPolicy, error, tracker.ReturnValue) {
-	allPolicyList, err, returnValue := GetAllAlertPolicies()
+func IsPolicyNameExists(ctx context.Context, policyName string) (bool, *newrelic.AlertsPolicy, error, tracker.ReturnValue) {
+	allPolicyList, err, returnValue := GetAllAlertPolicies(ctx)
 	if returnValue.IsContinue == false {
 		return false, nil, err, returnValue
 	}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var applicationCmd = &cobra.Command{
+	Use:     "application",
+	Short:   "Display a single APM application by id, or its hosts or instances.",
+	Aliases: []string{"app"},
+	Example: "nr get application <id>\nnr get application <id> --instances -o table",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		if _, err := strconv.ParseInt(args[0], 10, 64); err != nil {
+			var err = fmt.Errorf("%q looks like a non-number.\n", args[0])
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		id, _ := strconv.ParseInt(args[0], 10, 64)
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		hosts, _ := cmd.Flags().GetBool("hosts")
+		instances, _ := cmd.Flags().GetBool("instances")
+
+		switch {
+		case hosts:
+			hostList, err := client.Applications.ListAllHostsPages(ctx, id, nil)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			if utils.IsTabular(printer) {
+				printer.Print(hostRows(hostList), os.Stdout)
+			} else {
+				printer.Print(hostList, os.Stdout)
+			}
+		case instances:
+			instanceList, err := client.Applications.ListAllInstancesPages(ctx, id, nil)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			if utils.IsTabular(printer) {
+				printer.Print(instanceRows(instanceList), os.Stdout)
+			} else {
+				printer.Print(instanceList, os.Stdout)
+			}
+		default:
+			application, _, err := client.Applications.GetByID(ctx, id)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			if utils.IsTabular(printer) {
+				printer.Print(applicationRows([]*newrelic.Application{application.Application}), os.Stdout)
+			} else {
+				printer.Print(application, os.Stdout)
+			}
+		}
+
+		os.Exit(0)
+	},
+}
+
+// hostRow is an application host as printed by -o table, wide and csv.
+type hostRow struct {
+	ID           *int64  `json:"id"`
+	Host         *string `json:"host"`
+	HealthStatus *string `json:"health_status"`
+	summaryRow
+}
+
+func hostRows(hostList *newrelic.ApplicationHostList) map[string][]hostRow {
+	rows := []hostRow{}
+	for _, host := range hostList.ApplicationHosts {
+		rows = append(rows, hostRow{
+			ID:           host.ID,
+			Host:         host.Host,
+			HealthStatus: host.HealthStatus,
+			summaryRow:   newSummaryRow(host.Summary),
+		})
+	}
+	return map[string][]hostRow{"application_hosts": rows}
+}
+
+// instanceRow is an application instance as printed by -o table, wide and
+// csv.
+type instanceRow struct {
+	ID           *int64  `json:"id"`
+	Host         *string `json:"host"`
+	Port         *int64  `json:"port"`
+	HealthStatus *string `json:"health_status"`
+	summaryRow
+}
+
+func instanceRows(instanceList *newrelic.ApplicationInstanceList) map[string][]instanceRow {
+	rows := []instanceRow{}
+	for _, instance := range instanceList.ApplicationInstances {
+		rows = append(rows, instanceRow{
+			ID:           instance.ID,
+			Host:         instance.Host,
+			Port:         instance.Port,
+			HealthStatus: instance.HealthStatus,
+			summaryRow:   newSummaryRow(instance.Summary),
+		})
+	}
+	return map[string][]instanceRow{"application_instances": rows}
+}
+
+// GetApplicationID returns the id of the application app, given by id or by
+// name.
+func GetApplicationID(ctx context.Context, client *newrelic.Client, app string) (int64, error) {
+	if id, err := strconv.ParseInt(app, 10, 64); err == nil {
+		return id, nil
+	}
+	application, err := client.Applications.GetByName(ctx, app)
+	if err != nil {
+		return 0, err
+	}
+	if application == nil || application.ID == nil {
+		return 0, fmt.Errorf("Application %q not found.", app)
+	}
+	return *application.ID, nil
+}
+
+func init() {
+	GetCmd.AddCommand(applicationCmd)
+
+	applicationCmd.Flags().Bool("hosts", false, "Display the hosts of the application instead")
+	applicationCmd.Flags().Bool("instances", false, "Display the instances of the application instead")
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var applicationsCmd = &cobra.Command{
+	Use:     "applications",
+	Short:   "Display all APM applications.",
+	Aliases: []string{"apps"},
+	Example: "nr get applications --language java -o table",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		opt := &newrelic.ApplicationListOptions{}
+		opt.NameOptions, _ = cmd.Flags().GetString("name")
+		opt.HostOptions, _ = cmd.Flags().GetString("host")
+		opt.LanguageOptions, _ = cmd.Flags().GetString("language")
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.Streams(printer) {
+			err = client.Applications.ListPages(ctx, opt, func(applicationList *newrelic.ApplicationList, resp *newrelic.Response) error {
+				printer.Print(applicationList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		applicationList, err := client.Applications.ListAllPages(ctx, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(applicationRows(applicationList.Applications), os.Stdout)
+		} else {
+			printer.Print(applicationList, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// summaryRow holds the recent metrics of an application, a host or an
+// instance as printed by -o table, wide and csv.
+type summaryRow struct {
+	ApdexScore   *float64 `json:"apdex_score"`
+	ResponseTime *float64 `json:"response_time"`
+	Throughput   *float64 `json:"throughput"`
+	ErrorRate    *float64 `json:"error_rate"`
+}
+
+func newSummaryRow(summary *newrelic.ApplicationSummary) summaryRow {
+	if summary == nil {
+		return summaryRow{}
+	}
+	return summaryRow{
+		ApdexScore:   summary.ApdexScore,
+		ResponseTime: summary.ResponseTime,
+		Throughput:   summary.Throughput,
+		ErrorRate:    summary.ErrorRate,
+	}
+}
+
+// applicationRow is an application as printed by -o table, wide and csv,
+// with its health status and recent metrics.
+type applicationRow struct {
+	ID           *int64  `json:"id"`
+	Name         *string `json:"name"`
+	Language     *string `json:"language"`
+	HealthStatus *string `json:"health_status"`
+	Reporting    *bool   `json:"reporting"`
+	summaryRow
+}
+
+func applicationRows(applications []*newrelic.Application) map[string][]applicationRow {
+	rows := []applicationRow{}
+	for _, application := range applications {
+		rows = append(rows, applicationRow{
+			ID:           application.ID,
+			Name:         application.Name,
+			Language:     application.Language,
+			HealthStatus: application.HealthStatus,
+			Reporting:    application.Reporting,
+			summaryRow:   newSummaryRow(application.Summary),
+		})
+	}
+	return map[string][]applicationRow{"applications": rows}
+}
+
+func init() {
+	GetCmd.AddCommand(applicationsCmd)
+
+	applicationsCmd.Flags().String("name", "", "Only the applications whose name contains this")
+	applicationsCmd.Flags().String("host", "", "Only the applications running on this host")
+	applicationsCmd.Flags().String("language", "", "Only the applications of this language, like java or go")
+}
@@ -17,6 +17,7 @@ package get
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -25,7 +26,6 @@ import (
 	"github.com/IBM/newrelic-cli/utils"
 	"github.com/spf13/cobra"
 	"github.com/tidwall/gjson"
-	"github.com/tidwall/pretty"
 )
 
 // dashboardsCmd represents the dashboards command
@@ -49,10 +49,11 @@ var dashboardCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		id, _ := strconv.ParseInt(args[0], 10, 64)
 
-		result, err, ret := GetDashboardByID(id)
+		result, err, ret := GetDashboardByID(ctx, id)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -65,14 +66,19 @@ var dashboardCmd = &cobra.Command{
 			return
 		}
 
-		jsonStr := pretty.Pretty([]byte(result))
-		fmt.Printf("%s\n", string(jsonStr))
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer.Print(json.RawMessage(result), os.Stdout)
 
 		os.Exit(0)
 	},
 }
 
-func GetDashboardByID(id int64) (string, error, tracker.ReturnValue) {
+func GetDashboardByID(ctx context.Context, id int64) (string, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -80,20 +86,14 @@ func GetDashboardByID(id int64) (string, error, tracker.ReturnValue) {
 		return "", err, ret
 	}
 
-	resp, bytes, err := client.Dashboards.GetByID(context.Background(), id)
+	resp, bytes, err := client.Dashboards.GetByID(ctx, id)
 	if err != nil {
+		tracker.AppendRESTCallError(client.Dashboards, tracker.OPERATION_NAME_GET_DASHBOARD_BY_ID, err, "id:"+strconv.FormatInt(id, 10))
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_DASHBOARD_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return "", err, ret
 	}
-	tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_GET_DASHBOARD_BY_ID, resp.StatusCode, "id:"+strconv.FormatInt(id, 10))
-
-	if resp.StatusCode >= 400 {
-		var statusCode = resp.StatusCode
-		fmt.Printf("Response status code: %d. Get one specific dashboard, id '%d'\n", statusCode, id)
-		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_DASHBOARD_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-		return "", err, ret
-	}
+	tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_GET_DASHBOARD_BY_ID, resp.StatusCode, "id:"+strconv.FormatInt(id, 10))
 
 	strContent := string(bytes)
 
@@ -102,8 +102,8 @@ func GetDashboardByID(id int64) (string, error, tracker.ReturnValue) {
 	return strContent, nil, ret
 }
 
-func IsDashboardTitleExists(dashboardTitle string) (bool, string, error, tracker.ReturnValue) {
-	resultStr, err, returnValue := GetAllDashboards()
+func IsDashboardTitleExists(ctx context.Context, dashboardTitle string) (bool, string, error, tracker.ReturnValue) {
+	resultStr, err, returnValue := GetAllDashboards(ctx)
 	if returnValue.IsContinue == false {
 		return false, "", err, returnValue
 	}
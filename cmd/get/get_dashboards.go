@@ -17,13 +17,13 @@ package get
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/tidwall/gjson"
-	"github.com/tidwall/pretty"
 	"github.com/tidwall/sjson"
 
 	"github.com/IBM/newrelic-cli/newrelic"
@@ -37,8 +37,34 @@ var dashboardsCmd = &cobra.Command{
 	Short:   "Display all dashboards.",
 	Example: `* nr get dashboards`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
-		resultStr, err, ret := GetAllDashboards()
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.Streams(printer) {
+			client, err := utils.GetNewRelicClient()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			err = client.Dashboards.ListPages(ctx, nil, func(resp *newrelic.Response, bytes []byte) error {
+				printer.Print(json.RawMessage(bytes), os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+
+		resultStr, err, ret := GetAllDashboards(ctx)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -51,14 +77,13 @@ var dashboardsCmd = &cobra.Command{
 			return
 		}
 
-		result := pretty.Pretty([]byte(resultStr))
-		fmt.Printf("%s\n", string(result))
+		printer.Print(json.RawMessage(resultStr), os.Stdout)
 
 		os.Exit(0)
 	},
 }
 
-func GetAllDashboards() (string, error, tracker.ReturnValue) {
+func GetAllDashboards(ctx context.Context) (string, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -68,42 +93,23 @@ func GetAllDashboards() (string, error, tracker.ReturnValue) {
 
 	var dashboardJson = `{"dashboards":[]}`
 
-	var pageCount = 1
 	var opt *newrelic.DashboardListOptions = &newrelic.DashboardListOptions{}
 
-	for {
-		opt.Page = pageCount
+	err = client.Dashboards.ListPages(ctx, opt, func(resp *newrelic.Response, bytes []byte) error {
+		tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_GET_DASHBOARDS, resp.StatusCode, "pageCount:"+strconv.Itoa(opt.Page))
 
-		resp, bytes, err := client.Dashboards.ListAll(context.Background(), opt)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_DASHBOARDS, err, tracker.ERR_REST_CALL, "")
-			return "", err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_GET_DASHBOARDS, resp.StatusCode, "pageCount:"+strconv.Itoa(pageCount))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get one page dashboards, pageCount '%d'\n", statusCode, pageCount)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_DASHBOARDS, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return "", err, ret
-		}
-
-		strContent := string(bytes)
-		dashboardArr := gjson.Parse(strContent).Get("dashboards").Array()
-		var lenDashboards = len(dashboardArr)
-
-		if lenDashboards == 0 {
-			break
-		} else {
-			for _, dashboard := range dashboardArr {
-				if dashboard.String() != "" {
-					dashboardJson, _ = sjson.Set(dashboardJson, "dashboards.-1", dashboard.String())
-				}
+		dashboardArr := gjson.Parse(string(bytes)).Get("dashboards").Array()
+		for _, dashboard := range dashboardArr {
+			if dashboard.String() != "" {
+				dashboardJson, _ = sjson.Set(dashboardJson, "dashboards.-1", dashboard.String())
 			}
 		}
-		pageCount++
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_DASHBOARDS, err, tracker.ERR_REST_CALL, "")
+		return "", err, ret
 	}
 
 	var resultStr = ""
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var deploymentsCmd = &cobra.Command{
+	Use:     "deployments",
+	Short:   "Display the deployments of an APM application.",
+	Aliases: []string{"deployment", "deploy"},
+	Example: "nr get deployments --app <name|id> --since 7d -o table",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		app, _ := cmd.Flags().GetString("app")
+		appID, err := GetApplicationID(ctx, client, app)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		timeRange, err := utils.NewTimeRange(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.Streams(printer) {
+			err = client.Deployments.ListPages(ctx, appID, nil, func(deploymentList *newrelic.DeploymentList, resp *newrelic.Response) error {
+				printer.Print(deploymentsInRange(deploymentList, timeRange), os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		deploymentList, err := client.Deployments.ListAllPages(ctx, appID, nil)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer.Print(deploymentsInRange(deploymentList, timeRange), os.Stdout)
+
+		os.Exit(0)
+	},
+}
+
+// deploymentsInRange returns the deployments of deploymentList in timeRange.
+func deploymentsInRange(deploymentList *newrelic.DeploymentList, timeRange utils.TimeRange) *newrelic.DeploymentList {
+	if timeRange.IsZero() {
+		return deploymentList
+	}
+	inRange := &newrelic.DeploymentList{Deployments: []*newrelic.Deployment{}}
+	for _, deployment := range deploymentList.Deployments {
+		if deployment.Timestamp == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, *deployment.Timestamp)
+		if err == nil && timeRange.Contains(t.UnixNano()/int64(time.Millisecond)) {
+			inRange.Deployments = append(inRange.Deployments, deployment)
+		}
+	}
+	return inRange
+}
+
+func init() {
+	GetCmd.AddCommand(deploymentsCmd)
+
+	deploymentsCmd.Flags().String("app", "", "Name or id of the application")
+	deploymentsCmd.MarkFlagRequired("app")
+	utils.AddTimeRangeFlags(deploymentsCmd.Flags())
+}
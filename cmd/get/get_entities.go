@@ -0,0 +1,150 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var entitiesCmd = &cobra.Command{
+	Use:   "entities",
+	Short: "Search the NewRelic entities, like applications, monitors or hosts.",
+	Long: `Search the NewRelic entities, like APM applications, Synthetics monitors or
+hosts, through NerdGraph and display their GUID, name, type and tags.
+
+--query takes an entity search query, like "name LIKE 'web'". The key=value
+tags of --tag are searched by NerdGraph too, the other selections are made
+client side.`,
+	Aliases: []string{"entity"},
+	Example: `nr get entities --type APPLICATION -o table
+nr get entities --query "name LIKE 'web'" --tag env=production -o table`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient("graphql")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		entities, err := SearchEntities(ctx, client, cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.IsTabular(printer) {
+			// The entities are already selected, the rows no longer hold
+			// their tags as a list.
+			utils.Unselected(printer).Print(entityRows(entities), os.Stdout)
+		} else {
+			printer.Print(map[string][]*newrelic.Entity{"entities": entities}, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// SearchEntities returns the entities of the --type and --query flags of cmd
+// matching its --tag and --selector flags.
+func SearchEntities(ctx context.Context, client *newrelic.Client, cmd *cobra.Command) ([]*newrelic.Entity, error) {
+	opt := &newrelic.EntitySearchOptions{}
+	opt.Type, _ = cmd.Flags().GetString("type")
+	opt.Query, _ = cmd.Flags().GetString("query")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	for _, tag := range tags {
+		if i := strings.Index(tag, "="); i > 0 {
+			opt.Tags = append(opt.Tags, newrelic.TagValue{Key: tag[:i], Value: tag[i+1:]})
+		}
+	}
+	selector, err := utils.NewSelector(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := []*newrelic.Entity{}
+	err = client.Entities.SearchPages(ctx, opt, func(page []*newrelic.Entity, resp *newrelic.Response) error {
+		for _, entity := range page {
+			if selector == nil || selector.Matches(entity) {
+				entities = append(entities, entity)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// TagsText returns tags as key=value,value pairs separated by spaces.
+func TagsText(tags []*newrelic.Tag) string {
+	var pairs []string
+	for _, tag := range tags {
+		if tag.Key == nil {
+			continue
+		}
+		var values []string
+		for _, value := range tag.Values {
+			if value != nil {
+				values = append(values, *value)
+			}
+		}
+		pairs = append(pairs, *tag.Key+"="+strings.Join(values, ","))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// entityRow is an entity as printed by -o table, wide and csv.
+type entityRow struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+	Type *string `json:"type"`
+	Tags string  `json:"tags"`
+}
+
+func entityRows(entities []*newrelic.Entity) map[string][]entityRow {
+	rows := []entityRow{}
+	for _, entity := range entities {
+		rows = append(rows, entityRow{
+			GUID: entity.GUID,
+			Name: entity.Name,
+			Type: entity.Type,
+			Tags: TagsText(entity.Tags),
+		})
+	}
+	return map[string][]entityRow{"entities": rows}
+}
+
+func init() {
+	GetCmd.AddCommand(entitiesCmd)
+
+	entitiesCmd.Flags().String("type", "", "Only the entities of this type, like APPLICATION, MONITOR or HOST")
+	entitiesCmd.Flags().String("query", "", "Entity search query, like \"name LIKE 'web'\"")
+}
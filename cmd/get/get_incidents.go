@@ -0,0 +1,127 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var incidentsCmd = &cobra.Command{
+	Use:     "incidents",
+	Short:   "Display all alerts incidents.",
+	Aliases: []string{"incident", "in"},
+	Example: "nr get incidents --only-open -o table",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		onlyOpen, _ := cmd.Flags().GetBool("only-open")
+		opt := &newrelic.AlertsIncidentListOptions{OnlyOpen: onlyOpen}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.Streams(printer) {
+			err = client.AlertsIncidents.ListPages(ctx, opt, func(incidentList *newrelic.AlertsIncidentList, resp *newrelic.Response) error {
+				printer.Print(incidentList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		incidentList, err := client.AlertsIncidents.ListAllPages(ctx, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(incidentRows(ctx, client, incidentList), os.Stdout)
+		} else {
+			printer.Print(incidentList, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// incidentRow is an incident as printed by -o table, wide and csv, with the
+// name of its policy and its number of violations.
+type incidentRow struct {
+	ID                 *int64  `json:"id"`
+	PolicyID           *int64  `json:"policy_id,omitempty"`
+	PolicyName         string  `json:"policy_name"`
+	Violations         int     `json:"violations"`
+	IncidentPreference *string `json:"incident_preference,omitempty"`
+	OpenedAt           string  `json:"opened_at"`
+	ClosedAt           string  `json:"closed_at"`
+}
+
+// incidentRows returns the rows of the incidents, naming their policies if
+// the policies can be listed.
+func incidentRows(ctx context.Context, client *newrelic.Client, incidentList *newrelic.AlertsIncidentList) map[string][]incidentRow {
+	names := newAlertNames(ctx, client)
+	rows := []incidentRow{}
+	for _, incident := range incidentList.Incidents {
+		row := incidentRow{
+			ID:                 incident.ID,
+			IncidentPreference: incident.IncidentPreference,
+			OpenedAt:           formatEpochMillis(incident.OpenedAt),
+			ClosedAt:           formatEpochMillis(incident.ClosedAt),
+		}
+		if incident.Links != nil {
+			row.Violations = len(incident.Links.Violations)
+			row.PolicyID = incident.Links.PolicyID
+			row.PolicyName = names.policy(row.PolicyID)
+		}
+		rows = append(rows, row)
+	}
+	return map[string][]incidentRow{"incidents": rows}
+}
+
+// formatEpochMillis formats a time given in milliseconds since the epoch, as
+// returned by the alerts API, in the local time zone.
+func formatEpochMillis(millis *int64) string {
+	if millis == nil {
+		return ""
+	}
+	return time.Unix(0, *millis*int64(time.Millisecond)).Format(time.RFC3339)
+}
+
+func init() {
+	GetCmd.AddCommand(incidentsCmd)
+
+	incidentsCmd.Flags().Bool("only-open", false, "Only the open incidents")
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Display a single label by key, with its applications and servers.",
+	Long: `Display a single label by key, with its applications and servers.
+
+With -o table, wide or csv, every linked application and server is a row with
+its health status: green, orange, red or gray.`,
+	Example: "nr get label <category:name> -o table",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			var err = fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+			fmt.Println(err)
+			os.Exit(1)
+			return err
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		label, err := client.Labels.GetByKey(ctx, args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if label == nil {
+			fmt.Printf("Label %q not found.\n", args[0])
+			os.Exit(1)
+			return
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(labelLinkRows(label), os.Stdout)
+		} else {
+			printer.Print(&newrelic.LabelEntity{Label: label}, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// labelLinkRow is an application or server linked to a label, as printed by
+// -o table, wide and csv.
+type labelLinkRow struct {
+	Type         string `json:"type"`
+	ID           int64  `json:"id"`
+	HealthStatus string `json:"health_status"`
+}
+
+// labelLinkRows returns a row for every application and server of label.
+func labelLinkRows(label *newrelic.Label) map[string][]labelLinkRow {
+	rows := []labelLinkRow{}
+	if label.LabelLinks == nil {
+		return map[string][]labelLinkRow{"links": rows}
+	}
+	for _, link := range []struct {
+		linkType string
+		ids      []*int64
+		status   *newrelic.LabelsHealthStatus
+	}{
+		{"application", label.LabelLinks.Applications, label.LabelsApplicationHealthStatus},
+		{"server", label.LabelLinks.Servers, label.LabelsServerHealthStatus},
+	} {
+		statuses := healthStatuses(link.status)
+		for _, id := range link.ids {
+			if id != nil {
+				rows = append(rows, labelLinkRow{Type: link.linkType, ID: *id, HealthStatus: statuses[*id]})
+			}
+		}
+	}
+	return map[string][]labelLinkRow{"links": rows}
+}
+
+// healthStatuses returns the health status of the ids of the buckets of
+// status.
+func healthStatuses(status *newrelic.LabelsHealthStatus) map[int64]string {
+	statuses := map[int64]string{}
+	if status == nil {
+		return statuses
+	}
+	for name, ids := range map[string][]*int64{"green": status.Green, "orange": status.Orange, "red": status.Red, "gray": status.Gray} {
+		for _, id := range ids {
+			if id != nil {
+				statuses[*id] = name
+			}
+		}
+	}
+	return statuses
+}
+
+func init() {
+	GetCmd.AddCommand(labelCmd)
+}
@@ -35,27 +35,28 @@ var labelsCmd = &cobra.Command{
 * nr get labels -o json
 * nr get labels -o yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
-		labelArray, err, _ := GetLabels()
+		printer, err := utils.NewPriter(cmd)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-
-		var printer utils.Printer
-
-		printer = &utils.JSONPrinter{}
-
-		var output string
-		flags := cmd.Flags()
-		if flags.Lookup("output") != nil {
-			output, err = cmd.Flags().GetString("output")
-			if output == "yaml" {
-				printer = &utils.YAMLPrinter{}
+		if utils.Streams(printer) {
+			err, _ := GetLabelsPages(ctx, func(labelList *newrelic.LabelList) error {
+				printer.Print(labelList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
 			}
+			os.Exit(0)
 		}
 
+		labelArray, err, _ := GetLabels(ctx)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -67,51 +68,44 @@ var labelsCmd = &cobra.Command{
 	},
 }
 
-func GetLabels() (*newrelic.LabelList, error, tracker.ReturnValue) {
+func GetLabels(ctx context.Context) (*newrelic.LabelList, error, tracker.ReturnValue) {
+	var allLabelList *newrelic.LabelList = &newrelic.LabelList{}
+
+	err, ret := GetLabelsPages(ctx, func(labelList *newrelic.LabelList) error {
+		allLabelList.Labels = append(allLabelList.Labels, labelList.Labels...)
+		return nil
+	})
+	if ret.IsContinue == false {
+		return nil, err, ret
+	}
+
+	return allLabelList, err, ret
+}
+
+// GetLabelsPages calls fn with every page of the labels, as they are fetched.
+func GetLabelsPages(ctx context.Context, fn func(*newrelic.LabelList) error) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_LABELS, err, tracker.ERR_CREATE_NR_CLINET, "")
-		return nil, err, ret
+		return err, ret
 	}
 
 	var opt *newrelic.LabelListOptions
 	opt = &newrelic.LabelListOptions{}
 
-	var allLabelList *newrelic.LabelList = &newrelic.LabelList{}
-
-	var pageCount = 1
-	for {
-		opt.Page = pageCount
-		labelList, resp, err := client.Labels.ListAll(context.Background(), opt)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_LABELS, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_GET_LABELS, resp.StatusCode, "pageCount:"+strconv.Itoa(pageCount))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get one page labels, pageCount '%d'\n", statusCode, pageCount)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_LABELS, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-		var labelListLen = len(labelList.Labels)
-
-		if labelListLen == 0 {
-			break
-		} else {
-			allLabelList.Labels = utils.MergeLabelList(allLabelList.Labels, labelList.Labels)
-
-			pageCount++
-		}
+	err = client.Labels.ListPages(ctx, opt, func(labelList *newrelic.LabelList, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.Labels, tracker.OPERATION_NAME_GET_LABELS, resp.StatusCode, "pageCount:"+strconv.Itoa(opt.Page))
+		return fn(labelList)
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_LABELS, err, tracker.ERR_REST_CALL, "")
+		return err, ret
 	}
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_LABELS, nil, nil, "")
-
-	return allLabelList, err, ret
+	return nil, ret
 }
 
 func init() {
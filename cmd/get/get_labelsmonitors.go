@@ -45,100 +45,99 @@ var labelsmonitorsCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		label := args[0]
 
-		monitorRefList, err, ret := GetMonitorsByLabel(label)
+		printer, err := utils.NewPriter(cmd)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-
-		if ret.IsContinue == false {
-			os.Exit(1)
-			return
-		}
-
-		var printer utils.Printer
-
-		printer = &utils.JSONPrinter{}
-
-		var output string
-		flags := cmd.Flags()
-		if flags.Lookup("output") != nil {
-			output, err = cmd.Flags().GetString("output")
-			if output == "yaml" {
-				printer = &utils.YAMLPrinter{}
+		if utils.Streams(printer) {
+			_, ret := GetMonitorsByLabelPages(ctx, label, func(monitorRefList *newrelic.MonitorRefList) error {
+				printer.Print(monitorRefList, os.Stdout)
+				return nil
+			})
+			if ret.IsContinue == false {
+				os.Exit(1)
+				return
 			}
+			os.Exit(0)
 		}
 
+		monitorRefList, err, ret := GetMonitorsByLabel(ctx, label)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
+
+		if ret.IsContinue == false {
+			os.Exit(1)
+			return
+		}
 		printer.Print(monitorRefList, os.Stdout)
 
 		os.Exit(0)
 	},
 }
 
-func GetMonitorsByLabel(label string) (*newrelic.MonitorRefList, error, tracker.ReturnValue) {
+func GetMonitorsByLabel(ctx context.Context, label string) (*newrelic.MonitorRefList, error, tracker.ReturnValue) {
+	var allMonitorRefList *newrelic.MonitorRefList = &newrelic.MonitorRefList{}
+
+	err, ret := GetMonitorsByLabelPages(ctx, label, func(monitorRefList *newrelic.MonitorRefList) error {
+		allMonitorRefList.MonitorRefs = append(allMonitorRefList.MonitorRefs, monitorRefList.MonitorRefs...)
+		return nil
+	})
+	if ret.IsContinue == false {
+		return nil, err, ret
+	}
+	return allMonitorRefList, err, ret
+}
+
+// GetMonitorsByLabelPages calls fn with every page of the monitors having
+// label, as they are fetched.
+func GetMonitorsByLabelPages(ctx context.Context, label string, fn func(*newrelic.MonitorRefList) error) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("labelSynthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, err, tracker.ERR_CREATE_NR_CLINET, "")
-		return nil, err, ret
+		return err, ret
 	}
 	var opt *newrelic.PageLimitOptions
-	opt = &newrelic.PageLimitOptions{}
-	var pageSize int = 20
-	var pageOffset int = 0
-	var allMonitorRefList *newrelic.MonitorRefList = &newrelic.MonitorRefList{}
-	for {
-		opt.Limit = pageSize
-		opt.Offset = pageOffset
-		labelSynthetics, resp, err := client.LabelsSynthetics.GetMonitorsByLabel(context.Background(), opt, label)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-		tracker.AppendRESTCallResult(client.LabelsSynthetics, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, resp.StatusCode, "pageSize:"+strconv.Itoa(pageSize)+",pageOffset:"+strconv.Itoa(pageOffset))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get monitors by specific label, pageSize '%d', pageOffset '%d'\n", statusCode, pageSize, pageOffset)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-
-		var refsLen = len(labelSynthetics.PagedData.MonitorRefs)
-		if refsLen == 0 {
-			break
-		} else {
-			allMonitorRefList.MonitorRefs = utils.MergeMonitorReflList(allMonitorRefList.MonitorRefs, labelSynthetics.PagedData.MonitorRefs)
+	opt = &newrelic.PageLimitOptions{Limit: 20}
 
-			pageOffset = pageOffset + pageSize
+	err = client.LabelsSynthetics.GetMonitorsByLabelPages(ctx, opt, label, func(labelSynthetics *newrelic.LabelSynthetics, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.LabelsSynthetics, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, resp.StatusCode, "pageSize:"+strconv.Itoa(opt.Limit)+",pageOffset:"+strconv.Itoa(opt.Offset))
+		if labelSynthetics.PagedData == nil {
+			return nil
 		}
+		return fn(labelSynthetics.PagedData)
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, err, tracker.ERR_REST_CALL, "")
+		return err, ret
 	}
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_MONITORS_BY_LABEL, nil, nil, "")
-	return allMonitorRefList, err, ret
+	return nil, ret
 }
 
-func GetLabelsByMonitorID(monitorId string) ([]*string, error, tracker.ReturnValue) {
+func GetLabelsByMonitorID(ctx context.Context, monitorId string) ([]*string, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_LABELS_BY_MONITOR_ID, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return nil, err, ret
 	}
-	monitor, _, err := client.SyntheticsMonitors.GetByID(context.Background(), monitorId)
+	monitor, _, err := client.SyntheticsMonitors.GetByID(ctx, monitorId)
 
 	var labels []*string
 
 	//get all labels
-	lablesArray, err, returnValue := GetLabels()
+	lablesArray, err, returnValue := GetLabels(ctx)
 	if returnValue.IsContinue == false {
 		return nil, err, returnValue
 	}
@@ -147,7 +146,7 @@ func GetLabelsByMonitorID(monitorId string) ([]*string, error, tracker.ReturnVal
 		l := lablesArray.Labels[index]
 		key := fmt.Sprintf("%v:%v", *l.Category, *l.Name)
 
-		labelSynthetics, err, returnValue := GetMonitorsByLabel(key)
+		labelSynthetics, err, returnValue := GetMonitorsByLabel(ctx, key)
 		if returnValue.IsContinue == false {
 			return nil, err, returnValue
 		}
@@ -167,13 +166,8 @@ func GetLabelsByMonitorID(monitorId string) ([]*string, error, tracker.ReturnVal
 				//get monitor id
 				mId := *ref.ID
 				if mId == (*monitor.ID) {
-					labLen := len(monitor.Labels)
-					var newLabelList = make([]*string, (labLen + 1))
-					for i := 0; i < labLen; i++ {
-						newLabelList[i] = monitor.Labels[i]
-					}
-					newLabelList[labLen] = &key
-					labels = utils.MergeStringList(labels, newLabelList)
+					labels = append(labels, monitor.Labels...)
+					labels = append(labels, &key)
 				}
 			}
 		} else {
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Display the metric names or the metric data of an APM application.",
+	Long: `Display the metric names or the metric data of an APM application or of one of its hosts.
+
+Without --names, the names of the metrics and of their values are listed. With
+--names, the timeslices of the values of these metrics are displayed, one row
+per metric and timeslice in table and csv output.`,
+	Aliases: []string{"metric"},
+	Example: `nr get metrics --app <name|id> --match Http
+nr get metrics --app <name|id> --names HttpDispatcher --values average_response_time,call_count --since 1h -o table`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		app, _ := cmd.Flags().GetString("app")
+		appID, err := GetApplicationID(ctx, client, app)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		var hostID int64
+		if host, _ := cmd.Flags().GetString("host"); host != "" {
+			if hostID, err = getApplicationHostID(ctx, client, appID, host); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+		}
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		names, _ := cmd.Flags().GetStringSlice("names")
+		if len(names) == 0 {
+			match, _ := cmd.Flags().GetString("match")
+			nameList, err := client.Metrics.ListAllNamesPages(ctx, appID, hostID, &newrelic.MetricNameListOptions{NameOptions: match})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			printer.Print(nameList, os.Stdout)
+			os.Exit(0)
+		}
+
+		timeRange, err := utils.NewTimeRange(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if timeRange.Since.IsZero() && !timeRange.Until.IsZero() {
+			fmt.Println("--until requires --since.")
+			os.Exit(1)
+			return
+		}
+		opt := &newrelic.MetricDataOptions{Names: names, From: timeRange.Since, To: timeRange.Until}
+		opt.Values, _ = cmd.Flags().GetStringSlice("values")
+		opt.Period, _ = cmd.Flags().GetInt64("period")
+		opt.Summarize, _ = cmd.Flags().GetBool("summarize")
+
+		var data *newrelic.MetricDataEntity
+		if hostID != 0 {
+			data, _, err = client.Metrics.GetDataForHost(ctx, appID, hostID, opt)
+		} else {
+			data, _, err = client.Metrics.GetData(ctx, appID, opt)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if data.MetricData != nil && len(data.MetricData.MetricsNotFound) > 0 {
+			var notFound []string
+			for _, name := range data.MetricData.MetricsNotFound {
+				if name != nil {
+					notFound = append(notFound, *name)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "Metrics not found: %s\n", strings.Join(notFound, ", "))
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(timesliceRows(data.MetricData, opt.Values), os.Stdout)
+		} else {
+			printer.Print(data, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// getApplicationHostID returns the id of the host of the application
+// applicationID, given by id or by host name.
+func getApplicationHostID(ctx context.Context, client *newrelic.Client, applicationID int64, host string) (int64, error) {
+	if id, err := strconv.ParseInt(host, 10, 64); err == nil {
+		return id, nil
+	}
+	hostList, err := client.Applications.ListAllHostsPages(ctx, applicationID, &newrelic.ApplicationHostListOptions{HostnameOptions: host})
+	if err != nil {
+		return 0, err
+	}
+	for _, h := range hostList.ApplicationHosts {
+		if h.Host != nil && *h.Host == host && h.ID != nil {
+			return *h.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("Host %q of application %d not found.", host, applicationID)
+}
+
+// timesliceRows returns a row per metric and timeslice, with its name, from,
+// to and a column per value, as printed by -o table, wide and csv. The values
+// are the ones asked for in their order, else all of them sorted by name.
+func timesliceRows(data *newrelic.MetricData, values []string) json.RawMessage {
+	if data == nil {
+		data = &newrelic.MetricData{}
+	}
+	if len(values) == 0 {
+		seen := map[string]bool{}
+		for _, metric := range data.Metrics {
+			for _, timeslice := range metric.Timeslices {
+				for value := range timeslice.Values {
+					if !seen[value] {
+						seen[value] = true
+						values = append(values, value)
+					}
+				}
+			}
+		}
+		sort.Strings(values)
+	}
+
+	// The rows are written as JSON to keep their columns in order.
+	var rows bytes.Buffer
+	writeField := func(name string, value interface{}) {
+		key, _ := json.Marshal(name)
+		v, _ := json.Marshal(value)
+		rows.Write(key)
+		rows.WriteString(":")
+		rows.Write(v)
+	}
+	rows.WriteString(`{"metrics":[`)
+	for _, metric := range data.Metrics {
+		for _, timeslice := range metric.Timeslices {
+			if rows.Len() > len(`{"metrics":[`) {
+				rows.WriteString(",")
+			}
+			rows.WriteString("{")
+			writeField("name", metric.Name)
+			rows.WriteString(",")
+			writeField("from", timeslice.From)
+			rows.WriteString(",")
+			writeField("to", timeslice.To)
+			for _, value := range values {
+				rows.WriteString(",")
+				writeField(value, timeslice.Values[value])
+			}
+			rows.WriteString("}")
+		}
+	}
+	rows.WriteString("]}")
+	return json.RawMessage(rows.Bytes())
+}
+
+func init() {
+	GetCmd.AddCommand(metricsCmd)
+
+	metricsCmd.Flags().String("app", "", "Name or id of the application")
+	metricsCmd.MarkFlagRequired("app")
+	metricsCmd.Flags().String("host", "", "Name or id of a host of the application, to display the metrics of this host only")
+	metricsCmd.Flags().String("match", "", "Without --names, only the metric names containing this")
+	metricsCmd.Flags().StringSlice("names", nil, "Names of the metrics to display the data of, separated by commas")
+	metricsCmd.Flags().StringSlice("values", nil, "Names of the values to display, like average_response_time or call_count, separated by commas. Default is all of them")
+	metricsCmd.Flags().Int64("period", 0, "Length of the timeslices in seconds. Default depends on the time range")
+	metricsCmd.Flags().Bool("summarize", false, "Display one timeslice over the whole time range")
+	utils.AddTimeRangeFlags(metricsCmd.Flags())
+}
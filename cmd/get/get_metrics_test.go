@@ -0,0 +1,85 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+func TestTimesliceRows(t *testing.T) {
+	// Metric data in the REST v2 metric data documentation.
+	body := `{
+  "metrics": [
+    {
+      "name": "HttpDispatcher",
+      "timeslices": [
+        {"from": "2018-06-12T09:00:00+00:00", "to": "2018-06-12T09:30:00+00:00", "values": {"average_response_time": 120, "call_count": 42, "requests_per_minute": 1.4}},
+        {"from": "2018-06-12T09:30:00+00:00", "to": "2018-06-12T10:00:00+00:00", "values": {"average_response_time": 95, "call_count": 30}}
+      ]
+    },
+    {
+      "name": "Errors/all",
+      "timeslices": [
+        {"from": "2018-06-12T09:00:00+00:00", "to": "2018-06-12T10:00:00+00:00", "values": {"error_count": 2}}
+      ]
+    }
+  ]
+}`
+	data := new(newrelic.MetricData)
+	if err := json.Unmarshal([]byte(body), data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		data   *newrelic.MetricData
+		values []string
+		want   string
+	}{
+		{
+			"several values, all of them",
+			data,
+			nil,
+			`{"metrics":[` +
+				`{"name":"HttpDispatcher","from":"2018-06-12T09:00:00+00:00","to":"2018-06-12T09:30:00+00:00","average_response_time":120,"call_count":42,"error_count":null,"requests_per_minute":1.4},` +
+				`{"name":"HttpDispatcher","from":"2018-06-12T09:30:00+00:00","to":"2018-06-12T10:00:00+00:00","average_response_time":95,"call_count":30,"error_count":null,"requests_per_minute":null},` +
+				`{"name":"Errors/all","from":"2018-06-12T09:00:00+00:00","to":"2018-06-12T10:00:00+00:00","average_response_time":null,"call_count":null,"error_count":2,"requests_per_minute":null}]}`,
+		},
+		{
+			"several values, in the order asked",
+			&newrelic.MetricData{Metrics: data.Metrics[:1]},
+			[]string{"call_count", "average_response_time"},
+			`{"metrics":[` +
+				`{"name":"HttpDispatcher","from":"2018-06-12T09:00:00+00:00","to":"2018-06-12T09:30:00+00:00","call_count":42,"average_response_time":120},` +
+				`{"name":"HttpDispatcher","from":"2018-06-12T09:30:00+00:00","to":"2018-06-12T10:00:00+00:00","call_count":30,"average_response_time":95}]}`,
+		},
+		{
+			"no data",
+			nil,
+			nil,
+			`{"metrics":[]}`,
+		},
+	}
+	for _, test := range tests {
+		got := timesliceRows(test.data, test.values)
+		if string(got) != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
@@ -41,24 +41,17 @@ var monitorCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		id := args[0]
 
-		monitor, err, _ := GetMonitorByID(id)
-
-		var printer utils.Printer
-
-		printer = &utils.JSONPrinter{}
-
-		var output string
-		flags := cmd.Flags()
-		if flags.Lookup("output") != nil {
-			output, err = cmd.Flags().GetString("output")
-			if output == "yaml" {
-				printer = &utils.YAMLPrinter{}
-			}
+		monitor, err, _ := GetMonitorByID(ctx, id)
+		if err != nil {
+			os.Exit(1)
+			return
 		}
 
+		printer, err := utils.NewPriter(cmd)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -70,8 +63,8 @@ var monitorCmd = &cobra.Command{
 	},
 }
 
-func GetMonitorByID(id string) (*newrelic.Monitor, error, tracker.ReturnValue) {
-	fmt.Printf("Enter GetMonitorByID() func, monitor id: %s\n", id)
+func GetMonitorByID(ctx context.Context, id string) (*newrelic.Monitor, error, tracker.ReturnValue) {
+	fmt.Fprintf(os.Stderr, "Enter GetMonitorByID() func, monitor id: %s\n", id)
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
@@ -79,7 +72,7 @@ func GetMonitorByID(id string) (*newrelic.Monitor, error, tracker.ReturnValue) {
 		return nil, err, ret
 	}
 
-	monitor, resp, err := client.SyntheticsMonitors.GetByID(context.Background(), id)
+	monitor, resp, err := client.SyntheticsMonitors.GetByID(ctx, id)
 
 	if err != nil {
 		fmt.Println(err)
@@ -98,31 +91,22 @@ func GetMonitorByID(id string) (*newrelic.Monitor, error, tracker.ReturnValue) {
 		monitorID := monitor.ID
 		var id string = ""
 		id = *monitorID
-		scriptText, resp, err := client.SyntheticsScript.GetByID(context.Background(), id)
-
-		tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, resp.StatusCode, "monitor id: "+id+", monitor name: "+(*monitor.Name))
+		scriptText, resp, err := client.SyntheticsScript.GetByID(ctx, id)
 
-		if resp.StatusCode == 404 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get one monitor script, monitor id '%s', monitor name '%s'\n", statusCode, id, *monitor.Name)
+		if newrelic.IsNotFound(err) {
+			tracker.AppendRESTCallError(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, err, "monitor id: "+id+", monitor name: "+(*monitor.Name))
+			fmt.Printf("Response status code: %d. Get one monitor script, monitor id '%s', monitor name '%s'\n", resp.StatusCode, id, *monitor.Name)
 			s := new(newrelic.Script)
 			s.ScriptText = new(string)
 			scriptText = s
 		} else {
 			if err != nil {
+				tracker.AppendRESTCallError(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, err, "monitor id: "+id+", monitor name: "+(*monitor.Name))
 				fmt.Println(err)
-				// var st *newrelic.Script
-				// st = &newrelic.Script{}
-				// scriptText = st
 				ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITOR_BY_ID, err, tracker.ERR_REST_CALL, "")
 				return nil, err, ret
 			}
-			if resp.StatusCode >= 400 {
-				var statusCode = resp.StatusCode
-				fmt.Printf("Response status code: %d. Get one monitor script, monitor id '%s', monitor name '%s'\n", statusCode, id, *monitor.Name)
-				ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITOR_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "monitor id: "+id+", monitor name: "+(*monitor.Name))
-				return nil, err, ret
-			}
+			tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, resp.StatusCode, "monitor id: "+id+", monitor name: "+(*monitor.Name))
 		}
 		monitor.Script = scriptText
 	}
@@ -133,10 +117,10 @@ func GetMonitorByID(id string) (*newrelic.Monitor, error, tracker.ReturnValue) {
 var isAllMonitorsFetched bool = false
 var allMonitors []*newrelic.Monitor
 
-func IsMonitorNameExists(monitorName string) (bool, *newrelic.Monitor, error, tracker.ReturnValue) {
+func IsMonitorNameExists(ctx context.Context, monitorName string) (bool, *newrelic.Monitor, error, tracker.ReturnValue) {
 	// var allMonitorList []*newrelic.Monitor
 	if isAllMonitorsFetched == false {
-		allMonitorList, err, returnValue := GetMonitors()
+		allMonitorList, err, returnValue := GetMonitors(ctx)
 		if returnValue.IsContinue == false {
 			return false, nil, err, returnValue
 		}
@@ -154,9 +138,9 @@ func IsMonitorNameExists(monitorName string) (bool, *newrelic.Monitor, error, tr
 	return false, nil, nil, ret
 }
 
-func GetMonitorByName(monitorName string) (*newrelic.Monitor, error, tracker.ReturnValue) {
+func GetMonitorByName(ctx context.Context, monitorName string) (*newrelic.Monitor, error, tracker.ReturnValue) {
 
-	isExist, monitor, err, ret := IsMonitorNameExists(monitorName)
+	isExist, monitor, err, ret := IsMonitorNameExists(ctx, monitorName)
 	if ret.IsContinue == false {
 		return nil, err, ret
 	}
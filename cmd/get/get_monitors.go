@@ -39,87 +39,98 @@ var monitorsCmd = &cobra.Command{
 * nr get monitors -o json
 * nr get monitors -o yaml`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
-		monitorArray, err, ret := GetMonitors()
+		printer, err := utils.NewPriter(cmd)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-		if ret.IsContinue == false {
-			os.Exit(1)
-			return
-		}
-
-		var printer utils.Printer
-
-		printer = &utils.JSONPrinter{}
-
-		var output string
-		flags := cmd.Flags()
-		if flags.Lookup("output") != nil {
-			output, err = cmd.Flags().GetString("output")
-			if output == "yaml" {
-				printer = &utils.YAMLPrinter{}
+		if utils.Streams(printer) {
+			err, ret := GetMonitorsPages(ctx, func(monitors []*newrelic.Monitor) error {
+				printer.Print(monitors, os.Stdout)
+				return nil
+			})
+			if err != nil || ret.IsContinue == false {
+				os.Exit(1)
+				return
 			}
+			os.Exit(0)
 		}
 
+		monitorArray, err, ret := GetMonitors(ctx)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
+		if ret.IsContinue == false {
+			os.Exit(1)
+			return
+		}
 		printer.Print(monitorArray, os.Stdout)
 
 		os.Exit(0)
 	},
 }
 
-func GetMonitors() ([]*newrelic.Monitor, error, tracker.ReturnValue) {
+func GetMonitors(ctx context.Context) ([]*newrelic.Monitor, error, tracker.ReturnValue) {
+	var monitorArray = []*newrelic.Monitor{}
+
+	err, ret := GetMonitorsPages(ctx, func(monitors []*newrelic.Monitor) error {
+		monitorArray = append(monitorArray, monitors...)
+		return nil
+	})
+	if ret.IsContinue == false {
+		return nil, err, ret
+	}
+	return monitorArray, err, ret
+}
+
+// GetMonitorsPages calls fn with every page of the monitors, with their
+// scripts and tags, as they are fetched. The error of the tags, if they
+// cannot be fetched, is returned with a ReturnValue to continue.
+func GetMonitorsPages(ctx context.Context, fn func([]*newrelic.Monitor) error) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS, err, tracker.ERR_CREATE_NR_CLINET, "")
-		return nil, err, ret
+		return err, ret
 	}
 
+	tags, tagsErr := GetMonitorTags(ctx)
+
 	var opt *newrelic.MonitorListOptions
 	opt = &newrelic.MonitorListOptions{}
-	var pageSize int = 50
-	var pageOffset int = 0
-
-	var mList *newrelic.MonitorList = &newrelic.MonitorList{}
-
-	for {
-		opt.PageLimitOptions.Limit = pageSize
-		opt.PageLimitOptions.Offset = pageOffset
-		monitorList, resp, err := client.SyntheticsMonitors.ListAll(context.Background(), opt)
-		if err != nil {
-			fmt.Println(err)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS, err, tracker.ERR_REST_CALL, "")
-			return nil, err, ret
-		}
-
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_GET_MONITORS, resp.StatusCode, "pageSize:"+strconv.Itoa(pageSize)+",pageOffset:"+strconv.Itoa(pageOffset))
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get one page monitors, pageSize '%d', pageOffset '%d'\n", statusCode, pageSize, pageOffset)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
-		var monitorListLen = len(monitorList.Monitors)
-
-		if monitorListLen == 0 {
-			break
-		} else {
-			mList.Monitors = utils.MergeMonitorList(mList.Monitors, monitorList.Monitors)
-
-			pageOffset = pageOffset + pageSize
+	opt.PageLimitOptions.Limit = 50
+
+	err = client.SyntheticsMonitors.ListPages(ctx, opt, func(monitorList *newrelic.MonitorList, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_GET_MONITORS, resp.StatusCode, "pageSize:"+strconv.Itoa(opt.Limit)+",pageOffset:"+strconv.Itoa(opt.Offset))
+		monitorArray := getMonitorScripts(ctx, client, monitorList.Monitors)
+		if tagsErr == nil {
+			for _, m := range monitorArray {
+				if tags[*m.ID] != nil {
+					m.Tags = tags[*m.ID].Tags
+				}
+			}
 		}
+		return fn(monitorArray)
+	})
+	if err != nil {
+		fmt.Println(err)
+		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_GET_MONITORS, err, tracker.ERR_REST_CALL, "")
+		return err, ret
 	}
 
-	var mListLen = len(mList.Monitors)
+	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_MONITORS, tagsErr, nil, "")
+	return tagsErr, ret
+}
+
+// getMonitorScripts fetches the scripts of the scripted monitors, at most
+// MaxConcurrentTask at a time.
+func getMonitorScripts(ctx context.Context, client *newrelic.Client, monitors []*newrelic.Monitor) []*newrelic.Monitor {
+	var mListLen = len(monitors)
 	var monitorArray = make([]*newrelic.Monitor, mListLen)
 
 	scriptChMap := make(map[string]chan *newrelic.Script)
@@ -127,7 +138,7 @@ func GetMonitors() ([]*newrelic.Monitor, error, tracker.ReturnValue) {
 	defer close(chTaskCtrl)
 
 	for i := 0; i < mListLen; i++ {
-		monitor := mList.Monitors[i]
+		monitor := monitors[i]
 		if *monitor.Type == "SCRIPT_BROWSER" || *monitor.Type == "SCRIPT_API" {
 			r := make(chan *newrelic.Script)
 			id := *(monitor.ID)
@@ -135,15 +146,16 @@ func GetMonitors() ([]*newrelic.Monitor, error, tracker.ReturnValue) {
 			go func() {
 				defer close(r)
 				chTaskCtrl <- struct{}{}
-				fmt.Printf("Fetching script for Monitor: %s\n", name)
-				scriptText, resp, err := client.SyntheticsScript.GetByID(context.Background(), id)
+				fmt.Fprintf(os.Stderr, "Fetching script for Monitor: %s\n", name)
+				scriptText, resp, err := client.SyntheticsScript.GetByID(ctx, id)
 				<-chTaskCtrl
-				tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, resp.StatusCode, "monitor id: "+id+", monitor name: "+name)
 				if err != nil {
+					tracker.AppendRESTCallError(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, err, "monitor id: "+id+", monitor name: "+name)
 					fmt.Println(err)
 					r <- nil
 					return
 				}
+				tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_GET_MONITOR_SCRIPT, resp.StatusCode, "monitor id: "+id+", monitor name: "+name)
 				r <- scriptText
 				return
 			}()
@@ -152,7 +164,7 @@ func GetMonitors() ([]*newrelic.Monitor, error, tracker.ReturnValue) {
 	}
 
 	for i := 0; i < mListLen; i++ {
-		monitor := mList.Monitors[i]
+		monitor := monitors[i]
 		if *monitor.Type == "SCRIPT_BROWSER" || *monitor.Type == "SCRIPT_API" {
 			monitorID := monitor.ID
 			var id string = ""
@@ -166,59 +178,36 @@ func GetMonitors() ([]*newrelic.Monitor, error, tracker.ReturnValue) {
 		}
 
 	}
-	tags, err := GetMonitorTags()
-	if err == nil {
-		for _, m := range monitorArray {
-			if tags[*m.ID] != nil {
-				m.Tags = tags[*m.ID].Tags
-			}
-		}
-	}
-	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_GET_MONITORS, err, nil, "")
-	return monitorArray, err, ret
+	return monitorArray
 }
 
-func GetMonitorTags() (map[string]*newrelic.EntitySearchResultsMonitor, error) {
+func GetMonitorTags(ctx context.Context) (map[string]*newrelic.EntitySearchResultsMonitor, error) {
 	client, err := utils.GetNewRelicClient("graphql")
 	if err != nil {
 		fmt.Println(err)
 		return nil, err
 	}
 	m := make(map[string]*newrelic.EntitySearchResultsMonitor)
-	var cursor *string = nil
-	var c string = ""
-	for {
-		monitorTags, resp, err := client.SyntheticsMonitors.ListTags(context.Background(), cursor)
-		if err != nil {
-			fmt.Println(err)
-			return nil, err
-		}
-		if cursor != nil {
-			c = *cursor
-		}
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_GET_MONITORTAGS, resp.StatusCode, "cursor:"+c)
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Get monitor tags, query cursor: '%s'\n", statusCode, c)
-			return nil, tracker.ERR_REST_CALL_NOT_2XX
-		}
-		entities := monitorTags.Data.Actor.EntitySearch.Results.Entities
-
-		for _, e := range entities {
-			m[*e.MonitorId] = e
-		}
-
-		cursor = monitorTags.Data.Actor.EntitySearch.Results.NextCursor
-		if cursor == nil {
-			break
+	var page = 1
+	err = client.SyntheticsMonitors.ListTagsPages(ctx, func(monitorTags *newrelic.MonitorTagsResp, resp *newrelic.Response) error {
+		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_GET_MONITORTAGS, resp.StatusCode, "page:"+strconv.Itoa(page))
+		for _, e := range monitorTags.Entities() {
+			if e.MonitorId != nil {
+				m[*e.MonitorId] = e
+			}
 		}
-
+		page++
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
 	}
 	return m, err
 }
 func init() {
 	GetCmd.AddCommand(monitorsCmd)
+	utils.AddLabelFlag(monitorsCmd.Flags())
 
 	// Here you will define your flags and configuration settings.
 
@@ -16,7 +16,6 @@
 package get
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -46,6 +45,7 @@ var userCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
@@ -53,9 +53,9 @@ var userCmd = &cobra.Command{
 			return
 		}
 		id, _ := strconv.ParseInt(args[0], 10, 64)
-		user, resp, err := client.Users.GetByID(context.Background(), id)
-		if err != nil || resp.StatusCode >= 400 {
-			fmt.Printf("%v:%v\n", resp.Status, err)
+		user, _, err := client.Users.GetByID(ctx, id)
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
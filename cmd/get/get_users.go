@@ -16,7 +16,6 @@
 package get
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -36,6 +35,7 @@ var usersCmd = &cobra.Command{
 * nr get users -i 2102902
 * nr get users -i 2102902,+801314`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		client, err := utils.GetNewRelicClient()
 		if err != nil {
 			fmt.Println(err)
@@ -54,13 +54,25 @@ var usersCmd = &cobra.Command{
 			}
 			opt.EmailOptions = emails
 		}
-		userList, resp, err := client.Users.ListAll(context.Background(), opt)
-		if err != nil || resp.StatusCode >= 400 {
-			fmt.Printf("%v:%v\n", resp.Status, err)
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 			return
 		}
-		printer, err := utils.NewPriter(cmd)
+		if utils.Streams(printer) {
+			err = client.Users.ListPages(ctx, opt, func(userList *newrelic.UserList, resp *newrelic.Response) error {
+				printer.Print(userList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		userList, err := client.Users.ListAllPages(ctx, opt)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
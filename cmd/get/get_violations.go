@@ -0,0 +1,156 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var violationsCmd = &cobra.Command{
+	Use:     "violations",
+	Short:   "Display all alerts violations.",
+	Aliases: []string{"violation", "vi"},
+	Example: "nr get violations --since 24h --until now --only-open -o table",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		timeRange, err := utils.NewTimeRange(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		onlyOpen, _ := cmd.Flags().GetBool("only-open")
+		opt := &newrelic.AlertsViolationListOptions{OnlyOpen: onlyOpen}
+		// The API takes both dates or none.
+		if !timeRange.IsZero() {
+			if timeRange.Since.IsZero() {
+				fmt.Println("--until needs --since.")
+				os.Exit(1)
+				return
+			}
+			if timeRange.Until.IsZero() {
+				timeRange.Until = time.Now()
+			}
+			opt.StartDate = timeRange.Since.Format(time.RFC3339)
+			opt.EndDate = timeRange.Until.Format(time.RFC3339)
+		}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.Streams(printer) {
+			err = client.AlertsViolations.ListPages(ctx, opt, func(violationList *newrelic.AlertsViolationList, resp *newrelic.Response) error {
+				printer.Print(violationList, os.Stdout)
+				return nil
+			})
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			os.Exit(0)
+		}
+		violationList, err := client.AlertsViolations.ListAllPages(ctx, opt)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(violationRows(ctx, client, violationList), os.Stdout)
+		} else {
+			printer.Print(violationList, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// violationRow is a violation as printed by -o table, wide and csv, with the
+// names of its policy, condition and entity.
+type violationRow struct {
+	ID            *int64  `json:"id"`
+	Label         *string `json:"label,omitempty"`
+	Priority      *string `json:"priority,omitempty"`
+	PolicyName    string  `json:"policy_name"`
+	ConditionName string  `json:"condition_name"`
+	EntityType    *string `json:"entity_type,omitempty"`
+	EntityName    *string `json:"entity_name,omitempty"`
+	OpenedAt      string  `json:"opened_at"`
+	ClosedAt      string  `json:"closed_at"`
+	Duration      *int64  `json:"duration,omitempty"`
+}
+
+// violationRows returns the rows of the violations, resolving the names of
+// their policies and conditions when the API left them out.
+func violationRows(ctx context.Context, client *newrelic.Client, violationList *newrelic.AlertsViolationList) map[string][]violationRow {
+	names := newAlertNames(ctx, client)
+	rows := []violationRow{}
+	for _, violation := range violationList.Violations {
+		row := violationRow{
+			ID:       violation.ID,
+			Label:    violation.Label,
+			Priority: violation.Priority,
+			OpenedAt: formatEpochMillis(violation.OpenedAt),
+			ClosedAt: formatEpochMillis(violation.ClosedAt),
+			Duration: violation.Duration,
+		}
+		if violation.PolicyName != nil {
+			row.PolicyName = *violation.PolicyName
+		}
+		if violation.ConditionName != nil {
+			row.ConditionName = *violation.ConditionName
+		}
+		if violation.Links != nil {
+			if row.PolicyName == "" {
+				row.PolicyName = names.policy(violation.Links.PolicyID)
+			}
+			if row.ConditionName == "" {
+				row.ConditionName = names.condition(violation.Links.PolicyID, violation.Links.ConditionID)
+			}
+		}
+		if violation.Entity != nil {
+			row.EntityType = violation.Entity.Type
+			row.EntityName = violation.Entity.Name
+		}
+		rows = append(rows, row)
+	}
+	return map[string][]violationRow{"violations": rows}
+}
+
+func init() {
+	GetCmd.AddCommand(violationsCmd)
+
+	violationsCmd.Flags().Bool("only-open", false, "Only the open violations")
+	utils.AddTimeRangeFlags(violationsCmd.Flags())
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+// GraphQLCmd represents the graphql command
+var GraphQLCmd = &cobra.Command{
+	Use:   "graphql [query]",
+	Short: "Run a NerdGraph query or mutation.",
+	Long: `Run a query or a mutation against NerdGraph, the GraphQL API of NewRelic, and
+print the data section of its answer.
+
+The query is given as argument, or read from a file with -q. Variables are
+given with --var name=value: values holding valid JSON, like numbers, true or
+lists, are sent as is, the others as strings. Quote a value to send it as a
+string, like --var 'id="1234"'. NerdGraph errors are printed to stderr, along
+with the partial data if any, and the command fails.`,
+	Example: `nr graphql '{ actor { user { name email } } }'
+nr graphql -q entity.graphql --var guid=<entity guid> -o yaml
+nr graphql -q nrql.graphql --var accountId=1234567 --var 'nrql=SELECT count(*) FROM Transaction'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+
+		file, _ := cmd.Flags().GetString("query")
+		var query string
+		switch {
+		case file != "" && len(args) > 0:
+			fmt.Println("Give the query either as argument or with -q, not both.")
+			os.Exit(1)
+			return
+		case file == "-":
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			query = string(data)
+		case file != "":
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				fmt.Printf("Unable to open file '%v': %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			query = string(data)
+		case len(args) == 1:
+			query = args[0]
+		default:
+			fmt.Println("Please give one query, or -q.")
+			os.Exit(1)
+			return
+		}
+		if strings.TrimSpace(query) == "" {
+			fmt.Println("The query is empty.")
+			os.Exit(1)
+			return
+		}
+
+		vars, _ := cmd.Flags().GetStringArray("var")
+		variables, err := parseVariables(vars)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		client, err := utils.GetNewRelicClient("graphql")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		var data json.RawMessage
+		_, err = client.GraphQL.Query(ctx, query, variables, &data)
+		var graphQLErrs newrelic.GraphQLErrors
+		if err != nil && !errors.As(err, &graphQLErrs) {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if len(data) > 0 {
+			printer.Print(data, os.Stdout)
+		}
+		if len(graphQLErrs) > 0 {
+			for _, e := range graphQLErrs {
+				fmt.Fprintln(os.Stderr, e)
+			}
+			os.Exit(1)
+			return
+		}
+
+		os.Exit(0)
+	},
+}
+
+// parseVariables returns the variables given as name=value. Values holding
+// valid JSON are decoded, the others are kept as strings.
+func parseVariables(vars []string) (map[string]interface{}, error) {
+	if len(vars) == 0 {
+		return nil, nil
+	}
+	variables := make(map[string]interface{}, len(vars))
+	for _, v := range vars {
+		i := strings.Index(v, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("Invalid variable %q, must be like name=value.", v)
+		}
+		name, value := v[:i], v[i+1:]
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			variables[name] = decoded
+		} else {
+			variables[name] = value
+		}
+	}
+	return variables, nil
+}
+
+func init() {
+	GraphQLCmd.Flags().StringP("query", "q", "", "File holding the query, - for stdin")
+	GraphQLCmd.Flags().StringArray("var", nil, "Variable of the query, like name=value. Can be repeated")
+	GraphQLCmd.Flags().StringP("output", "o", "json", "Output format. "+utils.OutputFormats+" are supported")
+}
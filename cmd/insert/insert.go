@@ -37,9 +37,7 @@ func init() {
 	InsertCmd.PersistentFlags().StringP("file", "f", "", "File name to store custom events data in JSON.")
 	InsertCmd.MarkPersistentFlagRequired("file")
 
-	InsertCmd.PersistentFlags().StringP("insert-key", "i", "", "New Relic insert key.")
-	InsertCmd.MarkPersistentFlagRequired("insert-key")
+	InsertCmd.PersistentFlags().StringP("insert-key", "i", "", "New Relic insert key. Default is NEW_RELIC_INSERTKEY, or insert_key of the profile.")
 
-	InsertCmd.PersistentFlags().StringP("account-id", "a", "", "New Relic account ID.")
-	InsertCmd.MarkPersistentFlagRequired("account-id")
+	InsertCmd.PersistentFlags().StringP("account-id", "a", "", "New Relic account ID. Default is NEW_RELIC_ACCOUNT_ID, account_id of the profile, or the account of the API key.")
 }
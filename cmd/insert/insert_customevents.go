@@ -16,7 +16,6 @@
 package insert
 
 import (
-	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -30,8 +29,10 @@ var customeventsCmd = &cobra.Command{
 	Use:     "customevents",
 	Short:   "Insert custom events.",
 	Aliases: []string{"m"},
-	Example: `nr insert customevents`,
+	Example: `* nr insert customevents -f events.json
+* nr insert customevents -f events.json -i <insert key> -a <account ID>`,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var err error
 
@@ -45,10 +46,20 @@ var customeventsCmd = &cobra.Command{
 				os.Exit(1)
 				return
 			}
-			if insertKey == "" {
-				fmt.Println("Please give New Relic insert key.")
+		}
+		if insertKey == "" {
+			insertKey, err = utils.GetCredential("insert_key")
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
 			}
 		}
+		if insertKey == "" {
+			fmt.Println("Please give New Relic insert key.")
+			os.Exit(1)
+			return
+		}
 
 		var accountID = ""
 		if flags.Lookup("account-id") != nil {
@@ -58,8 +69,14 @@ var customeventsCmd = &cobra.Command{
 				os.Exit(1)
 				return
 			}
-			if accountID == "" {
+		}
+		if accountID == "" {
+			accountID, err = utils.GetAccountID(ctx)
+			if err != nil {
+				fmt.Println(err)
 				fmt.Println("Please give New Relic account ID.")
+				os.Exit(1)
+				return
 			}
 		}
 
@@ -91,10 +108,11 @@ var customeventsCmd = &cobra.Command{
 			return
 		}
 
-		resp, bytes, err := client.CustomEvents.Insert(context.Background(), insertKey, accountID, fileContent)
+		resp, bytes, err := client.CustomEvents.Insert(ctx, insertKey, accountID, fileContent)
 		retMsg := string(bytes)
 
 		if err != nil {
+			tracker.AppendRESTCallError(client.CustomEvents, tracker.OPERATION_NAME_INSERT_CUSTOM_EVENTS, err, retMsg)
 			tracker.ToReturnValue(false, tracker.OPERATION_NAME_INSERT_CUSTOM_EVENTS, err, tracker.ERR_REST_CALL, "")
 			fmt.Printf("Failed to insert custom events.")
 			fmt.Println(err)
@@ -105,25 +123,10 @@ var customeventsCmd = &cobra.Command{
 
 		tracker.AppendRESTCallResult(client.CustomEvents, tracker.OPERATION_NAME_INSERT_CUSTOM_EVENTS, resp.StatusCode, retMsg)
 
-		var ret tracker.ReturnValue
-		if resp.StatusCode >= 400 {
-			ret = tracker.ToReturnValue(false, tracker.OPERATION_NAME_INSERT_CUSTOM_EVENTS, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, retMsg)
-			fmt.Printf("Failed to insert custom events. status code is not 200, Response status code: %d\n", resp.StatusCode)
-			fmt.Println(retMsg)
-		} else {
-			ret = tracker.ToReturnValue(true, tracker.OPERATION_NAME_INSERT_CUSTOM_EVENTS, nil, nil, "")
-		}
-
 		//print REST call
 		tracker.PrintStatisticsInfo(tracker.GlobalRESTCallResultList)
 		fmt.Println()
 
-		if ret.IsContinue == false {
-			fmt.Printf("Failed to insert custom events.")
-			os.Exit(1)
-			return
-		}
-
 		fmt.Println("Insert custom events successful.")
 		os.Exit(0)
 	},
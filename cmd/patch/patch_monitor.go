@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/newrelic"
 	"github.com/IBM/newrelic-cli/utils"
 )
@@ -30,8 +31,9 @@ import (
 var monitorCmd = &cobra.Command{
 	Use:     "monitor",
 	Short:   "Patch monitor from a file.",
-	Example: "nr patch monitor -f <example.yaml>",
+	Example: "nr patch monitor -f <example.yaml>\nnr patch monitor -f <example.yaml> --selector 'name=~^prod-' --dry-run",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -67,32 +69,82 @@ var monitorCmd = &cobra.Command{
 			return
 		}
 
+		if utils.HasSelector(cmd) {
+			patchSelectedMonitors(ctx, cmd, client, p)
+			return
+		}
+
 		if p.ID == nil {
 			fmt.Printf("Can't find {.id} in %q.\n", file)
 			os.Exit(1)
 			return
 		}
 
-		resp, err := client.SyntheticsMonitors.Patch(context.Background(), p, p.ID)
+		resp, err := client.SyntheticsMonitors.Patch(ctx, p, p.ID)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Patch monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, *p.ID)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
+		var statusCode = resp.StatusCode
+		fmt.Printf("Response status code: %d. Patch monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, *p.ID)
 
 		os.Exit(0)
 	},
 }
 
+// patchSelectedMonitors patches all the monitors matching the selector with
+// the fields of p, or only prints them with --dry-run, then exits.
+func patchSelectedMonitors(ctx context.Context, cmd *cobra.Command, client *newrelic.Client, p *newrelic.Monitor) {
+	selector, err := utils.NewSelector(cmd)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	monitors, err, ret := get.GetMonitors(ctx)
+	if !ret.IsContinue {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	items, err := selector.Filter(monitors)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(items) == 0 {
+		fmt.Println("No monitor matches.")
+		os.Exit(0)
+	}
+
+	// The matching monitors are patched, not the one of the file.
+	p.ID = nil
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	failed := 0
+	for _, item := range items {
+		id, name := utils.ItemID(item), utils.ItemName(item)
+		if dryRun {
+			fmt.Printf("Would patch monitor %s, %q\n", id, name)
+			continue
+		}
+		_, err := client.SyntheticsMonitors.Patch(ctx, p, &id)
+		if err != nil {
+			fmt.Printf("Failed to patch monitor %s, %q: %v\n", id, name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Patched monitor %s, %q\n", id, name)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
 func init() {
 	PatchCmd.AddCommand(monitorCmd)
+	utils.AddSelectorFlags(monitorCmd.Flags())
+	utils.AddLabelFlag(monitorCmd.Flags())
+	monitorCmd.Flags().Bool("dry-run", false, "Only print the monitors matching the selector, without patching them")
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+// QueryCmd represents the query command
+var QueryCmd = &cobra.Command{
+	Use:   "query <NRQL>",
+	Short: "Run a NRQL query through NerdGraph.",
+	Long: `Run a NRQL query in a NewRelic account through NerdGraph, and display its results.
+
+In table, wide and csv output, the results have one row per facet and
+timeslice: the FACET attributes come first, then the begin_time and end_time
+of TIMESERIES queries, then the selected values. Other outputs print the
+result set of NerdGraph as is, with its metadata.`,
+	Example: `nr query "SELECT count(*) FROM Transaction FACET appName SINCE 1 day ago"
+nr query "SELECT average(duration) FROM Transaction TIMESERIES 1 hour SINCE 1 day ago" --account <id> -o csv
+nr query --file query.nrql -o json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+
+		file, _ := cmd.Flags().GetString("file")
+		var query string
+		switch {
+		case file != "" && len(args) > 0:
+			fmt.Println("Give the NRQL query either as argument or with --file, not both.")
+			os.Exit(1)
+			return
+		case file == "-":
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+				return
+			}
+			query = string(data)
+		case file != "":
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				fmt.Printf("Unable to open file '%v': %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			query = string(data)
+		case len(args) == 1:
+			query = args[0]
+		default:
+			fmt.Println("Please give one NRQL query, or --file.")
+			os.Exit(1)
+			return
+		}
+		query = strings.TrimSpace(query)
+		if query == "" {
+			fmt.Println("The NRQL query is empty.")
+			os.Exit(1)
+			return
+		}
+
+		account, _ := cmd.Flags().GetString("account")
+		if account == "" {
+			var err error
+			if account, err = utils.GetAccountID(ctx); err != nil {
+				fmt.Println(err)
+				fmt.Println("Please give New Relic account ID.")
+				os.Exit(1)
+				return
+			}
+		}
+		accountID, err := strconv.ParseInt(account, 10, 64)
+		if err != nil {
+			fmt.Printf("Invalid account ID %q.\n", account)
+			os.Exit(1)
+			return
+		}
+
+		printer, err := utils.NewPriter(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		client, err := utils.GetNewRelicClient("graphql")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		result, _, err := client.NRQL.Query(ctx, accountID, query)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		if result.Metadata != nil {
+			for _, message := range result.Metadata.Messages {
+				fmt.Fprintln(os.Stderr, message)
+			}
+		}
+		if utils.IsTabular(printer) {
+			printer.Print(resultRows(result), os.Stdout)
+		} else {
+			printer.Print(result, os.Stdout)
+		}
+
+		os.Exit(0)
+	},
+}
+
+// resultRows returns the rows of result as printed by -o table, wide and
+// csv: a column per facet, named after its attribute, then begin_time and
+// end_time for TIMESERIES queries, then the other fields of the results.
+func resultRows(result *newrelic.NRQLResult) json.RawMessage {
+	var facets []string
+	if result.Metadata != nil {
+		facets = result.Metadata.Facets
+	}
+	skip := map[string]bool{"facet": true, "beginTimeSeconds": true, "endTimeSeconds": true}
+	for _, facet := range facets {
+		skip[facet] = true
+	}
+
+	// The rows are written as JSON to keep their columns in order.
+	var rows bytes.Buffer
+	writeField := func(first bool, name string, value []byte) {
+		if !first {
+			rows.WriteString(",")
+		}
+		key, _ := json.Marshal(name)
+		rows.Write(key)
+		rows.WriteString(":")
+		rows.Write(value)
+	}
+	rows.WriteString(`{"results":[`)
+	for i, raw := range result.Results {
+		if i > 0 {
+			rows.WriteString(",")
+		}
+		row := gjson.ParseBytes(raw)
+		first := true
+		rows.WriteString("{")
+		facet := row.Get("facet")
+		for j, name := range facets {
+			value := facet
+			if facet.IsArray() {
+				value = facet.Get(strconv.Itoa(j))
+			}
+			writeField(first, name, rawValue(value))
+			first = false
+		}
+		for _, field := range [][2]string{{"beginTimeSeconds", "begin_time"}, {"endTimeSeconds", "end_time"}} {
+			if seconds := row.Get(field[0]); seconds.Exists() {
+				t, _ := json.Marshal(time.Unix(seconds.Int(), 0).UTC().Format(time.RFC3339))
+				writeField(first, field[1], t)
+				first = false
+			}
+		}
+		row.ForEach(func(key, value gjson.Result) bool {
+			if !skip[key.String()] {
+				writeField(first, key.String(), rawValue(value))
+				first = false
+			}
+			return true
+		})
+		rows.WriteString("}")
+	}
+	rows.WriteString("]}")
+	return json.RawMessage(rows.Bytes())
+}
+
+func rawValue(value gjson.Result) []byte {
+	if !value.Exists() {
+		return []byte("null")
+	}
+	return []byte(value.Raw)
+}
+
+func init() {
+	QueryCmd.Flags().StringP("account", "a", "", "New Relic account ID. Default is NEW_RELIC_ACCOUNT_ID, account_id of the profile, or the account of the API key.")
+	QueryCmd.Flags().StringP("file", "f", "", "File holding the NRQL query, - for stdin. For long queries")
+	QueryCmd.Flags().StringP("output", "o", "table", "Output format. "+utils.OutputFormats+" are supported")
+}
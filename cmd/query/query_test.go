@@ -0,0 +1,76 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+func TestResultRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		facets  []string
+		results string
+		want    string
+	}{
+		{
+			"no facet",
+			nil,
+			`[{"count": 1104}]`,
+			`{"results":[{"count":1104}]}`,
+		},
+		{
+			"single facet",
+			[]string{"appName"},
+			`[{"appName": "web", "count": 1024, "facet": "web"}, {"appName": "api", "count": 80, "facet": "api"}]`,
+			`{"results":[{"appName":"web","count":1024},{"appName":"api","count":80}]}`,
+		},
+		{
+			"multi-facet",
+			[]string{"appName", "request.method"},
+			`[{"count": 12, "facet": ["web", "GET"], "appName": "web", "request.method": "GET"}, {"count": 3, "facet": ["web", null]}]`,
+			`{"results":[{"appName":"web","request.method":"GET","count":12},{"appName":"web","request.method":null,"count":3}]}`,
+		},
+		{
+			"TIMESERIES with FACET",
+			[]string{"appName"},
+			`[{"facet": "web", "appName": "web", "beginTimeSeconds": 1528794000, "endTimeSeconds": 1528797600, "count": 512}]`,
+			`{"results":[{"appName":"web","begin_time":"2018-06-12T09:00:00Z","end_time":"2018-06-12T10:00:00Z","count":512}]}`,
+		},
+		{
+			"TIMESERIES only",
+			nil,
+			`[{"beginTimeSeconds": 1528794000, "endTimeSeconds": 1528797600, "average.duration": 0.25}, {"beginTimeSeconds": 1528797600, "endTimeSeconds": 1528801200, "average.duration": null}]`,
+			`{"results":[{"begin_time":"2018-06-12T09:00:00Z","end_time":"2018-06-12T10:00:00Z","average.duration":0.25},{"begin_time":"2018-06-12T10:00:00Z","end_time":"2018-06-12T11:00:00Z","average.duration":null}]}`,
+		},
+	}
+	for _, test := range tests {
+		result := &newrelic.NRQLResult{Metadata: &newrelic.NRQLMetadata{Facets: test.facets}}
+		if err := json.Unmarshal([]byte(test.results), &result.Results); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		got := resultRows(result)
+		if string(got) != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+		if !json.Valid(got) {
+			t.Errorf("%s: rows are not valid JSON", test.name)
+		}
+	}
+}
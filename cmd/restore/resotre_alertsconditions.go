@@ -17,6 +17,7 @@ package restore
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -41,6 +42,7 @@ var alertsconditionsCmd = &cobra.Command{
 	Short:   "Restore alertsconditions from directory.",
 	Example: "nr restore alertsconditions -d <Directory name where are files to restore>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var restoreFileFolder string
 		var err error
@@ -177,7 +179,7 @@ var alertsconditionsCmd = &cobra.Command{
 
 			//delete all alert policies
 
-			allPolicyList, err, returnValue := get.GetAllAlertPolicies()
+			allPolicyList, err, returnValue := get.GetAllAlertPolicies(ctx)
 			if err != nil {
 				exitRestoreAlertCondtionsWithError(returnValue)
 				writeFailRestoreConditionsFileList(resultFileName, rapmArray)
@@ -192,7 +194,7 @@ var alertsconditionsCmd = &cobra.Command{
 			}
 
 			for _, alertsPolicy := range allPolicyList.AlertsPolicies {
-				err, returnValue := delete.DeletePolicyByName(*alertsPolicy.Name)
+				err, returnValue := delete.DeletePolicyByName(ctx, *alertsPolicy.Name)
 				if err != nil {
 					exitRestoreAlertCondtionsWithError(returnValue)
 					writeFailRestoreConditionsFileList(resultFileName, rapmArray)
@@ -214,6 +216,12 @@ var alertsconditionsCmd = &cobra.Command{
 			restoreAlertPolicyMeta.FileName = restoreFileName
 			restoreAlertPolicyMeta.OperationStatus = "fail"
 
+			if utils.IsCancelled() {
+				// Interrupted, the files left are logged as failed to be restored later.
+				restoreAlertPolicyMetaArray = append(restoreAlertPolicyMetaArray, restoreAlertPolicyMeta)
+				continue
+			}
+
 			restoreFile, err := os.Open(restoreFileName)
 			defer restoreFile.Close()
 			if err != nil {
@@ -238,7 +246,7 @@ var alertsconditionsCmd = &cobra.Command{
 			alertPolicy := alertPolicySet.AlertsPolicy
 			// fmt.Println(*alertPolicy.Name)
 
-			newAlertPolicy, isPolicyCreated, err, ret := RestoreOnePolicy(alertPolicy, updateMode)
+			newAlertPolicy, isPolicyCreated, err, ret := RestoreOnePolicy(ctx, alertPolicy, updateMode)
 			if err != nil {
 				fmt.Println(err)
 				goto next
@@ -261,7 +269,7 @@ var alertsconditionsCmd = &cobra.Command{
 						var ac = new(newrelic.AlertsConditionEntity)
 						ac.AlertsDefaultConditionEntity = &newrelic.AlertsDefaultConditionEntity{}
 						ac.AlertsDefaultConditionEntity.AlertsDefaultCondition = defaultCondition
-						err, ret := RestoreOneCondition(*newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
+						err, ret := RestoreOneCondition(ctx, *newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
 						if err != nil || ret.IsContinue == false {
 							isErr = true
 							break
@@ -281,7 +289,7 @@ var alertsconditionsCmd = &cobra.Command{
 						var ac = new(newrelic.AlertsConditionEntity)
 						ac.AlertsExternalServiceConditionEntity = &newrelic.AlertsExternalServiceConditionEntity{}
 						ac.AlertsExternalServiceConditionEntity.AlertsExternalServiceCondition = externalServiceCondition
-						err, ret := RestoreOneCondition(*newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
+						err, ret := RestoreOneCondition(ctx, *newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
 						if err != nil || ret.IsContinue == false {
 							isErr = true
 							break
@@ -301,7 +309,7 @@ var alertsconditionsCmd = &cobra.Command{
 						var ac = new(newrelic.AlertsConditionEntity)
 						ac.AlertsNRQLConditionEntity = &newrelic.AlertsNRQLConditionEntity{}
 						ac.AlertsNRQLConditionEntity.AlertsNRQLCondition = nrqlCondition
-						err, ret := RestoreOneCondition(*newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
+						err, ret := RestoreOneCondition(ctx, *newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
 						if err != nil || ret.IsContinue == false {
 							isErr = true
 							break
@@ -321,7 +329,7 @@ var alertsconditionsCmd = &cobra.Command{
 						var ac = new(newrelic.AlertsConditionEntity)
 						ac.AlertsPluginsConditionEntity = &newrelic.AlertsPluginsConditionEntity{}
 						ac.AlertsPluginsConditionEntity.AlertsPluginsCondition = pluginsCondition
-						err, ret := RestoreOneCondition(*newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
+						err, ret := RestoreOneCondition(ctx, *newAlertPolicy.ID, cat, ac, updateMode, isPolicyCreated)
 						if err != nil || ret.IsContinue == false {
 							isErr = true
 							break
@@ -341,7 +349,27 @@ var alertsconditionsCmd = &cobra.Command{
 						var ac = new(newrelic.AlertsConditionEntity)
 						ac.AlertsSyntheticsConditionEntity = &newrelic.AlertsSyntheticsConditionEntity{}
 						ac.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition = syntheticsCondition
-						err, ret := RestoreOneConditionForSynthetics(*newAlertPolicy.ID, cat, ac, updateMode, p.AlertDependencies.MonitorMap, isPolicyCreated)
+						err, ret := RestoreOneConditionForSynthetics(ctx, *newAlertPolicy.ID, cat, ac, updateMode, p.AlertDependencies.MonitorMap, isPolicyCreated)
+						if err != nil || ret.IsContinue == false {
+							isErr = true
+							break
+						}
+					}
+					if isErr == true {
+						goto next
+					}
+				}
+			}
+
+			if alertPolicySet.AlertsConditionList != nil {
+				if alertPolicySet.AlertsConditionList.AlertsLocationConditionList != nil {
+					var isErr bool = false
+					for _, locationCondition := range alertPolicySet.AlertsConditionList.AlertsLocationConditionList.AlertsLocationConditions {
+						var cat newrelic.ConditionCategory = newrelic.ConditionLocation
+						var ac = new(newrelic.AlertsConditionEntity)
+						ac.AlertsLocationConditionEntity = &newrelic.AlertsLocationConditionEntity{}
+						ac.AlertsLocationConditionEntity.AlertsLocationCondition = locationCondition
+						err, ret := RestoreOneConditionForLocation(ctx, *newAlertPolicy.ID, cat, ac, updateMode, p.AlertDependencies.MonitorMap, isPolicyCreated)
 						if err != nil || ret.IsContinue == false {
 							isErr = true
 							break
@@ -354,7 +382,7 @@ var alertsconditionsCmd = &cobra.Command{
 			}
 
 			//restore policy channels associations
-			err, ret = RestorePolicyChannels(*newAlertPolicy.ID, alertPolicySet.AlertsChannels, updateMode, isPolicyCreated)
+			err, ret = RestorePolicyChannels(ctx, *newAlertPolicy.ID, alertPolicySet.AlertsChannels, updateMode, isPolicyCreated)
 			if ret.IsContinue == false {
 				goto next
 			} else {
@@ -383,8 +411,8 @@ var alertsconditionsCmd = &cobra.Command{
 	},
 }
 
-func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrelic.AlertsPolicy, bool, error, tracker.ReturnValue) {
-	isExist, policy, err, ret := get.IsPolicyNameExists(*alertsPolicy.Name)
+func RestoreOnePolicy(ctx context.Context, alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrelic.AlertsPolicy, bool, error, tracker.ReturnValue) {
+	isExist, policy, err, ret := get.IsPolicyNameExists(ctx, *alertsPolicy.Name)
 	if err != nil {
 		fmt.Println(err)
 		return nil, false, err, ret
@@ -400,7 +428,7 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 			return nil, false, err, ret
 		} else {
 			//create the policy
-			newAlertsPolicy, err, ret := create.CreateAlertsPolicy(alertsPolicy)
+			newAlertsPolicy, err, ret := create.CreateAlertsPolicy(ctx, alertsPolicy)
 			if err != nil {
 				fmt.Println(err)
 				ret.IsContinue = false
@@ -415,7 +443,7 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 	} else if mode == "override" {
 		if isExist == true {
 			//update all by name
-			newAlertsPolicy, err, ret := update.UpdateByPolicyName(policy, *policy.Name)
+			newAlertsPolicy, err, ret := update.UpdateByPolicyName(ctx, policy, *policy.Name)
 			if err != nil {
 				fmt.Println(err)
 				return nil, false, err, ret
@@ -426,7 +454,7 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 			return newAlertsPolicy, false, err, ret
 		} else {
 			//create the policy
-			newAlertsPolicy, err, ret := create.CreateAlertsPolicy(alertsPolicy)
+			newAlertsPolicy, err, ret := create.CreateAlertsPolicy(ctx, alertsPolicy)
 			if err != nil {
 				fmt.Println(err)
 				return nil, false, err, ret
@@ -440,7 +468,7 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 	} else if mode == "clean" {
 		if isExist == true {
 			//delete policy by name
-			err, ret := delete.DeletePolicyByName(*alertsPolicy.Name)
+			err, ret := delete.DeletePolicyByName(ctx, *alertsPolicy.Name)
 			if err != nil {
 				fmt.Println(err)
 				return nil, false, err, ret
@@ -450,7 +478,7 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 			}
 		}
 		//create the policy
-		newAlertsPolicy, err, ret = create.CreateAlertsPolicy(alertsPolicy)
+		newAlertsPolicy, err, ret = create.CreateAlertsPolicy(ctx, alertsPolicy)
 		if err != nil {
 			fmt.Println(err)
 			return nil, false, err, ret
@@ -469,11 +497,11 @@ func RestoreOnePolicy(alertsPolicy *newrelic.AlertsPolicy, mode string) (*newrel
 	return nil, false, err, ret
 }
 
-func RestoreOneCondition(alertPolicyID int64, cat newrelic.ConditionCategory, c *newrelic.AlertsConditionEntity, mode string, isPolicyCreated bool) (error, tracker.ReturnValue) {
+func RestoreOneCondition(ctx context.Context, alertPolicyID int64, cat newrelic.ConditionCategory, c *newrelic.AlertsConditionEntity, mode string, isPolicyCreated bool) (error, tracker.ReturnValue) {
 	var err error
 	if isPolicyCreated == true {
 		//create conditions directly, because the alert policy was new created.
-		_, err, ret := create.CreateCondition(cat, c, alertPolicyID)
+		_, err, ret := create.CreateCondition(ctx, cat, c, alertPolicyID)
 		if err != nil {
 			fmt.Println(err)
 			ret.IsContinue = false
@@ -499,8 +527,10 @@ func RestoreOneCondition(alertPolicyID int64, cat newrelic.ConditionCategory, c
 			conditionName = *c.AlertsPluginsCondition.Name
 		case newrelic.ConditionSynthetics:
 			conditionName = *c.AlertsSyntheticsCondition.Name
+		case newrelic.ConditionLocation:
+			conditionName = *c.AlertsLocationCondition.Name
 		}
-		isConditionExists, conditionId, err, ret := get.IsConditionNameExists(alertPolicyID, conditionName, cat)
+		isConditionExists, conditionId, err, ret := get.IsConditionNameExists(ctx, alertPolicyID, conditionName, cat)
 		if err != nil {
 			fmt.Println(err)
 			ret.IsContinue = false
@@ -514,13 +544,13 @@ func RestoreOneCondition(alertPolicyID int64, cat newrelic.ConditionCategory, c
 				//do nothing
 			} else if mode == "override" {
 				//update condtion
-				_, err, ret := update.UpdateCondition(cat, c, conditionId)
+				_, err, ret := update.UpdateCondition(ctx, cat, c, conditionId)
 				if ret.IsContinue == false {
 					return err, ret
 				}
 			} else if mode == "clean" {
 				//delete current condition
-				err, ret := delete.DeleteCondition(cat, conditionId)
+				err, ret := delete.DeleteCondition(ctx, cat, conditionId)
 				if err != nil {
 					fmt.Println(err)
 					ret.IsContinue = false
@@ -530,11 +560,11 @@ func RestoreOneCondition(alertPolicyID int64, cat newrelic.ConditionCategory, c
 					return err, ret
 				}
 				//create condition
-				create.CreateCondition(cat, c, alertPolicyID)
+				create.CreateCondition(ctx, cat, c, alertPolicyID)
 			}
 		} else {
 			//create condtion directly
-			create.CreateCondition(cat, c, alertPolicyID)
+			create.CreateCondition(ctx, cat, c, alertPolicyID)
 		}
 
 	}
@@ -542,54 +572,16 @@ func RestoreOneCondition(alertPolicyID int64, cat newrelic.ConditionCategory, c
 	return err, ret
 }
 
-func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.ConditionCategory, c *newrelic.AlertsConditionEntity, mode string, monitorMap map[string]*newrelic.Monitor, isPolicyCreated bool) (error, tracker.ReturnValue) {
-	monitorId := c.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.MonitorID
-	monitorName := monitorMap[*monitorId].Name
-	//check if monitor exist by monitorId
-	isExists, monitor, err, ret := get.IsMonitorNameExists(*monitorName)
-	if err != nil {
-		fmt.Println(err)
-		ret.IsContinue = false
-		return err, ret
-	}
+func RestoreOneConditionForSynthetics(ctx context.Context, alertPolicyID int64, cat newrelic.ConditionCategory, c *newrelic.AlertsConditionEntity, mode string, monitorMap map[string]*newrelic.Monitor, isPolicyCreated bool) (error, tracker.ReturnValue) {
+	monitorId, err, ret := restoreConditionMonitor(ctx, *c.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.MonitorID, mode, monitorMap)
 	if ret.IsContinue == false {
 		return err, ret
 	}
-	if isExists == true {
-		c.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.MonitorID = monitor.ID
-		if mode == "skip" {
-			//do nothing
-		} else {
-			//update monitor by monitor name
-			err, returnValue := update.UpdateMonitorByID(monitor.ID, monitor, monitor.Script)
-			if err != nil {
-				fmt.Println(err)
-				return err, returnValue
-			}
-			if returnValue.IsContinue == false {
-				return err, returnValue
-			}
-		}
-	} else {
-		//create this synthetics monitor
-		monitor := monitorMap[*monitorId]
-
-		newMonitorId, err, returnValue := create.CreateMonitor(monitor, monitor.Script)
-		if err != nil {
-			fmt.Println(err)
-			return err, returnValue
-		}
-		if returnValue.IsContinue == false {
-
-			return returnValue.OriginalError, returnValue
-		} else {
-			c.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.MonitorID = &newMonitorId
-		}
-	}
+	c.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.MonitorID = monitorId
 
 	if isPolicyCreated == true {
 		//create conditions directly, because the alert policy was new created.
-		_, err, ret := create.CreateCondition(cat, c, alertPolicyID)
+		_, err, ret := create.CreateCondition(ctx, cat, c, alertPolicyID)
 		if err != nil {
 			fmt.Println(err)
 			return err, ret
@@ -601,7 +593,7 @@ func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.Conditio
 		//first check if condition exists by name, because the alert policy was updated.
 		//if so, update condtion, if not, create condition
 		//
-		isConditionExists, conditionId, err, ret := get.IsConditionNameExists(alertPolicyID, *c.AlertsSyntheticsCondition.Name, cat)
+		isConditionExists, conditionId, err, ret := get.IsConditionNameExists(ctx, alertPolicyID, *c.AlertsSyntheticsCondition.Name, cat)
 		if err != nil {
 			fmt.Println(err)
 			ret.IsContinue = false
@@ -615,7 +607,7 @@ func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.Conditio
 				//do nothing
 			} else if mode == "override" {
 				//update condtion
-				_, err, ret := update.UpdateCondition(cat, c, conditionId)
+				_, err, ret := update.UpdateCondition(ctx, cat, c, conditionId)
 				if err != nil {
 					fmt.Println(err)
 					return err, ret
@@ -625,7 +617,7 @@ func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.Conditio
 				}
 			} else if mode == "clean" {
 				//delete current condition
-				err, ret := delete.DeleteCondition(cat, conditionId)
+				err, ret := delete.DeleteCondition(ctx, cat, conditionId)
 				if err != nil {
 					fmt.Println(err)
 					return err, ret
@@ -634,14 +626,14 @@ func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.Conditio
 					return ret.OriginalError, ret
 				}
 				//create condition
-				_, err, ret = create.CreateCondition(cat, c, alertPolicyID)
+				_, err, ret = create.CreateCondition(ctx, cat, c, alertPolicyID)
 				if ret.IsContinue == false {
 					return ret.OriginalError, ret
 				}
 			}
 		} else {
 			//create condtion directly
-			_, err, ret = create.CreateCondition(cat, c, alertPolicyID)
+			_, err, ret = create.CreateCondition(ctx, cat, c, alertPolicyID)
 			if ret.IsContinue == false {
 				return ret.OriginalError, ret
 			}
@@ -653,10 +645,79 @@ func RestoreOneConditionForSynthetics(alertPolicyID int64, cat newrelic.Conditio
 	return nil, ret
 }
 
-func RestorePolicyChannels(policyId int64, channels []*newrelic.AlertsChannel, mode string, isPolicyCreated bool) (error, tracker.ReturnValue) {
+// RestoreOneConditionForLocation restores a location condition like
+// RestoreOneCondition, once the monitors it applies to are restored.
+func RestoreOneConditionForLocation(ctx context.Context, alertPolicyID int64, cat newrelic.ConditionCategory, c *newrelic.AlertsConditionEntity, mode string, monitorMap map[string]*newrelic.Monitor, isPolicyCreated bool) (error, tracker.ReturnValue) {
+	condition := c.AlertsLocationConditionEntity.AlertsLocationCondition
+	for i, monitorId := range condition.Entities {
+		if monitorId == nil {
+			continue
+		}
+		newMonitorId, err, ret := restoreConditionMonitor(ctx, *monitorId, mode, monitorMap)
+		if ret.IsContinue == false {
+			return err, ret
+		}
+		condition.Entities[i] = newMonitorId
+	}
+	return RestoreOneCondition(ctx, alertPolicyID, cat, c, mode, isPolicyCreated)
+}
+
+// restoreConditionMonitor restores the backed up monitor of monitorId, found
+// by name or created, and returns its id. A monitor missing from the backup
+// keeps its id.
+func restoreConditionMonitor(ctx context.Context, monitorId string, mode string, monitorMap map[string]*newrelic.Monitor) (*string, error, tracker.ReturnValue) {
+	if monitorMap[monitorId] == nil || monitorMap[monitorId].Name == nil {
+		return &monitorId, nil, tracker.ToReturnValue(true, "Restore one monitor", nil, nil, "")
+	}
+	monitorName := monitorMap[monitorId].Name
+	//check if monitor exist by monitorId
+	isExists, monitor, err, ret := get.IsMonitorNameExists(ctx, *monitorName)
+	if err != nil {
+		fmt.Println(err)
+		ret.IsContinue = false
+		return nil, err, ret
+	}
+	if ret.IsContinue == false {
+		return nil, err, ret
+	}
+	if isExists == true {
+		if mode == "skip" {
+			//do nothing
+		} else {
+			//update the live monitor with the backed up one
+			backup := *monitorMap[monitorId]
+			backup.ID = monitor.ID
+			err, returnValue := update.UpdateMonitorByID(ctx, monitor.ID, &backup, backup.Script)
+			if err != nil {
+				fmt.Println(err)
+				returnValue.IsContinue = false
+				return nil, err, returnValue
+			}
+			if returnValue.IsContinue == false {
+				return nil, err, returnValue
+			}
+		}
+		return monitor.ID, nil, ret
+	}
+
+	//create this synthetics monitor
+	monitor = monitorMap[monitorId]
+	newMonitorId, err, returnValue := create.CreateMonitor(ctx, monitor, monitor.Script)
+	if err != nil {
+		fmt.Println(err)
+		returnValue.IsContinue = false
+		return nil, err, returnValue
+	}
+	if returnValue.IsContinue == false {
+		return nil, returnValue.OriginalError, returnValue
+	}
+	return &newMonitorId, nil, returnValue
+}
+
+func RestorePolicyChannels(ctx context.Context, policyId int64, channels []*newrelic.AlertsChannel, mode string, isPolicyCreated bool) (error, tracker.ReturnValue) {
 	var channelIds []*int64
 	for _, channel := range channels {
-		isChannelExists, newChannel, err, ret := get.IsChannelNameExists(*channel.Name)
+		isChannelExists, newChannel, err, ret := get.IsChannelNameExists(ctx, *channel.Name)
 		if err != nil {
 			fmt.Println(err)
 			return err, ret
@@ -677,7 +738,7 @@ func RestorePolicyChannels(policyId int64, channels []*newrelic.AlertsChannel, m
 	}
 
 	if isPolicyCreated == true {
-		err, ret := update.UpdatePolicyChannels(policyId, channelIds)
+		err, ret := update.UpdatePolicyChannels(ctx, policyId, channelIds)
 		if err != nil {
 			fmt.Println(err)
 			return err, ret
@@ -689,7 +750,7 @@ func RestorePolicyChannels(policyId int64, channels []*newrelic.AlertsChannel, m
 		if mode == "skip" {
 			//do nothing
 		} else if mode == "override" {
-			err, ret := update.UpdatePolicyChannels(policyId, channelIds)
+			err, ret := update.UpdatePolicyChannels(ctx, policyId, channelIds)
 			if err != nil {
 				fmt.Println(err)
 				ret.IsContinue = false
@@ -700,7 +761,7 @@ func RestorePolicyChannels(policyId int64, channels []*newrelic.AlertsChannel, m
 			}
 		} else if mode == "clean" {
 			//create associations
-			err, ret := update.UpdatePolicyChannels(policyId, channelIds)
+			err, ret := update.UpdatePolicyChannels(ctx, policyId, channelIds)
 			if err != nil {
 				fmt.Println(err)
 				ret.IsContinue = false
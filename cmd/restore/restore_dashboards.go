@@ -17,6 +17,7 @@ package restore
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/IBM/newrelic-cli/cmd/get"
 	"github.com/IBM/newrelic-cli/cmd/update"
 	"github.com/IBM/newrelic-cli/tracker"
+	"github.com/IBM/newrelic-cli/utils"
 	"github.com/spf13/cobra"
 	"github.com/tidwall/gjson"
 )
@@ -39,6 +41,7 @@ var dashboardsCmd = &cobra.Command{
 	Short:   "Restore dashboards from directory.",
 	Example: "nr restore dashboards -d <Directory name where are files to restore>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var restoreFileFolder string
 		var err error
@@ -177,7 +180,7 @@ var dashboardsCmd = &cobra.Command{
 			}
 
 			//delete all dashboards
-			resultStr, err, returnValue := get.GetAllDashboards()
+			resultStr, err, returnValue := get.GetAllDashboards(ctx)
 			if err != nil {
 				fmt.Println(err)
 				exitRestoreDashboardsWithError(returnValue)
@@ -202,7 +205,7 @@ var dashboardsCmd = &cobra.Command{
 					fmt.Println(title.String())
 
 					dashboardId, _ := strconv.ParseInt(id.String(), 10, 64)
-					err, returnValue := delete.DeleteDashboardByID(dashboardId)
+					err, returnValue := delete.DeleteDashboardByID(ctx, dashboardId)
 					if err != nil {
 						fmt.Println(err)
 						exitRestoreDashboardsWithError(returnValue)
@@ -231,6 +234,12 @@ var dashboardsCmd = &cobra.Command{
 			restoreDashboardMeta.FileName = restoreFileName
 			restoreDashboardMeta.OperationStatus = "fail"
 
+			if utils.IsCancelled() {
+				// Interrupted, the files left are logged as failed to be restored later.
+				restoreDashboardMetaArray = append(restoreDashboardMetaArray, restoreDashboardMeta)
+				continue
+			}
+
 			restoreFile, err := os.Open(restoreFileName)
 			defer restoreFile.Close()
 			if err != nil {
@@ -249,7 +258,7 @@ var dashboardsCmd = &cobra.Command{
 				continue
 			}
 
-			isRestored, err, ret := RestoreOneDashboard(fileContent, updateMode)
+			isRestored, err, ret := RestoreOneDashboard(ctx, fileContent, updateMode)
 			if err != nil {
 				fmt.Println(err)
 				goto next
@@ -284,10 +293,10 @@ var dashboardsCmd = &cobra.Command{
 	},
 }
 
-func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tracker.ReturnValue) {
+func RestoreOneDashboard(ctx context.Context, dashboardContent string, mode string) (bool, error, tracker.ReturnValue) {
 
 	title := gjson.Parse(dashboardContent).Get("dashboard.title").String()
-	isExist, _, err, ret := get.IsDashboardTitleExists(title)
+	isExist, _, err, ret := get.IsDashboardTitleExists(ctx, title)
 	if err != nil {
 		fmt.Println(err)
 		return false, err, ret
@@ -304,7 +313,7 @@ func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tra
 			return true, err, ret
 		} else {
 			//create the dashboard
-			_, err, ret := create.CreateDashboard(dashboardContent)
+			_, err, ret := create.CreateDashboard(ctx, dashboardContent)
 			if err != nil {
 				fmt.Println(err)
 				ret.IsContinue = false
@@ -320,7 +329,7 @@ func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tra
 		if isExist == true {
 			//update all by title
 			title := gjson.Parse(dashboardContent).Get("dashboard.title").String()
-			err, ret := update.UpdateByDashboardTitle(dashboardContent, title)
+			err, ret := update.UpdateByDashboardTitle(ctx, dashboardContent, title)
 			if err != nil {
 				fmt.Println(err)
 				return false, err, ret
@@ -331,7 +340,7 @@ func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tra
 			return true, err, ret
 		} else {
 			//create the dashboard
-			_, err, ret := create.CreateDashboard(dashboardContent)
+			_, err, ret := create.CreateDashboard(ctx, dashboardContent)
 			if err != nil {
 				fmt.Println(err)
 				return false, err, ret
@@ -346,7 +355,7 @@ func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tra
 		if isExist == true {
 			//delete dashbaord by title
 			title := gjson.Parse(dashboardContent).Get("dashboard.title").String()
-			err, ret := delete.DeleteByDashboardTitle(title)
+			err, ret := delete.DeleteByDashboardTitle(ctx, title)
 			if err != nil {
 				fmt.Println(err)
 				return false, err, ret
@@ -356,7 +365,7 @@ func RestoreOneDashboard(dashboardContent string, mode string) (bool, error, tra
 			}
 		}
 		//create the dashboard
-		_, err, ret = create.CreateDashboard(dashboardContent)
+		_, err, ret = create.CreateDashboard(ctx, dashboardContent)
 		if err != nil {
 			fmt.Println(err)
 			return false, err, ret
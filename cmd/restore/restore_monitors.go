@@ -40,6 +40,7 @@ var monitorsCmd = &cobra.Command{
 	Short:   "Restore monitors from directory.",
 	Example: "nr restore monitors -d <Directory name where are files to restore>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 
 		var restoreFileFolder string
 		var err error
@@ -178,7 +179,7 @@ var monitorsCmd = &cobra.Command{
 			}
 
 			//delete all monitors
-			monitors, err, returnValue := get.GetMonitors()
+			monitors, err, returnValue := get.GetMonitors(ctx)
 			if err != nil {
 				fmt.Println(err)
 				writeFailRestoreMonitorsFileList(resultFileName, rmmArray)
@@ -196,7 +197,11 @@ var monitorsCmd = &cobra.Command{
 			fmt.Println()
 			fmt.Println("Deleting all monitors...")
 			for _, monitor := range monitors {
-				err, returnValue := delete.DeleteMonitorByID(*monitor.ID)
+				err, returnValue := delete.DeleteMonitorByID(ctx, *monitor.ID)
+				if newrelic.IsNotFound(err) {
+					// Already deleted since it was listed.
+					continue
+				}
 				if err != nil {
 					fmt.Println(err)
 					writeFailRestoreMonitorsFileList(resultFileName, rmmArray)
@@ -221,6 +226,12 @@ var monitorsCmd = &cobra.Command{
 			restoreMonitorMeta.FileName = restoreFileName
 			restoreMonitorMeta.OperationStatus = "fail"
 
+			if utils.IsCancelled() {
+				// Interrupted, the files left are logged as failed to be restored later.
+				restoreMonitorMetaArray = append(restoreMonitorMetaArray, restoreMonitorMeta)
+				continue
+			}
+
 			fmt.Println("start to restore monitor in file: " + restoreFileName)
 			isBak := strings.HasSuffix(restoreFileName, ".monitor.bak")
 			if isBak == true {
@@ -277,7 +288,7 @@ var monitorsCmd = &cobra.Command{
 					if *monitor.Type == "SCRIPT_BROWSER" || *monitor.Type == "SCRIPT_API" {
 						scriptTextEncoded = monitor.Script
 					}
-					_, err, returnValue := create.CreateMonitor(monitor, scriptTextEncoded)
+					_, err, returnValue := create.CreateMonitor(ctx, monitor, scriptTextEncoded)
 					if err != nil {
 						restoreMonitorMeta.OperationStatus = "fail"
 						restoreMonitorMetaArray = append(restoreMonitorMetaArray, restoreMonitorMeta)
@@ -302,8 +313,8 @@ var monitorsCmd = &cobra.Command{
 					}
 					backupMonitorId := monitor.ID
 					//try to create, if response status code is 400, monitor exist, then update
-					// _, err, returnValue := create.CreateMonitor(monitor, scriptTextEncoded)
-					isExists, _, err, returnValue := get.IsMonitorNameExists(*monitor.Name)
+					// _, err, returnValue := create.CreateMonitor(ctx, monitor, scriptTextEncoded)
+					isExists, _, err, returnValue := get.IsMonitorNameExists(ctx, *monitor.Name)
 					if err != nil {
 						restoreMonitorMetaArray = append(restoreMonitorMetaArray, restoreMonitorMeta)
 						continue
@@ -313,7 +324,7 @@ var monitorsCmd = &cobra.Command{
 							continue
 						}
 						if isExists == false {
-							_, err, returnValue := create.CreateMonitor(monitor, scriptTextEncoded)
+							_, err, returnValue := create.CreateMonitor(ctx, monitor, scriptTextEncoded)
 							if err != nil {
 								restoreMonitorMetaArray = append(restoreMonitorMetaArray, restoreMonitorMeta)
 								continue
@@ -328,7 +339,7 @@ var monitorsCmd = &cobra.Command{
 							if updateMode == "override" {
 								//update monitor
 								monitor.ID = backupMonitorId
-								err, ret := update.UpdateMonitorByName(monitor, scriptTextEncoded)
+								err, ret := update.UpdateMonitorByName(ctx, monitor, scriptTextEncoded)
 								if err != nil {
 									restoreMonitorMetaArray = append(restoreMonitorMetaArray, restoreMonitorMeta)
 									continue
@@ -19,14 +19,22 @@ import (
 	"fmt"
 	"os"
 
+	ackCmd "github.com/IBM/newrelic-cli/cmd/ack"
 	addCmd "github.com/IBM/newrelic-cli/cmd/add"
+	authCmd "github.com/IBM/newrelic-cli/cmd/auth"
 	backupCmd "github.com/IBM/newrelic-cli/cmd/backup"
+	closeCmd "github.com/IBM/newrelic-cli/cmd/close"
+	configCmd "github.com/IBM/newrelic-cli/cmd/config"
 	createCmd "github.com/IBM/newrelic-cli/cmd/create"
 	deleteCmd "github.com/IBM/newrelic-cli/cmd/delete"
+	devCmd "github.com/IBM/newrelic-cli/cmd/dev"
 	getCmd "github.com/IBM/newrelic-cli/cmd/get"
+	graphqlCmd "github.com/IBM/newrelic-cli/cmd/graphql"
 	insertCmd "github.com/IBM/newrelic-cli/cmd/insert"
 	patchCmd "github.com/IBM/newrelic-cli/cmd/patch"
+	queryCmd "github.com/IBM/newrelic-cli/cmd/query"
 	restoreCmd "github.com/IBM/newrelic-cli/cmd/restore"
+	tagCmd "github.com/IBM/newrelic-cli/cmd/tag"
 	takeCmd "github.com/IBM/newrelic-cli/cmd/take"
 	updateCmd "github.com/IBM/newrelic-cli/cmd/update"
 	homedir "github.com/mitchellh/go-homedir"
@@ -66,7 +74,23 @@ func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.nr.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.nr.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "Account profile of the config file to use. Default is NEW_RELIC_PROFILE, or current_profile of the config file.")
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	rootCmd.PersistentFlags().String("region", "", "NewRelic region of the account, US or EU. Default is US, or NEW_RELIC_REGION if set.")
+	viper.BindPFlag("region", rootCmd.PersistentFlags().Lookup("region"))
+	rootCmd.PersistentFlags().String("record", "", "Save every NewRelic API call, with secrets redacted, as a file in this folder.")
+	viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	rootCmd.PersistentFlags().String("replay", "", "Answer NewRelic API calls with the ones saved by --record in this folder, without network.")
+	viper.BindPFlag("replay", rootCmd.PersistentFlags().Lookup("replay"))
+	rootCmd.PersistentFlags().CountP("verbose", "v", "Log every NewRelic API call to stderr, -vv to also log headers and bodies. Secrets are redacted.")
+	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	rootCmd.PersistentFlags().Bool("curl", false, "Print every NewRelic API call as a curl command to stderr. Secrets are redacted.")
+	viper.BindPFlag("curl", rootCmd.PersistentFlags().Lookup("curl"))
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Give up the command after this long, e.g. 5m. Also NEW_RELIC_TIMEOUT. No limit by default.")
+	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	rootCmd.PersistentFlags().Duration("request-timeout", 0, "Give up a single NewRelic API call attempt after this long, e.g. 30s. Also NEW_RELIC_REQUEST_TIMEOUT. No limit by default.")
+	viper.BindPFlag("request-timeout", rootCmd.PersistentFlags().Lookup("request-timeout"))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -82,6 +106,14 @@ func init() {
 	rootCmd.AddCommand(addCmd.AddCmd)
 	rootCmd.AddCommand(insertCmd.InsertCmd)
 	rootCmd.AddCommand(takeCmd.TakeCmd)
+	rootCmd.AddCommand(devCmd.DevCmd)
+	rootCmd.AddCommand(configCmd.ConfigCmd)
+	rootCmd.AddCommand(authCmd.AuthCmd)
+	rootCmd.AddCommand(ackCmd.AckCmd)
+	rootCmd.AddCommand(closeCmd.CloseCmd)
+	rootCmd.AddCommand(queryCmd.QueryCmd)
+	rootCmd.AddCommand(graphqlCmd.GraphQLCmd)
+	rootCmd.AddCommand(tagCmd.TagCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -102,7 +134,8 @@ func initConfig() {
 		viper.SetConfigName(".nr")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	viper.SetEnvPrefix("NEW_RELIC")
+	viper.AutomaticEnv() // read in environment variables that match, like NEW_RELIC_REGION
 
 	// If a config file is found, read it in.
 	viper.ReadInConfig()
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tag
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/cmd/get"
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+// TagCmd represents the tag command
+var TagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add, remove or replace the tags of NewRelic entities.",
+	Long: `Add, remove or replace the tags of NewRelic entities, like APM applications,
+Synthetics monitors or hosts, through NerdGraph.
+
+The entity is given by GUID or by name as first argument, --type telling
+apart entities of the same name. With --tag or --query, the tags apply instead
+to all the entities having these tags or matching this entity search query,
+and all the arguments are tags.`,
+}
+
+// selectEntities returns the entities the tags apply to, and the arguments
+// giving the tags.
+func selectEntities(ctx context.Context, client *newrelic.Client, cmd *cobra.Command, args []string) ([]*newrelic.Entity, []string, error) {
+	if query, _ := cmd.Flags().GetString("query"); query != "" || utils.HasSelector(cmd) {
+		entities, err := get.SearchEntities(ctx, client, cmd)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(entities) == 0 {
+			return nil, nil, fmt.Errorf("No entity matches the selection.")
+		}
+		return entities, args, nil
+	}
+
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("Please give the GUID or the name of the entity, or --tag or --query.")
+	}
+	entity, err := findEntity(ctx, client, cmd, args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return []*newrelic.Entity{entity}, args[1:], nil
+}
+
+// findEntity returns the entity of the given GUID or name.
+func findEntity(ctx context.Context, client *newrelic.Client, cmd *cobra.Command, entity string) (*newrelic.Entity, error) {
+	if isGUID(entity) {
+		found, _, err := client.Entities.GetByGUID(ctx, entity)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	opt := &newrelic.EntitySearchOptions{Name: entity}
+	opt.Type, _ = cmd.Flags().GetString("type")
+	entities, err := client.Entities.SearchAll(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	var named []*newrelic.Entity
+	for _, e := range entities {
+		if e.Name != nil && *e.Name == entity {
+			named = append(named, e)
+		}
+	}
+	switch len(named) {
+	case 0:
+		return nil, fmt.Errorf("Entity %q not found.", entity)
+	case 1:
+		return named[0], nil
+	}
+	var guids []string
+	for _, e := range named {
+		guids = append(guids, fmt.Sprintf("%s (%s)", *e.GUID, *e.Type))
+	}
+	return nil, fmt.Errorf("Several entities are named %q: %s. Give the GUID, or --type.", entity, strings.Join(guids, ", "))
+}
+
+// isGUID reports whether s looks like an entity GUID: the base64 of the
+// account, domain, type and ID of the entity separated by |.
+func isGUID(s string) bool {
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	return err == nil && strings.Count(string(data), "|") >= 3
+}
+
+// parseTags returns the tags of args, given as key=value, the values of the
+// same key merged into one tag. key alone is only allowed with keysAllowed,
+// and returned in keys.
+func parseTags(args []string, keysAllowed bool) (tags []*newrelic.Tag, keys []string, err error) {
+	byKey := map[string]*newrelic.Tag{}
+	for _, arg := range args {
+		i := strings.Index(arg, "=")
+		if i < 0 && keysAllowed && arg != "" {
+			keys = append(keys, arg)
+			continue
+		}
+		if i <= 0 || i == len(arg)-1 {
+			return nil, nil, fmt.Errorf("Invalid tag %q, must be like key=value.", arg)
+		}
+		key, value := arg[:i], arg[i+1:]
+		tag := byKey[key]
+		if tag == nil {
+			tag = &newrelic.Tag{Key: &key}
+			byKey[key] = tag
+			tags = append(tags, tag)
+		}
+		tag.Values = append(tag.Values, &value)
+	}
+	if len(tags) == 0 && len(keys) == 0 {
+		return nil, nil, fmt.Errorf("Please give the tags, like key=value.")
+	}
+	return tags, keys, nil
+}
+
+// tagEntities calls tag on every entity, printing done or the error, and
+// exits with 1 if any failed.
+func tagEntities(entities []*newrelic.Entity, tag func(guid string) error, done string) {
+	failed := false
+	for _, entity := range entities {
+		name := fmt.Sprintf("%s (%s)", *entity.Name, *entity.GUID)
+		if err := tag(*entity.GUID); err != nil {
+			fmt.Printf("Failed to update the tags of %s: %v\n", name, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s %s.\n", done, name)
+	}
+	if failed {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func init() {
+	TagCmd.PersistentFlags().String("type", "", "Type of the entities, like APPLICATION, MONITOR or HOST")
+	TagCmd.PersistentFlags().String("query", "", "Tag all the entities matching this entity search query, like \"name LIKE 'web'\"")
+	TagCmd.PersistentFlags().StringArray("tag", nil, "Tag all the entities having this tag, like key=value or key. Can be repeated")
+}
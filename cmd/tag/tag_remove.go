@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tag
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <guid|name> key[=value] ...",
+	Short: "Remove tag values, or whole tags given by key, from entities.",
+	Example: `nr tag remove web team=ops
+nr tag remove web owner
+nr tag remove --tag env=staging owner`,
+	Aliases: []string{"rm"},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient("graphql")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		entities, tagArgs, err := selectEntities(ctx, client, cmd, args)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		tags, keys, err := parseTags(tagArgs, true)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		var values []newrelic.TagValue
+		for _, tag := range tags {
+			for _, value := range tag.Values {
+				values = append(values, newrelic.TagValue{Key: *tag.Key, Value: *value})
+			}
+		}
+
+		tagEntities(entities, func(guid string) error {
+			if len(keys) > 0 {
+				if _, err := client.Entities.DeleteTags(ctx, guid, keys); err != nil {
+					return err
+				}
+			}
+			if len(values) > 0 {
+				if _, err := client.Entities.DeleteTagValues(ctx, guid, values); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, "Removed tags from")
+	},
+}
+
+func init() {
+	TagCmd.AddCommand(removeCmd)
+}
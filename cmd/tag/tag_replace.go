@@ -0,0 +1,64 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tag
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/IBM/newrelic-cli/utils"
+)
+
+var replaceCmd = &cobra.Command{
+	Use:   "replace <guid|name> key=value ...",
+	Short: "Replace all the tags of entities.",
+	Long: `Replace all the tags of entities by the given ones. The tags not given are
+removed.`,
+	Example: `nr tag replace web team=web env=production
+nr tag replace --tag team=legacy team=web env=production`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
+		client, err := utils.GetNewRelicClient("graphql")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		entities, tagArgs, err := selectEntities(ctx, client, cmd, args)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+		tags, _, err := parseTags(tagArgs, false)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+			return
+		}
+
+		tagEntities(entities, func(guid string) error {
+			_, err := client.Entities.ReplaceTags(ctx, guid, tags)
+			return err
+		}, "Replaced the tags of")
+	},
+}
+
+func init() {
+	TagCmd.AddCommand(replaceCmd)
+}
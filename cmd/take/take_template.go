@@ -29,7 +29,7 @@ var templateCmd = &cobra.Command{
 	Short: "Take JSON templdate file for creating target by template type name.",
 	Example: "nr take template [ monitor_simple | monitor_script_inline | alertspolicies | " +
 		"dashboard | alertsconditions_infra | alertsconditions_nrql | alertsconditions_synthetics | " +
-		"alertsconditions_plugin | alertsconditions_ext | alertsconditions_apm | " +
+		"alertsconditions_location | alertsconditions_plugin | alertsconditions_ext | alertsconditions_apm | " +
 		"alertschannels_campfire | alertschannels_email | alertschannels_hipchat | " +
 		"alertschannels_opsgenie | alertschannels_pagerduty | alertschannels_victorops | " +
 		"alertschannels_webhook_json | alertschannels_webhook_form ]",
@@ -101,6 +101,8 @@ func getTemplateByName(name string) (string, string, error) {
 		fileName, templateContentEncoded = getTemplateFileContent_alertsconditions_nrql()
 	case "alertsconditions_synthetics":
 		fileName, templateContentEncoded = getTemplateFileContent_alertsconditions_synthetics()
+	case "alertsconditions_location":
+		fileName, templateContentEncoded = getTemplateFileContent_alertsconditions_location()
 	case "alertsconditions_plugin":
 		fileName, templateContentEncoded = getTemplateFileContent_alertsconditions_plugin()
 	case "alertsconditions_ext":
@@ -189,6 +191,12 @@ func getTemplateFileContent_alertsconditions_synthetics() (string, string) {
 	return fileName, templateContent
 }
 
+func getTemplateFileContent_alertsconditions_location() (string, string) {
+	fileName := "alertsconditions_location.json"
+	templateContent := "ewogICJsb2NhdGlvbl9mYWlsdXJlX2NvbmRpdGlvbiI6IHsKICAgICJuYW1lIjogInN0cmluZyIsCiAgICAiZW5hYmxlZCI6ICJib29sZWFuIiwKICAgICJlbnRpdGllcyI6IFsKICAgICAgInN0cmluZyIKICAgIF0sCiAgICAicnVuYm9va191cmwiOiAic3RyaW5nIiwKICAgICJ0ZXJtcyI6IFsKICAgICAgewogICAgICAgICJwcmlvcml0eSI6ICJzdHJpbmciLAogICAgICAgICJ0aHJlc2hvbGQiOiAiaW50ZWdlciIKICAgICAgfQogICAgXSwKICAgICJ2aW9sYXRpb25fdGltZV9saW1pdF9zZWNvbmRzIjogImludGVnZXIiCiAgfQp9"
+	return fileName, templateContent
+}
+
 func getTemplateFileContent_alertsconditions_plugin() (string, string) {
 	fileName := "alertsconditions_plugin.json"
 	templateContent := "ewogICJwbHVnaW5zX2NvbmRpdGlvbiI6IHsKICAgICJuYW1lIjogInN0cmluZyIsCiAgICAiZW5hYmxlZCI6ICJib29sZWFuIiwKICAgICJlbnRpdGllcyI6IFsKICAgICAgImludGVnZXIiCiAgICBdLAogICAgIm1ldHJpY19kZXNjcmlwdGlvbiI6ICJzdHJpbmciLAogICAgIm1ldHJpYyI6ICJzdHJpbmciLAogICAgInZhbHVlX2Z1bmN0aW9uIjogInN0cmluZyIsCiAgICAicnVuYm9va191cmwiOiAic3RyaW5nIiwKICAgICJ0ZXJtcyI6IFsKICAgICAgewogICAgICAgICJkdXJhdGlvbiI6ICJzdHJpbmciLAogICAgICAgICJvcGVyYXRvciI6ICJzdHJpbmciLAogICAgICAgICJwcmlvcml0eSI6ICJzdHJpbmciLAogICAgICAgICJ0aHJlc2hvbGQiOiAic3RyaW5nIiwKICAgICAgICAidGltZV9mdW5jdGlvbiI6ICJzdHJpbmciCiAgICAgIH0KICAgIF0sCiAgICAicGx1Z2luIjogewogICAgICAiaWQiOiAic3RyaW5nIiwKICAgICAgImd1aWQiOiAic3RyaW5nIgogICAgfQogIH0KfQ=="
@@ -33,6 +33,7 @@ var alertschannelsCmd = &cobra.Command{
 	Short:   "Update policy/channel associations from a file.",
 	Example: "nr update alertschannels -f <example.json>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -62,7 +63,7 @@ var alertschannelsCmd = &cobra.Command{
 		}
 
 		// start to udpate
-		err, _ = UpdatePolicyChannels(*p.PolicyID, p.ChannelIDList)
+		err, _ = UpdatePolicyChannels(ctx, *p.PolicyID, p.ChannelIDList)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -73,7 +74,7 @@ var alertschannelsCmd = &cobra.Command{
 	},
 }
 
-func UpdatePolicyChannels(policyId int64, channelIds []*int64) (error, tracker.ReturnValue) {
+func UpdatePolicyChannels(ctx context.Context, policyId int64, channelIds []*int64) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -81,21 +82,14 @@ func UpdatePolicyChannels(policyId int64, channelIds []*int64) (error, tracker.R
 		return err, ret
 	}
 
-	resp, err := client.AlertsChannels.UpdatePolicyChannels(context.Background(), policyId, channelIds)
+	resp, err := client.AlertsChannels.UpdatePolicyChannels(ctx, policyId, channelIds)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.AlertsChannels, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, err, "")
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsChannels, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, resp.StatusCode, "")
-
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Update policy and channels associations.'\n", statusCode)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsChannels, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, resp.StatusCode, "")
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_CHANNEL, nil, nil, "")
 	return nil, ret
 }
@@ -49,6 +49,7 @@ var alertsconditionsCmd = &cobra.Command{
 		}
 		return nil
 	}, Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -117,6 +118,21 @@ var alertsconditionsCmd = &cobra.Command{
 			cat = newrelic.ConditionSynthetics
 			ac.AlertsSyntheticsConditionEntity = ace
 			// alertConditionID = *ac.AlertsSyntheticsConditionEntity.AlertsSyntheticsCondition.ID
+		case "location":
+			var ace = new(newrelic.AlertsLocationConditionEntity)
+			err = decorder.Decode(ace)
+			if err != nil {
+				fmt.Printf("Unable to decode for location type condition %q: %v\n", file, err)
+				os.Exit(1)
+				return
+			}
+			if reflect.DeepEqual(new(newrelic.AlertsLocationConditionEntity), ace) {
+				fmt.Printf("Error validating for location type condition %q.\n", file)
+				os.Exit(1)
+				return
+			}
+			cat = newrelic.ConditionLocation
+			ac.AlertsLocationConditionEntity = ace
 		case "ext":
 			var ace = new(newrelic.AlertsExternalServiceConditionEntity)
 			err = decorder.Decode(ace)
@@ -189,7 +205,7 @@ var alertsconditionsCmd = &cobra.Command{
 			os.Exit(1)
 			return
 		}
-		_, resp, err := client.AlertsConditions.Update(context.Background(), cat, ac, alertConditionID)
+		_, resp, err := client.AlertsConditions.Update(ctx, cat, ac, alertConditionID)
 		if err != nil {
 			fmt.Printf("Failed to update condition, %v\n", err)
 			os.Exit(1)
@@ -197,15 +213,11 @@ var alertsconditionsCmd = &cobra.Command{
 		}
 		statusCode := resp.StatusCode
 		fmt.Printf("Response status code: %d. Update alert conditions, condition id: '%d'\n", statusCode, alertConditionID)
-		if statusCode >= 400 {
-			os.Exit(1)
-			return
-		}
 		os.Exit(0)
 	},
 }
 
-func UpdateCondition(cat newrelic.ConditionCategory, ac *newrelic.AlertsConditionEntity, alertConditionID int64) (*newrelic.AlertsConditionEntity, error, tracker.ReturnValue) {
+func UpdateCondition(ctx context.Context, cat newrelic.ConditionCategory, ac *newrelic.AlertsConditionEntity, alertConditionID int64) (*newrelic.AlertsConditionEntity, error, tracker.ReturnValue) {
 	// start to update
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
@@ -213,20 +225,14 @@ func UpdateCondition(cat newrelic.ConditionCategory, ac *newrelic.AlertsConditio
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return nil, err, ret
 	}
-	alertsConditionEntity, resp, err := client.AlertsConditions.Update(context.Background(), cat, ac, alertConditionID)
+	alertsConditionEntity, resp, err := client.AlertsConditions.Update(ctx, cat, ac, alertConditionID)
 	if err != nil {
 		fmt.Printf("Failed to update condition, %v\n", err)
+		tracker.AppendRESTCallError(client.AlertsConditions, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, err, "alert condtion id:"+strconv.FormatInt(alertConditionID, 10))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return nil, err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, resp.StatusCode, "alert condtion id:"+strconv.FormatInt(alertConditionID, 10))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Update alert conditions, condition id: '%d'\n", statusCode, alertConditionID)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsConditions, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, resp.StatusCode, "alert condtion id:"+strconv.FormatInt(alertConditionID, 10))
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_UPDATE_ALERT_CONDITION_BY_ID, nil, nil, "")
 	return alertsConditionEntity, err, ret
 }
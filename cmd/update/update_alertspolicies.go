@@ -35,6 +35,7 @@ var alertspoliciesCmd = &cobra.Command{
 	Aliases: []string{"ap", "alertpolicy", "alertspolicy"},
 	Example: "nr update alertspolicies -f <example.yaml>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -74,50 +75,39 @@ var alertspoliciesCmd = &cobra.Command{
 			os.Exit(1)
 			return
 		}
-		_, resp, err := client.AlertsPolicies.Update(context.Background(), p, *p.AlertsPolicy.ID)
+		_, resp, err := client.AlertsPolicies.Update(ctx, p, *p.AlertsPolicy.ID)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 			return
-		} else {
-			fmt.Println(resp.Status)
-			if resp.StatusCode >= 400 {
-				os.Exit(1)
-				return
-			}
 		}
+		fmt.Println(resp.Status)
 
 		os.Exit(0)
 	},
 }
 
-func UpdateByPolicyID(policy *newrelic.AlertsPolicyEntity, alertPolicyID int64) (*newrelic.AlertsPolicyEntity, error, tracker.ReturnValue) {
+func UpdateByPolicyID(ctx context.Context, policy *newrelic.AlertsPolicyEntity, alertPolicyID int64) (*newrelic.AlertsPolicyEntity, error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, err, tracker.ERR_CREATE_NR_CLINET, "")
 		return nil, err, ret
 	}
-	policyEntity, resp, err := client.AlertsPolicies.Update(context.Background(), policy, alertPolicyID)
+	policyEntity, resp, err := client.AlertsPolicies.Update(ctx, policy, alertPolicyID)
 	if err != nil {
 		fmt.Printf("Failed to update alert policy, %v\n", err)
+		tracker.AppendRESTCallError(client.AlertsPolicies, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, err, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10)+",alert policy name:"+(*policy.AlertsPolicy.Name))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return nil, err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, resp.StatusCode, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10)+",alert policy name:"+(*policy.AlertsPolicy.Name))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Update alert policy, alert policy id: '%d'\n", statusCode, alertPolicyID)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return nil, err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.AlertsPolicies, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, resp.StatusCode, "alert policy id:"+strconv.FormatInt(alertPolicyID, 10)+",alert policy name:"+(*policy.AlertsPolicy.Name))
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_UPDATE_ALERT_POLICY_BY_ID, nil, nil, "")
 	return policyEntity, err, ret
 }
 
-func UpdateByPolicyName(policy *newrelic.AlertsPolicy, policyName string) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
-	list, err, ret := get.GetAllAlertPolicies()
+func UpdateByPolicyName(ctx context.Context, policy *newrelic.AlertsPolicy, policyName string) (*newrelic.AlertsPolicy, error, tracker.ReturnValue) {
+	list, err, ret := get.GetAllAlertPolicies(ctx)
 	if err != nil {
 		fmt.Println(err)
 		return nil, err, ret
@@ -131,7 +121,7 @@ func UpdateByPolicyName(policy *newrelic.AlertsPolicy, policyName string) (*newr
 			policy.ID = p.ID
 			var pEntity *newrelic.AlertsPolicyEntity = &newrelic.AlertsPolicyEntity{}
 			pEntity.AlertsPolicy = policy
-			newPolicy, err, ret := UpdateByPolicyID(pEntity, *policy.ID)
+			newPolicy, err, ret := UpdateByPolicyID(ctx, pEntity, *policy.ID)
 			if err != nil {
 				fmt.Println(err)
 				return nil, err, ret
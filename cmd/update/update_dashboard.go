@@ -36,6 +36,7 @@ var dashboardCmd = &cobra.Command{
 	Short:   "Update dashboard from a json file.",
 	Example: "nr update dashboard -f <example.json>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		fileName, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -58,11 +59,11 @@ var dashboardCmd = &cobra.Command{
 		//start to update
 		id := gjson.Parse(fileContent).Get("id").String()
 		dashboardId, _ := strconv.ParseInt(id, 10, 64)
-		UpdateDashboardByID(fileContent, dashboardId)
+		UpdateDashboardByID(ctx, fileContent, dashboardId)
 	},
 }
 
-func UpdateDashboardByID(dashboard string, id int64) (error, tracker.ReturnValue) {
+func UpdateDashboardByID(ctx context.Context, dashboard string, id int64) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient()
 	if err != nil {
 		fmt.Println(err)
@@ -71,30 +72,23 @@ func UpdateDashboardByID(dashboard string, id int64) (error, tracker.ReturnValue
 	}
 	title := gjson.Parse(dashboard).Get("title").String()
 
-	resp, bytes, err := client.Dashboards.Update(context.Background(), dashboard, id)
-
-	var retMsg string
+	resp, _, err := client.Dashboards.Update(ctx, dashboard, id)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.Dashboards, tracker.OPERATION_NAME_UPDATE_DASHBOARD_BY_ID, err, "dashboard title:"+title+", dashboard id:"+strconv.FormatInt(id, 10))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_DASHBOARD_BY_ID, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		retMsg = string(bytes)
-		tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_UPDATE_DASHBOARD_BY_ID, resp.StatusCode, "dashboard title:"+title+", dashboard id:"+strconv.FormatInt(id, 10))
-		var statusCode = resp.StatusCode
-		fmt.Printf("Response status code: %d. Update dashboard '%s'', dashboard id: '%d'\n", statusCode, title, id)
-		if resp.StatusCode >= 400 {
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_DASHBOARD_BY_ID, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, retMsg)
-			return err, ret
-		}
 	}
+	tracker.AppendRESTCallResult(client.Dashboards, tracker.OPERATION_NAME_UPDATE_DASHBOARD_BY_ID, resp.StatusCode, "dashboard title:"+title+", dashboard id:"+strconv.FormatInt(id, 10))
+	var statusCode = resp.StatusCode
+	fmt.Printf("Response status code: %d. Update dashboard '%s'', dashboard id: '%d'\n", statusCode, title, id)
 
 	ret := tracker.ToReturnValue(true, tracker.OPERATION_NAME_CREATE_DASHBOARD, nil, nil, "")
 	return err, ret
 }
 
-func UpdateByDashboardTitle(dashboardContent string, title string) (error, tracker.ReturnValue) {
-	resultStr, err, ret := get.GetAllDashboards()
+func UpdateByDashboardTitle(ctx context.Context, dashboardContent string, title string) (error, tracker.ReturnValue) {
+	resultStr, err, ret := get.GetAllDashboards(ctx)
 	if err != nil {
 		fmt.Println(err)
 		return err, ret
@@ -111,7 +105,7 @@ func UpdateByDashboardTitle(dashboardContent string, title string) (error, track
 			dashboardContent2, _ := sjson.Set(dashboardContent, "dashboard.id", id.Num)
 			fmt.Println(dashboardContent2)
 			dashboardId, _ := strconv.ParseInt(id.String(), 10, 64)
-			err, ret := UpdateDashboardByID(dashboardContent2, dashboardId)
+			err, ret := UpdateDashboardByID(ctx, dashboardContent2, dashboardId)
 			if err != nil {
 				fmt.Println(err)
 				return err, ret
@@ -39,6 +39,7 @@ var monitorCmd = &cobra.Command{
 	Short:   "Update monitor from a file.",
 	Example: "nr update monitor -f <example.yaml>",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := utils.GetContext()
 		file, err := utils.GetArg(cmd, "file")
 		if err != nil {
 			fmt.Printf("Unable to get argument 'file': %v\n", err)
@@ -80,13 +81,14 @@ var monitorCmd = &cobra.Command{
 			return
 		}
 
-		resp, err := client.SyntheticsMonitors.Update(context.Background(), p, p.ID)
+		resp, err := client.SyntheticsMonitors.Update(ctx, p, p.ID)
 		if err != nil {
 			fmt.Println(err)
-		} else {
-			fmt.Println(resp.Status)
-			fmt.Println(resp.StatusCode)
+			os.Exit(1)
+			return
 		}
+		fmt.Println(resp.Status)
+		fmt.Println(resp.StatusCode)
 
 		if *p.Type == "SCRIPT_BROWSER" || *p.Type == "SCRIPT_API" {
 			var scriptTextEncoded *newrelic.Script
@@ -121,18 +123,13 @@ var monitorCmd = &cobra.Command{
 
 			if scriptTextEncoded != nil && scriptTextEncoded.ScriptText != nil {
 				id := *p.ID
-				resp, err := client.SyntheticsScript.UpdateByID(context.Background(), scriptTextEncoded, id)
+				resp, err := client.SyntheticsScript.UpdateByID(ctx, scriptTextEncoded, id)
 				if err != nil {
 					fmt.Println(err)
 					os.Exit(1)
 					return
-				} else {
-					fmt.Println(resp.Status)
-					if resp.StatusCode >= 400 {
-						os.Exit(1)
-						return
-					}
 				}
+				fmt.Println(resp.Status)
 			}
 		}
 
@@ -140,7 +137,7 @@ var monitorCmd = &cobra.Command{
 	},
 }
 
-func UpdateMonitorByID(monitorId *string, p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (error, tracker.ReturnValue) {
+func UpdateMonitorByID(ctx context.Context, monitorId *string, p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
@@ -148,42 +145,28 @@ func UpdateMonitorByID(monitorId *string, p *newrelic.Monitor, scriptTextEncoded
 		return err, ret
 	}
 	//update monitor itself
-	resp, err := client.SyntheticsMonitors.Update(context.Background(), p, monitorId)
+	resp, err := client.SyntheticsMonitors.Update(ctx, p, monitorId)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, err, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Update monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, *monitorId)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
-
 	}
+	tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 	//update script if needed
 	if scriptTextEncoded != nil && scriptTextEncoded.ScriptText != nil {
 		id := *p.ID
-		resp, err := client.SyntheticsScript.UpdateByID(context.Background(), scriptTextEncoded, id)
+		resp, err := client.SyntheticsScript.UpdateByID(ctx, scriptTextEncoded, id)
 		if err != nil {
 			fmt.Println(err)
+			tracker.AppendRESTCallError(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, err, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, err, tracker.ERR_REST_CALL, "")
 			return err, ret
-		} else {
-			tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
-
-			if resp.StatusCode >= 400 {
-				var statusCode = resp.StatusCode
-				fmt.Printf("Response status code: %d. Update script to monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, id)
-				ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-				return err, ret
-			}
 		}
+		tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 	}
 	//update labels if needed
-	labelList, err, ret := get.GetLabelsByMonitorID(*p.ID)
+	labelList, err, ret := get.GetLabelsByMonitorID(ctx, *p.ID)
 	if ret.IsContinue == false {
 		return err, ret
 	}
@@ -191,7 +174,7 @@ func UpdateMonitorByID(monitorId *string, p *newrelic.Monitor, scriptTextEncoded
 	if labelListLen > 0 {
 		//delete all labels on this monitor first
 		for _, label := range labelList {
-			err, ret := delete.DeleteLabelFromMonitor(*p.ID, *label)
+			err, ret := delete.DeleteLabelFromMonitor(ctx, *p.ID, *label)
 			if ret.IsContinue == false {
 				return err, ret
 			}
@@ -205,7 +188,7 @@ func UpdateMonitorByID(monitorId *string, p *newrelic.Monitor, scriptTextEncoded
 		arr := strings.Split(*label, ":")
 		monitorLabel.Category = &arr[0]
 		monitorLabel.Label = &arr[1]
-		err, ret := add.AddLabelToMonitor(*p.ID, monitorLabel)
+		err, ret := add.AddLabelToMonitor(ctx, *p.ID, monitorLabel)
 		if ret.IsContinue == false {
 			return err, ret
 		}
@@ -216,7 +199,7 @@ func UpdateMonitorByID(monitorId *string, p *newrelic.Monitor, scriptTextEncoded
 	return err, ret
 }
 
-func UpdateMonitorByName(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (error, tracker.ReturnValue) {
+func UpdateMonitorByName(ctx context.Context, p *newrelic.Monitor, scriptTextEncoded *newrelic.Script) (error, tracker.ReturnValue) {
 	client, err := utils.GetNewRelicClient("synthetics")
 	if err != nil {
 		fmt.Println(err)
@@ -224,7 +207,7 @@ func UpdateMonitorByName(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script
 		return err, ret
 	}
 
-	curMonitor, err, ret := get.GetMonitorByName(*p.Name)
+	curMonitor, err, ret := get.GetMonitorByName(ctx, *p.Name)
 	if ret.IsContinue == false {
 		return err, ret
 	}
@@ -232,40 +215,27 @@ func UpdateMonitorByName(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script
 	monitorId := curMonitor.ID
 
 	//update monitor itself
-	resp, err := client.SyntheticsMonitors.Update(context.Background(), p, monitorId)
+	resp, err := client.SyntheticsMonitors.Update(ctx, p, monitorId)
 	if err != nil {
 		fmt.Println(err)
+		tracker.AppendRESTCallError(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, err, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 		ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, err, tracker.ERR_REST_CALL, "")
 		return err, ret
-	} else {
-		tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
-		if resp.StatusCode >= 400 {
-			var statusCode = resp.StatusCode
-			fmt.Printf("Response status code: %d. Update monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, *monitorId)
-			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-			return err, ret
-		}
-
 	}
+	tracker.AppendRESTCallResult(client.SyntheticsMonitors, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 	//update script if needed
 	if scriptTextEncoded != nil && scriptTextEncoded.ScriptText != nil {
-		resp, err := client.SyntheticsScript.UpdateByID(context.Background(), scriptTextEncoded, *monitorId)
+		resp, err := client.SyntheticsScript.UpdateByID(ctx, scriptTextEncoded, *monitorId)
 		if err != nil {
 			fmt.Println(err)
+			tracker.AppendRESTCallError(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, err, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 			ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR_SCRIPT, err, tracker.ERR_REST_CALL, "")
 			return err, ret
-		} else {
-			tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
-			if resp.StatusCode >= 400 {
-				var statusCode = resp.StatusCode
-				fmt.Printf("Response status code: %d. Update script to monitor '%s', monitor id: '%s'\n", statusCode, *p.Name, *monitorId)
-				ret := tracker.ToReturnValue(false, tracker.OPERATION_NAME_UPDATE_MONITOR, tracker.ERR_REST_CALL_NOT_2XX, tracker.ERR_REST_CALL_NOT_2XX, "")
-				return err, ret
-			}
 		}
+		tracker.AppendRESTCallResult(client.SyntheticsScript, tracker.OPERATION_NAME_UPDATE_MONITOR, resp.StatusCode, "monitor id: "+(*monitorId)+",monitor name: "+(*p.Name))
 	}
 	//update labels if needed
-	labelList, err, ret := get.GetLabelsByMonitorID(*monitorId)
+	labelList, err, ret := get.GetLabelsByMonitorID(ctx, *monitorId)
 	if ret.IsContinue == false {
 		return err, ret
 	}
@@ -273,7 +243,7 @@ func UpdateMonitorByName(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script
 	if labelListLen > 0 {
 		//delete all labels on this monitor first
 		for _, label := range labelList {
-			err, ret := delete.DeleteLabelFromMonitor(*monitorId, *label)
+			err, ret := delete.DeleteLabelFromMonitor(ctx, *monitorId, *label)
 			if ret.IsContinue == false {
 				return err, ret
 			}
@@ -287,7 +257,7 @@ func UpdateMonitorByName(p *newrelic.Monitor, scriptTextEncoded *newrelic.Script
 		arr := strings.Split(*label, ":")
 		monitorLabel.Category = &arr[0]
 		monitorLabel.Label = &arr[1]
-		err, ret := add.AddLabelToMonitor(*monitorId, monitorLabel)
+		err, ret := add.AddLabelToMonitor(ctx, *monitorId, monitorLabel)
 		if ret.IsContinue == false {
 			return err, ret
 		}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccountsService lists the accounts the API key has access to, through
+// NerdGraph.
+type AccountsService service
+
+type Account struct {
+	ID   *int64  `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+const accountsQueryString = `{ actor { accounts { id name } } }`
+
+type accountsData struct {
+	Actor struct {
+		Accounts []*Account `json:"accounts"`
+	} `json:"actor"`
+}
+
+// List returns the accounts the API key has access to.
+func (s *AccountsService) List(ctx context.Context) ([]*Account, *Response, error) {
+	data := new(accountsData)
+	resp, err := s.client.GraphQL.Query(ctx, accountsQueryString, nil, data)
+	if err != nil {
+		return nil, resp, fmt.Errorf("Failed to list accounts: %w", err)
+	}
+	return data.Actor.Accounts, resp, nil
+}
@@ -206,6 +206,33 @@ func (s *AlertsChannelsService) ListAll(ctx context.Context, opt *AlertsChannelL
 	return alertsChannelList, resp, nil
 }
 
+// ListPages calls fn with every page of the alert channels matching opt.
+func (s *AlertsChannelsService) ListPages(ctx context.Context, opt *AlertsChannelListOptions, fn func(*AlertsChannelList, *Response) error) error {
+	if opt == nil {
+		opt = new(AlertsChannelListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.AlertsChannels), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the alert channels matching opt from all pages.
+func (s *AlertsChannelsService) ListAllPages(ctx context.Context, opt *AlertsChannelListOptions) (*AlertsChannelList, error) {
+	all := new(AlertsChannelList)
+	err := s.ListPages(ctx, opt, func(list *AlertsChannelList, resp *Response) error {
+		all.AlertsChannels = append(all.AlertsChannels, list.AlertsChannels...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 // Create POST a AlertsChannelEntity to create
 //
 // maybe a potential bug in NewRelic side
@@ -33,6 +33,9 @@ const (
 
 type ConditionCategory string
 
+// listAllCategories are the categories listed by ListAll and ListAllPages.
+var listAllCategories = []ConditionCategory{ConditionDefault, ConditionPlugins, ConditionExternalService, ConditionSynthetics, ConditionLocation, ConditionNRQL, ConditionInfrastructure}
+
 type AlertsConditionsService struct {
 	*defaultConditions
 	*pluginsConditions
@@ -65,6 +68,7 @@ type AlertsConditionEntity struct {
 	*AlertsNRQLConditionEntity
 	*AlertsPluginsConditionEntity
 	*AlertsSyntheticsConditionEntity
+	*AlertsLocationConditionEntity
 	*AlertsInfrastructureConditionEntity
 }
 
@@ -74,13 +78,12 @@ func (s *AlertsConditionsService) ListAll(ctx context.Context, opt *AlertsCondit
 	}
 
 	list := new(AlertsConditionList)
-	cats := []ConditionCategory{ConditionDefault, ConditionPlugins, ConditionExternalService, ConditionSynthetics, ConditionNRQL, ConditionInfrastructure}
-	for _, cat := range cats {
+	for _, cat := range listAllCategories {
 		// TODO: paralleize and use ctx.Done() to cancel the parent context
 		listFunc := s.listByCategory(cat)
 		resp, err := listFunc(ctx, list, opt)
-		if err != nil || resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("%v.Response: %v. Error: %v.", cat, resp, err)
+		if err != nil {
+			return nil, fmt.Errorf("%v.Response: %v. Error: %w.", cat, resp, err)
 		}
 	}
 
@@ -98,6 +101,58 @@ func (s *AlertsConditionsService) List(ctx context.Context, opt *AlertsCondition
 	return list, resp, err
 }
 
+// ListPages calls fn with every page of the conditions of category cat in the
+// policy set in opt.
+func (s *AlertsConditionsService) ListPages(ctx context.Context, opt *AlertsConditionsOptions, cat ConditionCategory, fn func(*AlertsConditionList, *Response) error) error {
+	if opt == nil || opt.PolicyIDOptions == "" {
+		return fmt.Errorf("policy_id is required")
+	}
+
+	// Location and infrastructure conditions ignore the page parameter, they
+	// are only walked further when a Link header says so.
+	paged := cat != ConditionLocation && cat != ConditionInfrastructure
+	return listPages(&opt.PageOptions, paged, func() (*Response, int, error) {
+		list, resp, err := s.List(ctx, opt, cat)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, list.len(cat), fn(list, resp)
+	})
+}
+
+// ListCategoriesPages calls fn with every page of the conditions of the given
+// categories in the policy set in opt, the ones of ListAll if no category is
+// given.
+func (s *AlertsConditionsService) ListCategoriesPages(ctx context.Context, opt *AlertsConditionsOptions, fn func(ConditionCategory, *AlertsConditionList, *Response) error, cats ...ConditionCategory) error {
+	if len(cats) == 0 {
+		cats = listAllCategories
+	}
+
+	for _, cat := range cats {
+		err := s.ListPages(ctx, opt, cat, func(list *AlertsConditionList, resp *Response) error {
+			return fn(cat, list, resp)
+		})
+		if err != nil {
+			return fmt.Errorf("%v. Error: %w.", cat, err)
+		}
+	}
+	return nil
+}
+
+// ListAllPages returns the conditions of the given categories in the policy
+// set in opt from all pages, the ones of ListAll if no category is given.
+func (s *AlertsConditionsService) ListAllPages(ctx context.Context, opt *AlertsConditionsOptions, cats ...ConditionCategory) (*AlertsConditionList, error) {
+	all := new(AlertsConditionList)
+	err := s.ListCategoriesPages(ctx, opt, func(cat ConditionCategory, list *AlertsConditionList, resp *Response) error {
+		all.Append(cat, list)
+		return nil
+	}, cats...)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 func (s *AlertsConditionsService) Create(ctx context.Context, cat ConditionCategory, c *AlertsConditionEntity, conditionID int64) (*AlertsConditionEntity, *Response, error) {
 	createFunc := s.createByCategory(cat)
 	condition, resp, err := createFunc(ctx, c, conditionID)
@@ -151,6 +206,8 @@ func (s *AlertsConditionsService) createByCategory(cat ConditionCategory) func(c
 		return s.pluginsConditions.create
 	case ConditionSynthetics:
 		return s.syntheticsConditions.create
+	case ConditionLocation:
+		return s.locationConditions.create
 	case ConditionInfrastructure:
 		return s.infraConditions.create
 	default:
@@ -172,6 +229,8 @@ func (s *AlertsConditionsService) updateByCategory(cat ConditionCategory) func(c
 		return s.pluginsConditions.update
 	case ConditionSynthetics:
 		return s.syntheticsConditions.update
+	case ConditionLocation:
+		return s.locationConditions.update
 	case ConditionInfrastructure:
 		return s.infraConditions.update
 	default:
@@ -193,6 +252,8 @@ func (s *AlertsConditionsService) deleteByCategory(cat ConditionCategory) func(c
 		return s.pluginsConditions.deleteByID
 	case ConditionSynthetics:
 		return s.syntheticsConditions.deleteByID
+	case ConditionLocation:
+		return s.locationConditions.deleteByID
 	case ConditionInfrastructure:
 		return s.infraConditions.deleteByID
 	default:
@@ -202,6 +263,82 @@ func (s *AlertsConditionsService) deleteByCategory(cat ConditionCategory) func(c
 	}
 }
 
+// len returns the number of conditions of category cat in the list.
+func (l *AlertsConditionList) len(cat ConditionCategory) int {
+	switch {
+	case cat == ConditionDefault && l.AlertsDefaultConditionList != nil:
+		return len(l.AlertsDefaultConditions)
+	case cat == ConditionExternalService && l.AlertsExternalServiceConditionList != nil:
+		return len(l.AlertsExternalServiceConditions)
+	case cat == ConditionNRQL && l.AlertsNRQLConditionList != nil:
+		return len(l.AlertsNRQLConditions)
+	case cat == ConditionPlugins && l.AlertsPluginsConditionList != nil:
+		return len(l.AlertsPluginsConditions)
+	case cat == ConditionSynthetics && l.AlertsSyntheticsConditionList != nil:
+		return len(l.AlertsSyntheticsConditions)
+	case cat == ConditionLocation && l.AlertsLocationConditionList != nil:
+		return len(l.AlertsLocationConditions)
+	case cat == ConditionInfrastructure && l.AlertsInfrastructureConditionList != nil:
+		return len(l.AlertsInfrastructureConditions)
+	}
+	return 0
+}
+
+// Append adds the conditions of category cat found in page to the list.
+func (l *AlertsConditionList) Append(cat ConditionCategory, page *AlertsConditionList) {
+	switch cat {
+	case ConditionDefault:
+		if l.AlertsDefaultConditionList == nil {
+			l.AlertsDefaultConditionList = new(AlertsDefaultConditionList)
+		}
+		if page.AlertsDefaultConditionList != nil {
+			l.AlertsDefaultConditions = append(l.AlertsDefaultConditions, page.AlertsDefaultConditions...)
+		}
+	case ConditionExternalService:
+		if l.AlertsExternalServiceConditionList == nil {
+			l.AlertsExternalServiceConditionList = new(AlertsExternalServiceConditionList)
+		}
+		if page.AlertsExternalServiceConditionList != nil {
+			l.AlertsExternalServiceConditions = append(l.AlertsExternalServiceConditions, page.AlertsExternalServiceConditions...)
+		}
+	case ConditionNRQL:
+		if l.AlertsNRQLConditionList == nil {
+			l.AlertsNRQLConditionList = new(AlertsNRQLConditionList)
+		}
+		if page.AlertsNRQLConditionList != nil {
+			l.AlertsNRQLConditions = append(l.AlertsNRQLConditions, page.AlertsNRQLConditions...)
+		}
+	case ConditionPlugins:
+		if l.AlertsPluginsConditionList == nil {
+			l.AlertsPluginsConditionList = new(AlertsPluginsConditionList)
+		}
+		if page.AlertsPluginsConditionList != nil {
+			l.AlertsPluginsConditions = append(l.AlertsPluginsConditions, page.AlertsPluginsConditions...)
+		}
+	case ConditionSynthetics:
+		if l.AlertsSyntheticsConditionList == nil {
+			l.AlertsSyntheticsConditionList = new(AlertsSyntheticsConditionList)
+		}
+		if page.AlertsSyntheticsConditionList != nil {
+			l.AlertsSyntheticsConditions = append(l.AlertsSyntheticsConditions, page.AlertsSyntheticsConditions...)
+		}
+	case ConditionLocation:
+		if l.AlertsLocationConditionList == nil {
+			l.AlertsLocationConditionList = new(AlertsLocationConditionList)
+		}
+		if page.AlertsLocationConditionList != nil {
+			l.AlertsLocationConditions = append(l.AlertsLocationConditions, page.AlertsLocationConditions...)
+		}
+	case ConditionInfrastructure:
+		if l.AlertsInfrastructureConditionList == nil {
+			l.AlertsInfrastructureConditionList = new(AlertsInfrastructureConditionList)
+		}
+		if page.AlertsInfrastructureConditionList != nil {
+			l.AlertsInfrastructureConditions = append(l.AlertsInfrastructureConditions, page.AlertsInfrastructureConditions...)
+		}
+	}
+}
+
 type AlertsConditionTerm struct {
 	Duration     *string `json:"duration,omitempty"`
 	Operator     *string `json:"operator,omitempty"`
@@ -65,3 +65,30 @@ func (s *AlertsEventService) ListAll(ctx context.Context, opt *AlertsEventListOp
 
 	return alertsEventList, resp, nil
 }
+
+// ListPages calls fn with every page of the recent events matching opt.
+func (s *AlertsEventService) ListPages(ctx context.Context, opt *AlertsEventListOptions, fn func(*AlertsEventList, *Response) error) error {
+	if opt == nil {
+		opt = new(AlertsEventListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.RecentEvents), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the recent events matching opt from all pages.
+func (s *AlertsEventService) ListAllPages(ctx context.Context, opt *AlertsEventListOptions) (*AlertsEventList, error) {
+	all := new(AlertsEventList)
+	err := s.ListPages(ctx, opt, func(list *AlertsEventList, resp *Response) error {
+		all.RecentEvents = append(all.RecentEvents, list.RecentEvents...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
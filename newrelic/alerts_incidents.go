@@ -15,22 +15,30 @@
  */
 package newrelic
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type AlertsIncidentService service
 
 type AlertsIncidentList struct {
-	Incidents []*AlertsIncident        `json:"incidents,omitempty"`
+	Incidents []*AlertsIncident        `json:"incidents"`
 	Links     *AlertsIncidentListLinks `json:"links,omitempty"`
 }
 
 type AlertsIncident struct {
 	ID                 *int64               `json:"id,omitempty"`
 	OpenedAt           *int64               `json:"opened_at,omitempty"`
+	ClosedAt           *int64               `json:"closed_at,omitempty"`
 	IncidentPreference *string              `json:"incident_preference,omitempty"`
 	Links              *AlertsIncidentLinks `json:"links,omitempty"`
 }
 
+type AlertsIncidentEntity struct {
+	AlertsIncident *AlertsIncident `json:"incident,omitempty"`
+}
+
 type AlertsIncidentLinks struct {
 	Violations []*int64 `json:"violations,omitempty"`
 	PolicyID   *int64   `json:"policy_id,omitempty"`
@@ -64,3 +72,55 @@ func (s *AlertsIncidentService) ListAll(ctx context.Context, opt *AlertsIncident
 
 	return alertsIncidentList, resp, nil
 }
+
+// ListPages calls fn with every page of the incidents matching opt.
+func (s *AlertsIncidentService) ListPages(ctx context.Context, opt *AlertsIncidentListOptions, fn func(*AlertsIncidentList, *Response) error) error {
+	if opt == nil {
+		opt = new(AlertsIncidentListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Incidents), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the incidents matching opt from all pages.
+func (s *AlertsIncidentService) ListAllPages(ctx context.Context, opt *AlertsIncidentListOptions) (*AlertsIncidentList, error) {
+	all := &AlertsIncidentList{Incidents: []*AlertsIncident{}}
+	err := s.ListPages(ctx, opt, func(list *AlertsIncidentList, resp *Response) error {
+		all.Incidents = append(all.Incidents, list.Incidents...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Acknowledge acknowledges the open incident id.
+func (s *AlertsIncidentService) Acknowledge(ctx context.Context, id int64) (*AlertsIncidentEntity, *Response, error) {
+	return s.update(ctx, fmt.Sprintf("alerts_incidents/%v/acknowledge.json", id))
+}
+
+// Close closes the incident id, and its open violations.
+func (s *AlertsIncidentService) Close(ctx context.Context, id int64) (*AlertsIncidentEntity, *Response, error) {
+	return s.update(ctx, fmt.Sprintf("alerts_incidents/%v/close.json", id))
+}
+
+func (s *AlertsIncidentService) update(ctx context.Context, u string) (*AlertsIncidentEntity, *Response, error) {
+	req, err := s.client.NewRequest("PUT", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	incident := new(AlertsIncidentEntity)
+	resp, err := s.client.Do(ctx, req, incident)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return incident, resp, nil
+}
@@ -56,7 +56,7 @@ func (s *infraConditions) listAll(ctx context.Context, list *AlertsConditionList
 	if err != nil {
 		return nil, err
 	}
-	u = infrastructureURL + u
+	u = s.client.Endpoints.Infrastructure + u
 
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
@@ -72,7 +72,7 @@ func (s *infraConditions) listAll(ctx context.Context, list *AlertsConditionList
 }
 
 func (s *infraConditions) create(ctx context.Context, c *AlertsConditionEntity, policyID int64) (*AlertsConditionEntity, *Response, error) {
-	u := infrastructureURL + "conditions"
+	u := s.client.Endpoints.Infrastructure + "conditions"
 	if c.AlertsInfrastructureConditionEntity.AlertsInfrastructureCondition.ID != nil {
 		c.AlertsInfrastructureConditionEntity.AlertsInfrastructureCondition.ID = nil
 	}
@@ -93,7 +93,7 @@ func (s *infraConditions) create(ctx context.Context, c *AlertsConditionEntity,
 }
 
 func (s *infraConditions) update(ctx context.Context, c *AlertsConditionEntity, id int64) (*AlertsConditionEntity, *Response, error) {
-	u := fmt.Sprintf("%vconditions/%v", infrastructureURL, id)
+	u := fmt.Sprintf("%vconditions/%v", s.client.Endpoints.Infrastructure, id)
 	req, err := s.client.NewRequest("PUT", u, c.AlertsInfrastructureConditionEntity)
 	if err != nil {
 		return nil, nil, err
@@ -110,7 +110,7 @@ func (s *infraConditions) update(ctx context.Context, c *AlertsConditionEntity,
 }
 
 func (s *infraConditions) deleteByID(ctx context.Context, id int64) (*Response, error) {
-	u := fmt.Sprintf("%vconditions/%v", infrastructureURL, id)
+	u := fmt.Sprintf("%vconditions/%v", s.client.Endpoints.Infrastructure, id)
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
 		return nil, err
@@ -17,6 +17,7 @@ package newrelic
 
 import (
 	"context"
+	"fmt"
 )
 
 type locationConditions service
@@ -40,6 +41,10 @@ type AlertsLocationCondition struct {
 	TimeLimit  *int64          `json:"violation_time_limit_seconds,omitempty"`
 }
 
+type AlertsLocationConditionEntity struct {
+	AlertsLocationCondition *AlertsLocationCondition `json:"location_failure_condition,omitempty"`
+}
+
 func (s *locationConditions) listAll(ctx context.Context, list *AlertsConditionList, opt *AlertsConditionsOptions) (*Response, error) {
 	u, err := addOptions("alerts_location_failure_conditions/policies/"+opt.PolicyIDOptions+".json", opt)
 	if err != nil {
@@ -57,3 +62,50 @@ func (s *locationConditions) listAll(ctx context.Context, list *AlertsConditionL
 	}
 	return resp, nil
 }
+
+func (s *locationConditions) deleteByID(ctx context.Context, id int64) (*Response, error) {
+	u := fmt.Sprintf("alerts_location_failure_conditions/%v.json", id)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+func (s *locationConditions) create(ctx context.Context, c *AlertsConditionEntity, policyID int64) (*AlertsConditionEntity, *Response, error) {
+	u := fmt.Sprintf("alerts_location_failure_conditions/policies/%v.json", policyID)
+	if c.AlertsLocationConditionEntity.AlertsLocationCondition.ID != nil {
+		c.AlertsLocationConditionEntity.AlertsLocationCondition.ID = nil
+	}
+	req, err := s.client.NewRequest("POST", u, c.AlertsLocationConditionEntity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	condition := new(AlertsConditionEntity)
+	condition.AlertsLocationConditionEntity = new(AlertsLocationConditionEntity)
+	resp, err := s.client.Do(ctx, req, condition.AlertsLocationConditionEntity)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return condition, resp, nil
+}
+
+func (s *locationConditions) update(ctx context.Context, c *AlertsConditionEntity, id int64) (*AlertsConditionEntity, *Response, error) {
+	u := fmt.Sprintf("alerts_location_failure_conditions/%v.json", id)
+	req, err := s.client.NewRequest("PUT", u, c.AlertsLocationConditionEntity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	condition := new(AlertsConditionEntity)
+	condition.AlertsLocationConditionEntity = new(AlertsLocationConditionEntity)
+	resp, err := s.client.Do(ctx, req, condition.AlertsLocationConditionEntity)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return condition, resp, nil
+}
@@ -72,6 +72,33 @@ func (s *AlertsPoliciesService) ListAll(ctx context.Context, opt *AlertsPolicyLi
 	return alertsPolicyList, resp, nil
 }
 
+// ListPages calls fn with every page of the alert policies matching opt.
+func (s *AlertsPoliciesService) ListPages(ctx context.Context, opt *AlertsPolicyListOptions, fn func(*AlertsPolicyList, *Response) error) error {
+	if opt == nil {
+		opt = new(AlertsPolicyListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.AlertsPolicies), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the alert policies matching opt from all pages.
+func (s *AlertsPoliciesService) ListAllPages(ctx context.Context, opt *AlertsPolicyListOptions) (*AlertsPolicyList, error) {
+	all := new(AlertsPolicyList)
+	err := s.ListPages(ctx, opt, func(list *AlertsPolicyList, resp *Response) error {
+		all.AlertsPolicies = append(all.AlertsPolicies, list.AlertsPolicies...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 // Create tries to create an alerts policy
 // CAVEAT: it's good practice to check if a `p` existed
 // If a `p` is "Created" twice, two alerts policies will be created with same Name but different ID
@@ -22,7 +22,7 @@ import (
 type AlertsViolationService service
 
 type AlertsViolationList struct {
-	Violations []*AlertsViolation        `json:"violations,omitempty"`
+	Violations []*AlertsViolation        `json:"violations"`
 	Links      *AlertsViolationListLinks `json:"links,omitempty"`
 }
 
@@ -83,3 +83,30 @@ func (s *AlertsViolationService) ListAll(ctx context.Context, opt *AlertsViolati
 
 	return alertsViolationList, resp, nil
 }
+
+// ListPages calls fn with every page of the violations matching opt.
+func (s *AlertsViolationService) ListPages(ctx context.Context, opt *AlertsViolationListOptions, fn func(*AlertsViolationList, *Response) error) error {
+	if opt == nil {
+		opt = new(AlertsViolationListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Violations), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the violations matching opt from all pages.
+func (s *AlertsViolationService) ListAllPages(ctx context.Context, opt *AlertsViolationListOptions) (*AlertsViolationList, error) {
+	all := &AlertsViolationList{Violations: []*AlertsViolation{}}
+	err := s.ListPages(ctx, opt, func(list *AlertsViolationList, resp *Response) error {
+		all.Violations = append(all.Violations, list.Violations...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
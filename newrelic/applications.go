@@ -0,0 +1,318 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+)
+
+// ApplicationsService handles communication with the APM application
+// related methods of the NewRelic API.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apis/rest-api-v2/application-examples-v2/list-your-app-id-metric-timeslice-data-v2
+type ApplicationsService service
+
+// Application represents a NewRelic APM application.
+type Application struct {
+	ID             *int64               `json:"id,omitempty"`
+	Name           *string              `json:"name,omitempty"`
+	Language       *string              `json:"language,omitempty"`
+	HealthStatus   *string              `json:"health_status,omitempty"`
+	Reporting      *bool                `json:"reporting,omitempty"`
+	LastReportedAt *string              `json:"last_reported_at,omitempty"`
+	Summary        *ApplicationSummary  `json:"application_summary,omitempty"`
+	EndUserSummary *EndUserSummary      `json:"end_user_summary,omitempty"`
+	Settings       *ApplicationSettings `json:"settings,omitempty"`
+	Links          *ApplicationLinks    `json:"links,omitempty"`
+}
+
+// ApplicationSummary holds the recent APM metrics of an application, a host
+// or an instance.
+type ApplicationSummary struct {
+	ResponseTime            *float64 `json:"response_time,omitempty"`
+	Throughput              *float64 `json:"throughput,omitempty"`
+	ErrorRate               *float64 `json:"error_rate,omitempty"`
+	ApdexTarget             *float64 `json:"apdex_target,omitempty"`
+	ApdexScore              *float64 `json:"apdex_score,omitempty"`
+	HostCount               *int64   `json:"host_count,omitempty"`
+	InstanceCount           *int64   `json:"instance_count,omitempty"`
+	ConcurrentInstanceCount *int64   `json:"concurrent_instance_count,omitempty"`
+}
+
+// EndUserSummary holds the recent browser metrics of an application.
+type EndUserSummary struct {
+	ResponseTime *float64 `json:"response_time,omitempty"`
+	Throughput   *float64 `json:"throughput,omitempty"`
+	ApdexTarget  *float64 `json:"apdex_target,omitempty"`
+	ApdexScore   *float64 `json:"apdex_score,omitempty"`
+}
+
+type ApplicationSettings struct {
+	AppApdexThreshold        *float64 `json:"app_apdex_threshold,omitempty"`
+	EndUserApdexThreshold    *float64 `json:"end_user_apdex_threshold,omitempty"`
+	EnableRealUserMonitoring *bool    `json:"enable_real_user_monitoring,omitempty"`
+	UseServerSideConfig      *bool    `json:"use_server_side_config,omitempty"`
+}
+
+type ApplicationLinks struct {
+	Servers              []*int64 `json:"servers,omitempty"`
+	ApplicationHosts     []*int64 `json:"application_hosts,omitempty"`
+	ApplicationInstances []*int64 `json:"application_instances,omitempty"`
+	AlertPolicy          *int64   `json:"alert_policy,omitempty"`
+}
+
+// ApplicationEntity corresponds to a JSON payload returned by NewRelic API
+type ApplicationEntity struct {
+	Application *Application `json:"application,omitempty"`
+}
+
+// ApplicationList represents a collection of NewRelic APM applications.
+type ApplicationList struct {
+	Applications []*Application `json:"applications"`
+}
+
+// ApplicationListOptions specifies optional parameters to the
+// ApplicationsService.ListAll method. NameOptions matches the applications
+// whose name contains it.
+type ApplicationListOptions struct {
+	NameOptions     string `url:"filter[name],omitempty"`
+	HostOptions     string `url:"filter[host],omitempty"`
+	IDOptions       string `url:"filter[ids],omitempty"`
+	LanguageOptions string `url:"filter[language],omitempty"`
+
+	PageOptions
+}
+
+// ApplicationHost represents a host an APM application runs on.
+type ApplicationHost struct {
+	ID              *int64                `json:"id,omitempty"`
+	ApplicationName *string               `json:"application_name,omitempty"`
+	Host            *string               `json:"host,omitempty"`
+	Language        *string               `json:"language,omitempty"`
+	HealthStatus    *string               `json:"health_status,omitempty"`
+	Summary         *ApplicationSummary   `json:"application_summary,omitempty"`
+	Links           *ApplicationHostLinks `json:"links,omitempty"`
+}
+
+type ApplicationHostLinks struct {
+	Application          *int64   `json:"application,omitempty"`
+	ApplicationInstances []*int64 `json:"application_instances,omitempty"`
+	Server               *int64   `json:"server,omitempty"`
+}
+
+// ApplicationHostList represents a collection of the hosts of an APM
+// application.
+type ApplicationHostList struct {
+	ApplicationHosts []*ApplicationHost `json:"application_hosts,omitempty"`
+}
+
+// ApplicationInstance represents an instance, one agent, of an APM
+// application.
+type ApplicationInstance struct {
+	ID              *int64                    `json:"id,omitempty"`
+	ApplicationName *string                   `json:"application_name,omitempty"`
+	Host            *string                   `json:"host,omitempty"`
+	Port            *int64                    `json:"port,omitempty"`
+	Language        *string                   `json:"language,omitempty"`
+	HealthStatus    *string                   `json:"health_status,omitempty"`
+	Summary         *ApplicationSummary       `json:"application_summary,omitempty"`
+	Links           *ApplicationInstanceLinks `json:"links,omitempty"`
+}
+
+type ApplicationInstanceLinks struct {
+	Application     *int64 `json:"application,omitempty"`
+	ApplicationHost *int64 `json:"application_host,omitempty"`
+	Server          *int64 `json:"server,omitempty"`
+}
+
+// ApplicationInstanceList represents a collection of the instances of an
+// APM application.
+type ApplicationInstanceList struct {
+	ApplicationInstances []*ApplicationInstance `json:"application_instances,omitempty"`
+}
+
+// ApplicationHostListOptions specifies optional parameters to the
+// ApplicationsService.ListHosts and ListInstances methods.
+type ApplicationHostListOptions struct {
+	HostnameOptions string `url:"filter[hostname],omitempty"`
+	IDOptions       string `url:"filter[ids],omitempty"`
+
+	PageOptions
+}
+
+// ListAll returns the APM applications of the account matching opt.
+func (s *ApplicationsService) ListAll(ctx context.Context, opt *ApplicationListOptions) (*ApplicationList, *Response, error) {
+	u, err := addOptions("applications.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicationList := new(ApplicationList)
+	resp, err := s.client.Do(ctx, req, applicationList)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return applicationList, resp, nil
+}
+
+// ListPages calls fn with every page of the applications matching opt.
+func (s *ApplicationsService) ListPages(ctx context.Context, opt *ApplicationListOptions, fn func(*ApplicationList, *Response) error) error {
+	if opt == nil {
+		opt = new(ApplicationListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Applications), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the applications matching opt from all pages.
+func (s *ApplicationsService) ListAllPages(ctx context.Context, opt *ApplicationListOptions) (*ApplicationList, error) {
+	all := &ApplicationList{Applications: []*Application{}}
+	err := s.ListPages(ctx, opt, func(list *ApplicationList, resp *Response) error {
+		all.Applications = append(all.Applications, list.Applications...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// GetByID returns the APM application of the given `id`
+func (s *ApplicationsService) GetByID(ctx context.Context, id int64) (*ApplicationEntity, *Response, error) {
+	u := fmt.Sprintf("applications/%v.json", id)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicationEntity := new(ApplicationEntity)
+	resp, err := s.client.Do(ctx, req, applicationEntity)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return applicationEntity, resp, nil
+}
+
+// GetByName returns the application named name, nil if there is none.
+func (s *ApplicationsService) GetByName(ctx context.Context, name string) (*Application, error) {
+	// filter[name] matches the names containing it.
+	list, err := s.ListAllPages(ctx, &ApplicationListOptions{NameOptions: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, application := range list.Applications {
+		if application.Name != nil && *application.Name == name {
+			return application, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListHosts returns the hosts of the application applicationID matching
+// opt.
+func (s *ApplicationsService) ListHosts(ctx context.Context, applicationID int64, opt *ApplicationHostListOptions) (*ApplicationHostList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("applications/%v/hosts.json", applicationID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostList := new(ApplicationHostList)
+	resp, err := s.client.Do(ctx, req, hostList)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hostList, resp, nil
+}
+
+// ListAllHostsPages returns the hosts of the application applicationID
+// matching opt from all pages.
+func (s *ApplicationsService) ListAllHostsPages(ctx context.Context, applicationID int64, opt *ApplicationHostListOptions) (*ApplicationHostList, error) {
+	if opt == nil {
+		opt = new(ApplicationHostListOptions)
+	}
+	all := new(ApplicationHostList)
+	err := listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListHosts(ctx, applicationID, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		all.ApplicationHosts = append(all.ApplicationHosts, list.ApplicationHosts...)
+		return resp, len(list.ApplicationHosts), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ListInstances returns the instances of the application applicationID
+// matching opt.
+func (s *ApplicationsService) ListInstances(ctx context.Context, applicationID int64, opt *ApplicationHostListOptions) (*ApplicationInstanceList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("applications/%v/instances.json", applicationID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instanceList := new(ApplicationInstanceList)
+	resp, err := s.client.Do(ctx, req, instanceList)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return instanceList, resp, nil
+}
+
+// ListAllInstancesPages returns the instances of the application
+// applicationID matching opt from all pages.
+func (s *ApplicationsService) ListAllInstancesPages(ctx context.Context, applicationID int64, opt *ApplicationHostListOptions) (*ApplicationInstanceList, error) {
+	if opt == nil {
+		opt = new(ApplicationHostListOptions)
+	}
+	all := new(ApplicationInstanceList)
+	err := listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListInstances(ctx, applicationID, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		all.ApplicationInstances = append(all.ApplicationInstances, list.ApplicationInstances...)
+		return resp, len(list.ApplicationInstances), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
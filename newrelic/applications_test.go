@@ -0,0 +1,157 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// applicationsPage is the first page of GET /v2/applications.json as shown in
+// the REST API v2 documentation.
+const applicationsPage = `{
+  "applications": [
+    {
+      "id": 12345,
+      "name": "My Application",
+      "language": "java",
+      "health_status": "green",
+      "reporting": true,
+      "last_reported_at": "2018-06-12T10:00:00+00:00",
+      "application_summary": {
+        "response_time": 12.5,
+        "throughput": 300,
+        "error_rate": 0.1,
+        "apdex_target": 0.5,
+        "apdex_score": 0.98,
+        "host_count": 2,
+        "instance_count": 2
+      },
+      "settings": {
+        "app_apdex_threshold": 0.5,
+        "end_user_apdex_threshold": 7,
+        "enable_real_user_monitoring": true,
+        "use_server_side_config": false
+      },
+      "links": {
+        "servers": [],
+        "application_hosts": [67890],
+        "application_instances": [98765],
+        "alert_policy": 1234
+      }
+    }
+  ],
+  "links": {
+    "application.servers": "/v2/servers?ids={server_ids}",
+    "application.alert_policy": "/v2/alert_policies/{alert_policy_id}"
+  }
+}`
+
+func TestApplicationsListAllPages(t *testing.T) {
+	var queries []string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications.json" {
+			t.Errorf("got path %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/applications.json?page=2>; rel="next", <http://%s/applications.json?page=2>; rel="last"`, r.Host, r.Host))
+			fmt.Fprint(w, applicationsPage)
+			return
+		}
+		fmt.Fprint(w, `{"applications":[{"id":12346,"name":"My Application (Staging)","language":"java","health_status":"gray","reporting":false}]}`)
+	})
+
+	list, err := client.Applications.ListAllPages(context.Background(), &ApplicationListOptions{NameOptions: "My Application", LanguageOptions: "java"})
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if len(queries) != 2 || queries[0] != "filter%5Blanguage%5D=java&filter%5Bname%5D=My+Application&page=1" {
+		t.Errorf("got queries %q", queries)
+	}
+	if len(list.Applications) != 2 {
+		t.Fatalf("got %d applications, want the ones of page 1 and 2", len(list.Applications))
+	}
+	app := list.Applications[0]
+	if *app.HealthStatus != "green" || !*app.Reporting || *app.Summary.ApdexScore != 0.98 || *app.Summary.HostCount != 2 {
+		t.Errorf("got %+v, summary %+v", app, app.Summary)
+	}
+	if *app.Settings.EndUserApdexThreshold != 7 || *app.Links.AlertPolicy != 1234 || *app.Links.ApplicationHosts[0] != 67890 {
+		t.Errorf("got settings %+v, links %+v", app.Settings, app.Links)
+	}
+}
+
+func TestApplicationsGetByName(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `{"applications":[]}`)
+			return
+		}
+		// filter[name] matches the names containing it.
+		fmt.Fprint(w, `{"applications":[{"id":12346,"name":"My Application (Staging)"},{"id":12345,"name":"My Application"}]}`)
+	})
+
+	app, err := client.Applications.GetByName(context.Background(), "My Application")
+	if err != nil || app == nil || *app.ID != 12345 {
+		t.Errorf("GetByName returned %+v, %v, want the exact name", app, err)
+	}
+	if app, err := client.Applications.GetByName(context.Background(), "My"); err != nil || app != nil {
+		t.Errorf("GetByName of a partial name returned %+v, %v", app, err)
+	}
+}
+
+func TestApplicationHostsAndInstances(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			// Like the real API, the page past the last one is empty.
+			fmt.Fprint(w, `{"application_hosts":[],"application_instances":[]}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/applications/12345/hosts.json":
+			fmt.Fprint(w, `{"application_hosts":[{"id":67890,"application_name":"My Application","host":"web-1","language":"java","health_status":"green",
+				"application_summary":{"response_time":10.1,"throughput":150,"error_rate":0,"apdex_score":1,"instance_count":1},
+				"links":{"application":12345,"application_instances":[98765],"server":555}}]}`)
+		case "/applications/12345/instances.json":
+			fmt.Fprint(w, `{"application_instances":[{"id":98765,"application_name":"My Application","host":"web-1","port":8080,"language":"java","health_status":"green",
+				"links":{"application":12345,"application_host":67890,"server":555}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"title":"Application not found"}}`)
+		}
+	})
+	ctx := context.Background()
+
+	hosts, err := client.Applications.ListAllHostsPages(ctx, 12345, nil)
+	if err != nil || len(hosts.ApplicationHosts) != 1 {
+		t.Fatalf("ListAllHostsPages returned %+v, %v", hosts, err)
+	}
+	if host := hosts.ApplicationHosts[0]; *host.Host != "web-1" || *host.Summary.Throughput != 150 || *host.Links.ApplicationInstances[0] != 98765 {
+		t.Errorf("got host %+v", host)
+	}
+	instances, err := client.Applications.ListAllInstancesPages(ctx, 12345, &ApplicationHostListOptions{HostnameOptions: "web-1"})
+	if err != nil || len(instances.ApplicationInstances) != 1 {
+		t.Fatalf("ListAllInstancesPages returned %+v, %v", instances, err)
+	}
+	if instance := instances.ApplicationInstances[0]; *instance.Port != 8080 || *instance.Links.ApplicationHost != 67890 {
+		t.Errorf("got instance %+v", instance)
+	}
+	if _, _, err := client.Applications.ListHosts(ctx, 1, nil); !IsNotFound(err) {
+		t.Errorf("hosts of a missing application returned %v, want a not found error", err)
+	}
+}
@@ -20,38 +20,35 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	defaultBaseURL     = "https://api.newrelic.com/v2/"
-	syntheticsURL      = "https://synthetics.newrelic.com/synthetics/api/v3/monitors/"
-	userAgent          = "go-newrelic"
-	labelSyntheticsURL = "https://synthetics.newrelic.com/synthetics/api/v4/monitors/"
-	insightsURL        = "https://insights-collector.newrelic.com/v1/accounts/"
-	infrastructureURL  = "https://infra-api.newrelic.com/v2/alerts/"
-	graphqlURL         = "https://api.newrelic.com/graphql"
+	userAgent = "go-newrelic"
 )
 
 type Client struct {
 	client *http.Client
 
-	BaseURL   *url.URL
+	BaseURL *url.URL
+	// Endpoints are the URLs of all NewRelic APIs, services calling an API
+	// other than BaseURL (like infrastructure alerts) read them from here.
+	Endpoints Endpoints
 	UserAgent string
 	XApiKey   string
 	ProxyAuth string
-	Retries   int
+	// Retries is the number of attempts made for every call, see RetryPolicy
+	// for which failures are retried and how long to wait between them.
+	Retries     int
+	RetryPolicy RetryPolicy
 
 	common service
 
@@ -68,6 +65,13 @@ type Client struct {
 	LabelsSynthetics   *LabelsSyntheticsService
 	Dashboards         *DashboardService
 	CustomEvents       *CustomEventService
+	Accounts           *AccountsService
+	Applications       *ApplicationsService
+	Deployments        *DeploymentsService
+	Metrics            *MetricsService
+	NRQL               *NRQLService
+	GraphQL            *GraphQLClient
+	Entities           *EntitiesService
 }
 
 type service struct {
@@ -94,30 +98,24 @@ type PageOptions struct {
 	Page int `url:"page,omitempty"`
 }
 
+// NewClient returns a client of endpointType for the US region.
 func NewClient(httpClient *http.Client, endpointType string) *Client {
+	endpoints, _ := EndpointsForRegion(RegionUS)
+	return NewClientWithEndpoints(httpClient, endpointType, endpoints)
+}
+
+// NewClientWithEndpoints returns a client of endpointType whose services call
+// the given endpoints, e.g. the ones of another region or a local stand-in.
+func NewClientWithEndpoints(httpClient *http.Client, endpointType string, endpoints Endpoints) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	var baseURL *url.URL
-
-	switch endpointType {
-	case "synthetics":
-		baseURL, _ = url.Parse(syntheticsURL)
-	case "labelSynthetics":
-		baseURL, _ = url.Parse(labelSyntheticsURL)
-	case "insights":
-		baseURL, _ = url.Parse(insightsURL)
-	case "infrastructure":
-		baseURL, _ = url.Parse(infrastructureURL)
-	case "graphql":
-		baseURL, _ = url.Parse(graphqlURL)
-	default:
-		baseURL, _ = url.Parse(defaultBaseURL)
-	}
+	baseURL, _ := url.Parse(endpoints.baseURL(endpointType))
 
 	c := &Client{
 		client:    httpClient,
 		BaseURL:   baseURL,
+		Endpoints: endpoints,
 		UserAgent: userAgent,
 	}
 	c.common.client = c
@@ -145,8 +143,16 @@ func NewClient(httpClient *http.Client, endpointType string) *Client {
 	c.Dashboards = (*DashboardService)(&c.common)
 
 	c.CustomEvents = (*CustomEventService)(&c.common)
+	c.Accounts = (*AccountsService)(&c.common)
+	c.Applications = (*ApplicationsService)(&c.common)
+	c.Deployments = (*DeploymentsService)(&c.common)
+	c.Metrics = (*MetricsService)(&c.common)
+	c.NRQL = (*NRQLService)(&c.common)
+	c.GraphQL = (*GraphQLClient)(&c.common)
+	c.Entities = (*EntitiesService)(&c.common)
 
 	c.Retries = 3
+	c.RetryPolicy = DefaultRetryPolicy
 
 	return c
 }
@@ -160,18 +166,21 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		return nil, err
 	}
 
-	var buf io.ReadWriter
+	// The payload is kept as a byte slice so that http.NewRequest sets
+	// GetBody, and every retry of the request sends it again in full.
+	var payload io.Reader
 	if body != nil {
-		buf = new(bytes.Buffer)
+		buf := new(bytes.Buffer)
 		enc := json.NewEncoder(buf)
 		enc.SetEscapeHTML(false)
 		err := enc.Encode(body)
 		if err != nil {
 			return nil, err
 		}
+		payload = bytes.NewReader(buf.Bytes())
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequest(method, u.String(), payload)
 	if err != nil {
 		return nil, err
 	}
@@ -234,27 +243,12 @@ func truncateString(str string, num int) string {
 }
 
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	var retries = c.Retries
-
-	var resp *http.Response
-	var err error
-	for retries > 0 {
-		resp, err = c.client.Do(req)
-		if err != nil || resp.StatusCode > 299 {
-			if retries == 1 {
-				if err == nil {
-					bodyBytes, _ := ioutil.ReadAll(resp.Body)
-					bodyString := truncateString(string(bodyBytes), 100)
-					err = errors.New(fmt.Sprintf("%s %s returns status:%d body: %s", req.Method, req.URL.String(), resp.StatusCode, bodyString))
-				}
-				log.Println(err)
-				break
-			}
-			time.Sleep(time.Duration(3) * time.Second)
-			retries--
-		} else {
-			break
-		}
+	resp, err := c.doWithRetry(ctx, req)
+	if err == nil && resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		apiErr := newAPIError(req, resp, bodyBytes)
+		return newResponse(resp), apiErr
 	}
 	if err != nil {
 		// If we got an error, and the context has been canceled,
@@ -269,7 +263,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	}
 	defer resp.Body.Close()
 
-	response := &Response{Response: resp}
+	response := newResponse(resp)
 
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
@@ -289,20 +283,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 }
 
 func (c *Client) DoWithBytes(ctx context.Context, req *http.Request) (*Response, []byte, error) {
-	var retries = c.Retries
-
-	var resp *http.Response
-	var err error
-	for retries > 0 {
-		resp, err = c.client.Do(req)
-		if err != nil {
-			log.Println(err)
-			time.Sleep(time.Duration(3) * time.Second)
-			retries--
-		} else {
-			break
-		}
-	}
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -316,10 +297,13 @@ func (c *Client) DoWithBytes(ctx context.Context, req *http.Request) (*Response,
 	}
 	defer resp.Body.Close()
 
-	response := &Response{Response: resp}
+	response := newResponse(resp)
 
 	var retBytes []byte
 	retBytes, err = ioutil.ReadAll(resp.Body)
+	if err == nil && resp.StatusCode > 299 {
+		return response, retBytes, newAPIError(req, resp, retBytes)
+	}
 
 	return response, retBytes, err
 }
@@ -17,6 +17,7 @@ package newrelic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -66,6 +67,27 @@ func (s *DashboardService) ListAll(ctx context.Context, opt *DashboardListOption
 	return resp, bytes, nil
 }
 
+// ListPages calls fn with the raw JSON of every page of the dashboards.
+func (s *DashboardService) ListPages(ctx context.Context, opt *DashboardListOptions, fn func(*Response, []byte) error) error {
+	if opt == nil {
+		opt = new(DashboardListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		resp, bytes, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		// Dashboards are kept as raw JSON, only count them here.
+		page := struct {
+			Dashboards []json.RawMessage `json:"dashboards"`
+		}{}
+		if err := json.Unmarshal(bytes, &page); err != nil {
+			return resp, 0, err
+		}
+		return resp, len(page.Dashboards), fn(resp, bytes)
+	})
+}
+
 func (s *DashboardService) GetByID(ctx context.Context, id int64) (*Response, []byte, error) {
 	u := fmt.Sprintf("dashboards/%v.json", id)
 	req, err := s.client.NewRequest("GET", u, nil)
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeploymentsService handles communication with the deployment marker
+// related methods of the NewRelic API.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apm/new-relic-apm/maintenance/record-monitor-deployments
+type DeploymentsService service
+
+// Deployment represents a deployment marker of an APM application.
+type Deployment struct {
+	ID          *int64           `json:"id,omitempty"`
+	Revision    *string          `json:"revision,omitempty"`
+	Changelog   *string          `json:"changelog,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	User        *string          `json:"user,omitempty"`
+	Timestamp   *string          `json:"timestamp,omitempty"`
+	Links       *DeploymentLinks `json:"links,omitempty"`
+}
+
+type DeploymentLinks struct {
+	Application *int64 `json:"application,omitempty"`
+}
+
+// DeploymentEntity corresponds to a JSON payload returned by NewRelic API
+type DeploymentEntity struct {
+	Deployment *Deployment `json:"deployment,omitempty"`
+}
+
+// DeploymentList represents a collection of deployment markers.
+type DeploymentList struct {
+	Deployments []*Deployment `json:"deployments,omitempty"`
+}
+
+// DeploymentListOptions specifies optional parameters to the
+// DeploymentsService.ListAll method.
+type DeploymentListOptions struct {
+	PageOptions
+}
+
+// ListAll returns the deployments of the application applicationID, the
+// latest first.
+func (s *DeploymentsService) ListAll(ctx context.Context, applicationID int64, opt *DeploymentListOptions) (*DeploymentList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("applications/%v/deployments.json", applicationID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deploymentList := new(DeploymentList)
+	resp, err := s.client.Do(ctx, req, deploymentList)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deploymentList, resp, nil
+}
+
+// ListPages calls fn with every page of the deployments of the application
+// applicationID.
+func (s *DeploymentsService) ListPages(ctx context.Context, applicationID int64, opt *DeploymentListOptions, fn func(*DeploymentList, *Response) error) error {
+	if opt == nil {
+		opt = new(DeploymentListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, applicationID, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Deployments), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the deployments of the application applicationID from
+// all pages.
+func (s *DeploymentsService) ListAllPages(ctx context.Context, applicationID int64, opt *DeploymentListOptions) (*DeploymentList, error) {
+	all := new(DeploymentList)
+	err := s.ListPages(ctx, applicationID, opt, func(list *DeploymentList, resp *Response) error {
+		all.Deployments = append(all.Deployments, list.Deployments...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Create records a deployment of the application applicationID.
+func (s *DeploymentsService) Create(ctx context.Context, applicationID int64, d *DeploymentEntity) (*DeploymentEntity, *Response, error) {
+	u := fmt.Sprintf("applications/%v/deployments.json", applicationID)
+	req, err := s.client.NewRequest("POST", u, d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deploymentEntity := new(DeploymentEntity)
+	resp, err := s.client.Do(ctx, req, deploymentEntity)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deploymentEntity, resp, nil
+}
+
+// DeleteByID deletes the deployment id of the application applicationID.
+func (s *DeploymentsService) DeleteByID(ctx context.Context, applicationID int64, id int64) (*Response, error) {
+	u := fmt.Sprintf("applications/%v/deployments/%v.json", applicationID, id)
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDeploymentsCreate(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/applications/12345/deployments.json" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["deployment"]["revision"] == "" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"error":{"title":"revision is required"}}`)
+			return
+		}
+		if body["deployment"]["changelog"] != "Fixed the login" {
+			t.Errorf("got body %v", body)
+		}
+		// Answer of POST /v2/applications/{id}/deployments.json in the
+		// deployment marker documentation.
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+  "deployment": {
+    "id": 38911571,
+    "revision": "1.2.3",
+    "changelog": "Fixed the login",
+    "description": "Release 1.2.3",
+    "user": "jdoe@example.com",
+    "timestamp": "2018-06-12T10:00:00+00:00",
+    "links": {"application": 12345}
+  },
+  "links": {"deployment.agent": "/v2/applications/{application_id}"}
+}`)
+	})
+	ctx := context.Background()
+
+	revision, changelog := "1.2.3", "Fixed the login"
+	created, resp, err := client.Deployments.Create(ctx, 12345, &DeploymentEntity{Deployment: &Deployment{Revision: &revision, Changelog: &changelog}})
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Create returned %v, %v", resp, err)
+	}
+	if d := created.Deployment; *d.ID != 38911571 || *d.User != "jdoe@example.com" || *d.Links.Application != 12345 {
+		t.Errorf("got %+v", d)
+	}
+	if _, _, err := client.Deployments.Create(ctx, 12345, &DeploymentEntity{Deployment: &Deployment{}}); !IsValidation(err) {
+		t.Errorf("a deployment without revision returned %v, want a validation error", err)
+	}
+}
+
+func TestDeploymentsListAndDelete(t *testing.T) {
+	var deleted string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE":
+			deleted = r.URL.Path
+			fmt.Fprint(w, `{"deployment":{"id":38911571,"revision":"1.2.3"}}`)
+		case r.URL.Query().Get("page") == "1":
+			fmt.Fprint(w, `{"deployments":[
+				{"id":38911571,"revision":"1.2.3","timestamp":"2018-06-12T10:00:00+00:00","links":{"application":12345}},
+				{"id":38911570,"revision":"1.2.2","timestamp":"2018-06-11T10:00:00+00:00","links":{"application":12345}}]}`)
+		default:
+			fmt.Fprint(w, `{"deployments":[]}`)
+		}
+	})
+	ctx := context.Background()
+
+	list, err := client.Deployments.ListAllPages(ctx, 12345, nil)
+	if err != nil || len(list.Deployments) != 2 || *list.Deployments[0].Revision != "1.2.3" {
+		t.Fatalf("ListAllPages returned %+v, %v, want 1.2.3 then 1.2.2", list, err)
+	}
+	if _, err := client.Deployments.DeleteByID(ctx, 12345, 38911570); err != nil || deleted != "/applications/12345/deployments/38911570.json" {
+		t.Errorf("DeleteByID returned %v, deleted %q", err, deleted)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RegionUS = "US"
+	RegionEU = "EU"
+)
+
+// Endpoints holds the base URL of every NewRelic API the client talks to.
+type Endpoints struct {
+	API             string
+	Synthetics      string
+	LabelSynthetics string
+	Insights        string
+	Infrastructure  string
+	GraphQL         string
+}
+
+var regionEndpoints = map[string]Endpoints{
+	RegionUS: {
+		API:             "https://api.newrelic.com/v2/",
+		Synthetics:      "https://synthetics.newrelic.com/synthetics/api/v3/monitors/",
+		LabelSynthetics: "https://synthetics.newrelic.com/synthetics/api/v4/monitors/",
+		Insights:        "https://insights-collector.newrelic.com/v1/accounts/",
+		Infrastructure:  "https://infra-api.newrelic.com/v2/alerts/",
+		GraphQL:         "https://api.newrelic.com/graphql",
+	},
+	RegionEU: {
+		API:             "https://api.eu.newrelic.com/v2/",
+		Synthetics:      "https://synthetics.eu.newrelic.com/synthetics/api/v3/monitors/",
+		LabelSynthetics: "https://synthetics.eu.newrelic.com/synthetics/api/v4/monitors/",
+		Insights:        "https://insights-collector.eu01.nr-data.net/v1/accounts/",
+		Infrastructure:  "https://infra-api.eu.newrelic.com/v2/alerts/",
+		GraphQL:         "https://api.eu.newrelic.com/graphql",
+	},
+}
+
+// EndpointsForRegion returns the endpoints of a NewRelic region, US or EU.
+// An empty region means US.
+func EndpointsForRegion(region string) (Endpoints, error) {
+	if region == "" {
+		region = RegionUS
+	}
+	endpoints, ok := regionEndpoints[strings.ToUpper(region)]
+	if !ok {
+		return Endpoints{}, fmt.Errorf("Unsupported region %q, must be %s or %s.", region, RegionUS, RegionEU)
+	}
+	return endpoints, nil
+}
+
+// baseURL returns the endpoint used as BaseURL by a client of endpointType.
+func (e *Endpoints) baseURL(endpointType string) string {
+	switch endpointType {
+	case "synthetics":
+		return e.Synthetics
+	case "labelSynthetics":
+		return e.LabelSynthetics
+	case "insights":
+		return e.Insights
+	case "infrastructure":
+		return e.Infrastructure
+	case "graphql":
+		return e.GraphQL
+	default:
+		return e.API
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EntitiesService searches the entities of NewRelic, like APM applications,
+// Synthetics monitors or hosts, and manages their tags, through NerdGraph.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apis/nerdgraph/examples/nerdgraph-entities-api-tutorial
+type EntitiesService service
+
+// Entity is an entity as returned by an entity search.
+type Entity struct {
+	GUID       *string `json:"guid,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Type       *string `json:"type,omitempty"`
+	EntityType *string `json:"entityType,omitempty"`
+	Domain     *string `json:"domain,omitempty"`
+	AccountID  *int64  `json:"accountId,omitempty"`
+	Reporting  *bool   `json:"reporting,omitempty"`
+	Tags       []*Tag  `json:"tags,omitempty"`
+}
+
+// TagValue is one value of a tag.
+type TagValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// EntitySearchOptions specifies the entities returned by
+// EntitiesService.SearchPages. They are combined with AND into an entity
+// search query.
+type EntitySearchOptions struct {
+	// Query is an entity search query, like "name LIKE 'web'".
+	Query string
+	// Type is the type of the entities, like APPLICATION, MONITOR or HOST.
+	Type string
+	// Name is the exact name of the entities.
+	Name string
+	// Tags are tag values the entities must all have.
+	Tags []TagValue
+}
+
+// SearchQuery returns the entity search query of opt.
+func (opt *EntitySearchOptions) SearchQuery() string {
+	var terms []string
+	if opt.Query != "" {
+		terms = append(terms, "("+opt.Query+")")
+	}
+	if opt.Type != "" {
+		terms = append(terms, "type = "+quoteSearchValue(strings.ToUpper(opt.Type)))
+	}
+	if opt.Name != "" {
+		terms = append(terms, "name = "+quoteSearchValue(opt.Name))
+	}
+	for _, tag := range opt.Tags {
+		terms = append(terms, "tags.`"+tag.Key+"` = "+quoteSearchValue(tag.Value))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func quoteSearchValue(value string) string {
+	return "'" + strings.Replace(value, "'", `\'`, -1) + "'"
+}
+
+const entitySearchQueryString = `query($query: String, $cursor: String) {
+  actor {
+    entitySearch(query: $query) {
+      count
+      results(cursor: $cursor) {
+        nextCursor
+        entities { guid name type entityType domain accountId reporting tags { key values } }
+      }
+    }
+  }
+}`
+
+type entitySearchData struct {
+	Actor struct {
+		EntitySearch struct {
+			Results struct {
+				NextCursor *string   `json:"nextCursor"`
+				Entities   []*Entity `json:"entities"`
+			} `json:"results"`
+		} `json:"entitySearch"`
+	} `json:"actor"`
+}
+
+// SearchPages calls fn with every page of the entities matching opt.
+func (s *EntitiesService) SearchPages(ctx context.Context, opt *EntitySearchOptions, fn func([]*Entity, *Response) error) error {
+	if opt == nil {
+		opt = new(EntitySearchOptions)
+	}
+	variables := map[string]interface{}{"query": opt.SearchQuery()}
+	return s.client.GraphQL.QueryPages(ctx, entitySearchQueryString, variables, "cursor", func(data json.RawMessage, resp *Response) (*string, error) {
+		page := new(entitySearchData)
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, err
+		}
+		results := page.Actor.EntitySearch.Results
+		if err := fn(results.Entities, resp); err != nil {
+			return nil, err
+		}
+		return results.NextCursor, nil
+	})
+}
+
+// SearchAll returns the entities matching opt from all pages.
+func (s *EntitiesService) SearchAll(ctx context.Context, opt *EntitySearchOptions) ([]*Entity, error) {
+	var all []*Entity
+	err := s.SearchPages(ctx, opt, func(entities []*Entity, resp *Response) error {
+		all = append(all, entities...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+const entityQueryString = `query($guid: EntityGuid!) {
+  actor {
+    entity(guid: $guid) { guid name type entityType domain accountId reporting tags { key values } }
+  }
+}`
+
+// GetByGUID returns the entity of the given `guid`, nil if there is none.
+func (s *EntitiesService) GetByGUID(ctx context.Context, guid string) (*Entity, *Response, error) {
+	data := new(struct {
+		Actor struct {
+			Entity *Entity `json:"entity"`
+		} `json:"actor"`
+	})
+	resp, err := s.client.GraphQL.Query(ctx, entityQueryString, map[string]interface{}{"guid": guid}, data)
+	if err != nil {
+		return nil, resp, err
+	}
+	return data.Actor.Entity, resp, nil
+}
+
+// TaggingError is an error a tagging mutation answers with, like a tag
+// key not allowed.
+type TaggingError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+func (e *TaggingError) Error() string {
+	if e.Type == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Type)
+}
+
+const (
+	addTagsMutation = `mutation($guid: EntityGuid!, $tags: [TaggingTagInput!]!) {
+  taggingAddTagsToEntity(guid: $guid, tags: $tags) { errors { message type } }
+}`
+	replaceTagsMutation = `mutation($guid: EntityGuid!, $tags: [TaggingTagInput!]!) {
+  taggingReplaceTagsOnEntity(guid: $guid, tags: $tags) { errors { message type } }
+}`
+	deleteTagsMutation = `mutation($guid: EntityGuid!, $tagKeys: [String!]!) {
+  taggingDeleteTagFromEntity(guid: $guid, tagKeys: $tagKeys) { errors { message type } }
+}`
+	deleteTagValuesMutation = `mutation($guid: EntityGuid!, $tagValues: [TaggingTagValueInput!]!) {
+  taggingDeleteTagValuesFromEntity(guid: $guid, tagValues: $tagValues) { errors { message type } }
+}`
+)
+
+// AddTags adds tags to the entity guid, keeping its other tags and values.
+func (s *EntitiesService) AddTags(ctx context.Context, guid string, tags []*Tag) (*Response, error) {
+	return s.tag(ctx, "taggingAddTagsToEntity", addTagsMutation, map[string]interface{}{"guid": guid, "tags": tags})
+}
+
+// ReplaceTags replaces all the tags of the entity guid by tags.
+func (s *EntitiesService) ReplaceTags(ctx context.Context, guid string, tags []*Tag) (*Response, error) {
+	return s.tag(ctx, "taggingReplaceTagsOnEntity", replaceTagsMutation, map[string]interface{}{"guid": guid, "tags": tags})
+}
+
+// DeleteTags deletes the tags with the given keys, and all their values,
+// from the entity guid.
+func (s *EntitiesService) DeleteTags(ctx context.Context, guid string, keys []string) (*Response, error) {
+	return s.tag(ctx, "taggingDeleteTagFromEntity", deleteTagsMutation, map[string]interface{}{"guid": guid, "tagKeys": keys})
+}
+
+// DeleteTagValues deletes tag values from the entity guid.
+func (s *EntitiesService) DeleteTagValues(ctx context.Context, guid string, values []TagValue) (*Response, error) {
+	return s.tag(ctx, "taggingDeleteTagValuesFromEntity", deleteTagValuesMutation, map[string]interface{}{"guid": guid, "tagValues": values})
+}
+
+// tag runs the tagging mutation named field, returning the first error it
+// answers with.
+func (s *EntitiesService) tag(ctx context.Context, field string, mutation string, variables map[string]interface{}) (*Response, error) {
+	var data map[string]struct {
+		Errors []*TaggingError `json:"errors"`
+	}
+	resp, err := s.client.GraphQL.Query(ctx, mutation, variables, &data)
+	if err != nil {
+		return resp, err
+	}
+	if errs := data[field].Errors; len(errs) > 0 {
+		return resp, errs[0]
+	}
+	return resp, nil
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEntitySearchQuery(t *testing.T) {
+	opt := &EntitySearchOptions{
+		Query: "name LIKE 'web'",
+		Type:  "application",
+		Name:  "O'Brien",
+		Tags:  []TagValue{{Key: "env", Value: "prod"}},
+	}
+	want := "(name LIKE 'web') AND type = 'APPLICATION' AND name = 'O\\'Brien' AND tags.`env` = 'prod'"
+	if got := opt.SearchQuery(); got != want {
+		t.Errorf("SearchQuery returned %q, want %q", got, want)
+	}
+}
+
+func TestEntitiesSearchAllPages(t *testing.T) {
+	var cursors []interface{}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["query"] != "type = 'HOST'" {
+			t.Errorf("got query variable %v", body.Variables["query"])
+		}
+		cursors = append(cursors, body.Variables["cursor"])
+		// Pages of the entitySearch results in the NerdGraph entities tutorial.
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{
+  "data": {
+    "actor": {
+      "entitySearch": {
+        "count": 2,
+        "results": {
+          "nextCursor": "MjAxOC0wNi0xMg==",
+          "entities": [
+            {"guid": "MTIzNDU2N3xJTkZSQXxOQXwxMjM", "name": "web-1", "type": "HOST", "entityType": "INFRASTRUCTURE_HOST_ENTITY",
+             "domain": "INFRA", "accountId": 1234567, "reporting": true, "tags": [{"key": "env", "values": ["prod"]}]}
+          ]
+        }
+      }
+    }
+  }
+}`)
+			return
+		}
+		fmt.Fprint(w, `{
+  "data": {
+    "actor": {
+      "entitySearch": {
+        "count": 2,
+        "results": {
+          "nextCursor": null,
+          "entities": [
+            {"guid": "MTIzNDU2N3xJTkZSQXxOQXw0NTY", "name": "web-2", "type": "HOST", "entityType": "INFRASTRUCTURE_HOST_ENTITY",
+             "domain": "INFRA", "accountId": 1234567, "reporting": false, "tags": []}
+          ]
+        }
+      }
+    }
+  }
+}`)
+	})
+
+	entities, err := client.Entities.SearchAll(context.Background(), &EntitySearchOptions{Type: "host"})
+	if err != nil {
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if len(entities) != 2 || *entities[0].Name != "web-1" || *entities[1].Name != "web-2" {
+		t.Fatalf("got %d entities, want web-1 and web-2", len(entities))
+	}
+	if e := entities[0]; *e.AccountID != 1234567 || !*e.Reporting || *e.Tags[0].Key != "env" || *e.Tags[0].Values[0] != "prod" {
+		t.Errorf("got entity %+v", e)
+	}
+	if want := []interface{}{nil, "MjAxOC0wNi0xMg=="}; !reflect.DeepEqual(cursors, want) {
+		t.Errorf("got cursors %v, want %v", cursors, want)
+	}
+}
+
+func TestEntitiesGetByGUID(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["guid"] != "MTIzNDU2N3xBUE18QVBQTElDQVRJT058MTA" {
+			fmt.Fprint(w, `{"data":{"actor":{"entity":null}}}`)
+			return
+		}
+		fmt.Fprint(w, `{
+  "data": {
+    "actor": {
+      "entity": {
+        "guid": "MTIzNDU2N3xBUE18QVBQTElDQVRJT058MTA", "name": "web", "type": "APPLICATION", "entityType": "APM_APPLICATION_ENTITY",
+        "domain": "APM", "accountId": 1234567, "reporting": true, "tags": [{"key": "language", "values": ["java"]}]
+      }
+    }
+  }
+}`)
+	})
+
+	entity, _, err := client.Entities.GetByGUID(context.Background(), "MTIzNDU2N3xBUE18QVBQTElDQVRJT058MTA")
+	if err != nil || entity == nil || *entity.Name != "web" || *entity.Domain != "APM" {
+		t.Fatalf("GetByGUID returned %+v, %v", entity, err)
+	}
+	if entity, _, err := client.Entities.GetByGUID(context.Background(), "missing"); entity != nil || err != nil {
+		t.Errorf("GetByGUID of a missing entity returned %+v, %v, want nil, nil", entity, err)
+	}
+}
+
+func TestEntitiesTagging(t *testing.T) {
+	guid := "MTIzNDU2N3xBUE18QVBQTElDQVRJT058MTA"
+	tests := []struct {
+		field     string
+		variables string
+	}{
+		{"taggingAddTagsToEntity", `{"guid":"` + guid + `","tags":[{"key":"team","values":["web"]}]}`},
+		{"taggingReplaceTagsOnEntity", `{"guid":"` + guid + `","tags":[{"key":"team","values":["web"]}]}`},
+		{"taggingDeleteTagFromEntity", `{"guid":"` + guid + `","tagKeys":["team"]}`},
+		{"taggingDeleteTagValuesFromEntity", `{"guid":"` + guid + `","tagValues":[{"key":"team","value":"web"}]}`},
+	}
+	var got []graphQLBody
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body)
+		for _, test := range tests {
+			if strings.Contains(body.Query, test.field+"(") {
+				fmt.Fprintf(w, `{"data":{%q:{"errors":[]}}}`, test.field)
+			}
+		}
+	})
+	ctx := context.Background()
+	key, value := "team", "web"
+	tags := []*Tag{{Key: &key, Values: []*string{&value}}}
+
+	if _, err := client.Entities.AddTags(ctx, guid, tags); err != nil {
+		t.Errorf("AddTags returned error: %v", err)
+	}
+	if _, err := client.Entities.ReplaceTags(ctx, guid, tags); err != nil {
+		t.Errorf("ReplaceTags returned error: %v", err)
+	}
+	if _, err := client.Entities.DeleteTags(ctx, guid, []string{"team"}); err != nil {
+		t.Errorf("DeleteTags returned error: %v", err)
+	}
+	if _, err := client.Entities.DeleteTagValues(ctx, guid, []TagValue{{Key: "team", Value: "web"}}); err != nil {
+		t.Errorf("DeleteTagValues returned error: %v", err)
+	}
+
+	if len(got) != len(tests) {
+		t.Fatalf("got %d requests, want %d", len(got), len(tests))
+	}
+	for i, test := range tests {
+		if !strings.Contains(got[i].Query, test.field+"(") {
+			t.Errorf("request %d: got query %q, want the %s mutation", i, got[i].Query, test.field)
+		}
+		variables, _ := json.Marshal(got[i].Variables)
+		if string(variables) != test.variables {
+			t.Errorf("request %d: got variables %s, want %s", i, variables, test.variables)
+		}
+	}
+}
+
+func TestEntitiesTaggingError(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"taggingAddTagsToEntity":{"errors":[{"message":"Tag key env is reserved","type":"FORBIDDEN"}]}}}`)
+	})
+	key, value := "env", "prod"
+
+	_, err := client.Entities.AddTags(context.Background(), "MTIzNDU2N3xBUE18QVBQTElDQVRJT058MTA", []*Tag{{Key: &key, Values: []*string{&value}}})
+	var taggingErr *TaggingError
+	if !errors.As(err, &taggingErr) || taggingErr.Type != "FORBIDDEN" {
+		t.Fatalf("got %v, want the TaggingError", err)
+	}
+	if err.Error() != "Tag key env is reserved (FORBIDDEN)" {
+		t.Errorf("got message %q", err.Error())
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIError is returned for every call NewRelic answered with a non 2xx
+// status. Use errors.As to get it, or the Is* helpers to test the cause.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// RequestID identifies the call for NewRelic support, if sent back.
+	RequestID string
+	// Message is the error message parsed from the response body.
+	Message string
+	// Fields maps the fields rejected by a validation error to their
+	// message, when NewRelic reports them one by one.
+	Fields map[string]string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = truncateString(strings.TrimSpace(string(e.Body)), 100)
+	}
+	s := fmt.Sprintf("%s %s returns status:%d error: %s", e.Method, e.URL, e.StatusCode, msg)
+	if e.RequestID != "" {
+		s += fmt.Sprintf(" (request id: %s)", e.RequestID)
+	}
+	return s
+}
+
+// Description returns the error message NewRelic sent back or, when there
+// is none, what the status code means for the API that was called.
+func (e *APIError) Description() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	path := e.URL
+	if u, err := url.Parse(e.URL); err == nil {
+		path = u.Path
+	}
+	if desc, ok := apiStatusDescriptions(path)[e.StatusCode]; ok {
+		return desc
+	}
+	if desc, ok := commonStatusDescriptions[e.StatusCode]; ok {
+		return desc
+	}
+	return http.StatusText(e.StatusCode)
+}
+
+var commonStatusDescriptions = map[int]string{
+	400: "Bad request",
+	401: "Invalid request, API key required",
+	403: "New Relic API access has not been enabled",
+	500: "A server error occurred, please contact New Relic support",
+}
+
+// apiStatusDescriptions returns the status codes documented by the NewRelic
+// API served on path.
+func apiStatusDescriptions(path string) map[int]string {
+	switch {
+	case strings.HasSuffix(path, "/events"):
+		return map[int]string{
+			403: "Invalid insert key",
+			408: "Request timed out",
+			413: "Content too large",
+			429: "Too many requests",
+		}
+	case strings.Contains(path, "/script"):
+		return map[int]string{
+			400: "The script is invalid, or the format of the request is invalid.",
+			404: "The specified monitor does not exist",
+		}
+	case strings.Contains(path, "/monitors/") && strings.Contains(path, "/labels"):
+		return map[int]string{
+			400: "Bad request, or the format of the request is invalid.",
+			404: "The specified label does not exist",
+		}
+	case strings.Contains(path, "/monitors"):
+		return map[int]string{
+			400: "The monitor values is invalid, or the format of the request is invalid.",
+			404: "The specified monitor does not exist",
+		}
+	case strings.Contains(path, "_conditions/policies/"):
+		return map[int]string{
+			404: "No Alerts policy was found for the given ID",
+			422: "Validation error occurred while trying to create the alert condition",
+		}
+	case strings.Contains(path, "_conditions"):
+		return map[int]string{
+			404: "No Alerts condition was found with the given ID",
+			406: "Bad entity type",
+			422: "Validation error occurred while trying to update the alert condition",
+		}
+	case strings.Contains(path, "alerts_channels"), strings.Contains(path, "alerts_policies"):
+		return map[int]string{
+			422: "Validation or internal error occurred",
+		}
+	}
+	return nil
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError for a call rejected by
+// the rate limiter.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsValidation reports whether err is an APIError for a request NewRelic
+// refused to process as is, like a duplicated name or a missing field.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, codes ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// apiErrorBody covers the error formats of the NewRelic APIs:
+//
+//	REST v2          {"error": {"title": "..."}}
+//	Synthetics       {"errors": [{"error": "...", "field": "..."}]}
+//	Infrastructure   {"errors": [{"status": "...", "detail": "..."}]}
+//	Insights         {"success": false, "error": "..."}
+type apiErrorBody struct {
+	Error   json.RawMessage `json:"error"`
+	Errors  []apiErrorEntry `json:"errors"`
+	Message string          `json:"message"`
+}
+
+type apiErrorEntry struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+	Field   string `json:"field"`
+}
+
+func (e *apiErrorEntry) text() string {
+	switch {
+	case e.Error != "":
+		return e.Error
+	case e.Message != "":
+		return e.Message
+	}
+	return e.Detail
+}
+
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+
+	var parsed apiErrorBody
+	if json.Unmarshal(body, &parsed) != nil {
+		return apiErr
+	}
+
+	var messages []string
+	if len(parsed.Error) > 0 {
+		var title struct {
+			Title string `json:"title"`
+		}
+		var text string
+		if json.Unmarshal(parsed.Error, &title) == nil && title.Title != "" {
+			messages = append(messages, title.Title)
+		} else if json.Unmarshal(parsed.Error, &text) == nil && text != "" {
+			messages = append(messages, text)
+		}
+	}
+	for _, entry := range parsed.Errors {
+		if entry.text() == "" {
+			continue
+		}
+		if entry.Field != "" {
+			if apiErr.Fields == nil {
+				apiErr.Fields = make(map[string]string)
+			}
+			apiErr.Fields[entry.Field] = entry.text()
+			messages = append(messages, entry.Field+": "+entry.text())
+		} else {
+			messages = append(messages, entry.text())
+		}
+	}
+	if len(messages) == 0 && parsed.Message != "" {
+		messages = append(messages, parsed.Message)
+	}
+	apiErr.Message = strings.Join(messages, "; ")
+
+	return apiErr
+}
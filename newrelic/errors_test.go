@@ -0,0 +1,105 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorFromRESTv2(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc-123")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"title":"Policy not found"}}`)
+	})
+
+	_, resp, err := client.AlertsPolicies.ListAll(context.Background(), nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != 404 || apiErr.Message != "Policy not found" || apiErr.RequestID != "abc-123" {
+		t.Errorf("got %+v", apiErr)
+	}
+	if resp == nil || resp.StatusCode != 404 {
+		t.Errorf("want the response along with the error")
+	}
+	if !IsNotFound(err) || IsValidation(err) || IsRateLimited(err) {
+		t.Errorf("helpers do not match status 404")
+	}
+}
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"field":"frequency","error":"must be one of 1, 5, 10"}]}`)
+	})
+
+	name := "m"
+	_, _, err := client.SyntheticsMonitors.Create(context.Background(), &Monitor{Name: &name})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want *APIError", err)
+	}
+	if apiErr.Fields["frequency"] != "must be one of 1, 5, 10" {
+		t.Errorf("got fields %v", apiErr.Fields)
+	}
+	if !IsValidation(fmt.Errorf("wrapped: %w", err)) {
+		t.Errorf("IsValidation does not see through wrapped errors")
+	}
+}
+
+func TestAPIErrorFromConditionsListAll(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"title":"No Alerts policy was found for the given ID"}}`)
+	})
+
+	_, err := client.AlertsConditions.ListAll(context.Background(), &AlertsConditionsOptions{PolicyIDOptions: "1"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want a wrapped *APIError", err)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound does not see through the condition category")
+	}
+}
+
+func TestAPIErrorDescription(t *testing.T) {
+	cases := []struct {
+		err  *APIError
+		want string
+	}{
+		{&APIError{URL: "https://api.newrelic.com/v2/alerts_policies.json", StatusCode: 404, Message: "Policy not found"}, "Policy not found"},
+		{&APIError{URL: "https://insights-collector.newrelic.com/v1/accounts/1/events", StatusCode: 403}, "Invalid insert key"},
+		{&APIError{URL: "https://synthetics.newrelic.com/synthetics/api/v3/monitors/abc", StatusCode: 404}, "The specified monitor does not exist"},
+		{&APIError{URL: "https://api.newrelic.com/v2/alerts_conditions/policies/1.json", StatusCode: 404}, "No Alerts policy was found for the given ID"},
+		{&APIError{URL: "https://api.newrelic.com/v2/labels.json", StatusCode: 401}, "Invalid request, API key required"},
+		{&APIError{URL: "https://api.newrelic.com/v2/users.json", StatusCode: 429}, "Too Many Requests"},
+	}
+	for _, c := range cases {
+		if got := c.err.Description(); got != c.want {
+			t.Errorf("%s %d: got %q, want %q", c.err.URL, c.err.StatusCode, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GraphQLClient runs queries and mutations against NerdGraph, the GraphQL
+// API of NewRelic. It is used by the services backed by NerdGraph, and can
+// run any query through a client created for the "graphql" endpoint.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apis/nerdgraph/get-started/introduction-new-relic-nerdgraph
+type GraphQLClient service
+
+// GraphQLError is one of the errors NerdGraph answers a query with. Its
+// status is still 200, so it is not an APIError.
+type GraphQLError struct {
+	Message   string                 `json:"message"`
+	Path      []interface{}          `json:"path,omitempty"`
+	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
+	// Extensions holds the details NerdGraph adds, like its errorClass.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(path, "."), e.Message)
+}
+
+// Class returns the errorClass of the extensions of e, like
+// "INVALID_PARAMETER", empty if NerdGraph sent none.
+func (e *GraphQLError) Class() string {
+	class, _ := e.Extensions["errorClass"].(string)
+	return class
+}
+
+// GraphQLErrors are all the errors of a NerdGraph answer. Use errors.As to
+// get them.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+type graphQLBody struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResp struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors,omitempty"`
+}
+
+// Query runs query with variables and decodes its data into v, a pointer to
+// a struct shaped like the query or a *json.RawMessage. NerdGraph may answer
+// with both data and errors: v then holds the partial data and the errors are
+// returned as GraphQLErrors.
+func (c *GraphQLClient) Query(ctx context.Context, query string, variables map[string]interface{}, v interface{}) (*Response, error) {
+	req, err := c.client.NewRequest("POST", "", &graphQLBody{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(graphQLResp)
+	resp, err := c.client.Do(ctx, req, result)
+	if err != nil {
+		return resp, err
+	}
+	if v != nil && len(result.Data) > 0 && string(result.Data) != "null" {
+		if err := json.Unmarshal(result.Data, v); err != nil {
+			return resp, err
+		}
+	}
+	if len(result.Errors) > 0 {
+		return resp, result.Errors
+	}
+	return resp, nil
+}
+
+// QueryPages runs query once per page of a cursor paginated list, passing
+// the cursor of the page as the variable cursorVar, unset for the first one.
+// fn is called with the data of every page and returns the cursor of the
+// next one, like the nextCursor of the results, nil or empty after the last
+// page.
+func (c *GraphQLClient) QueryPages(ctx context.Context, query string, variables map[string]interface{}, cursorVar string, fn func(data json.RawMessage, resp *Response) (*string, error)) error {
+	vars := make(map[string]interface{}, len(variables)+1)
+	for name, value := range variables {
+		vars[name] = value
+	}
+	return listCursor(func(cursor *string) (*string, error) {
+		if cursor != nil {
+			vars[cursorVar] = *cursor
+		}
+		var data json.RawMessage
+		resp, err := c.Query(ctx, query, vars, &data)
+		if err != nil {
+			return nil, err
+		}
+		return fn(data, resp)
+	})
+}
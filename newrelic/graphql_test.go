@@ -0,0 +1,119 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Api-Key") != "key" {
+			t.Errorf("got %s with headers %v", r.Method, r.Header)
+		}
+		if body.Query != `query($id: Int!) { actor { account(id: $id) { name } user { name email } } }` || body.Variables["id"] != float64(1234567) {
+			t.Errorf("got body %+v", body)
+		}
+		// Answer of the first query of the NerdGraph tutorial.
+		fmt.Fprint(w, `{"data":{"actor":{"account":{"name":"Acme Corp"},"user":{"email":"jdoe@example.com","name":"Jane Doe"}}}}`)
+	})
+	client.XApiKey = "key"
+
+	var data struct {
+		Actor struct {
+			Account struct {
+				Name string `json:"name"`
+			} `json:"account"`
+			User struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+			} `json:"user"`
+		} `json:"actor"`
+	}
+	_, err := client.GraphQL.Query(context.Background(), `query($id: Int!) { actor { account(id: $id) { name } user { name email } } }`,
+		map[string]interface{}{"id": 1234567}, &data)
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if data.Actor.Account.Name != "Acme Corp" || data.Actor.User.Email != "jdoe@example.com" {
+		t.Errorf("got %+v", data)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"actor":{"user":{"name":"jdoe"},"account":null}},
+			"errors":[{"message":"Not authorized","path":["actor","account"],"extensions":{"errorClass":"FORBIDDEN"}}]}`)
+	})
+
+	var data struct {
+		Actor struct {
+			User struct {
+				Name string `json:"name"`
+			} `json:"user"`
+		} `json:"actor"`
+	}
+	_, err := client.GraphQL.Query(context.Background(), `{ actor { user { name } account(id: 1) { id } } }`, nil, &data)
+
+	var graphQLErrs GraphQLErrors
+	if !errors.As(err, &graphQLErrs) || len(graphQLErrs) != 1 {
+		t.Fatalf("got %v, want GraphQLErrors", err)
+	}
+	if graphQLErrs[0].Class() != "FORBIDDEN" || err.Error() != "actor.account: Not authorized" {
+		t.Errorf("got %q of class %q", err, graphQLErrs[0].Class())
+	}
+	if data.Actor.User.Name != "jdoe" {
+		t.Errorf("partial data was not decoded: %+v", data)
+	}
+}
+
+func TestGraphQLQueryPages(t *testing.T) {
+	var cursors []interface{}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		cursors = append(cursors, body.Variables["cursor"])
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{"data":{"results":{"nextCursor":"2"}}}`)
+		} else {
+			fmt.Fprint(w, `{"data":{"results":{"nextCursor":null}}}`)
+		}
+	})
+
+	err := client.GraphQL.QueryPages(context.Background(), `query($cursor: String) { results(cursor: $cursor) { nextCursor } }`, nil, "cursor",
+		func(data json.RawMessage, resp *Response) (*string, error) {
+			var page struct {
+				Results struct {
+					NextCursor *string `json:"nextCursor"`
+				} `json:"results"`
+			}
+			err := json.Unmarshal(data, &page)
+			return page.Results.NextCursor, err
+		})
+	if err != nil {
+		t.Fatalf("QueryPages returned error: %v", err)
+	}
+	if len(cursors) != 2 || cursors[0] != nil || cursors[1] != "2" {
+		t.Errorf("got cursors %v, want nil then 2", cursors)
+	}
+}
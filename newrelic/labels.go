@@ -18,6 +18,8 @@ package newrelic
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // NewRelic API docs: https://docs.newrelic.com/docs/apis/rest-api-v2/labels-examples-v2/list-labels-v2
@@ -87,6 +89,52 @@ func (s *LabelsService) ListAll(ctx context.Context, opt *LabelListOptions) (*La
 	return labelList, resp, nil
 }
 
+// ListPages calls fn with every page of the labels matching opt.
+func (s *LabelsService) ListPages(ctx context.Context, opt *LabelListOptions, fn func(*LabelList, *Response) error) error {
+	if opt == nil {
+		opt = new(LabelListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Labels), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the labels matching opt from all pages.
+func (s *LabelsService) ListAllPages(ctx context.Context, opt *LabelListOptions) (*LabelList, error) {
+	all := new(LabelList)
+	err := s.ListPages(ctx, opt, func(list *LabelList, resp *Response) error {
+		all.Labels = append(all.Labels, list.Labels...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// GetByKey returns the label key, like Env:Production, compared case
+// insensitively, or nil if there is none. The API has no call for one label,
+// the labels are listed.
+func (s *LabelsService) GetByKey(ctx context.Context, key string) (*Label, error) {
+	var found *Label
+	err := s.ListPages(ctx, nil, func(list *LabelList, resp *Response) error {
+		for _, label := range list.Labels {
+			if found == nil && label.Key != nil && strings.EqualFold(*label.Key, key) {
+				found = label
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
 func (s *LabelsService) Create(ctx context.Context, l *LabelEntity) (*LabelEntity, *Response, error) {
 	u := "labels.json"
 	req, err := s.client.NewRequest("PUT", u, l)
@@ -104,7 +152,7 @@ func (s *LabelsService) Create(ctx context.Context, l *LabelEntity) (*LabelEntit
 }
 
 func (s *LabelsService) DeleteByKey(ctx context.Context, key string) (*Response, error) {
-	u := fmt.Sprintf("labels/%v.json", key)
+	u := fmt.Sprintf("labels/%v.json", url.PathEscape(key))
 	req, err := s.client.NewRequest("DELETE", u, nil)
 	if err != nil {
 		return nil, err
@@ -75,6 +75,40 @@ func (s *LabelsSyntheticsService) GetMonitorsByLabel(ctx context.Context, opt *P
 	return labelSynthetics, resp, nil
 }
 
+// GetMonitorsByLabelPages calls fn with every page of the monitors having
+// label, walking them by the limit and offset set in opt.
+func (s *LabelsSyntheticsService) GetMonitorsByLabelPages(ctx context.Context, opt *PageLimitOptions, label string, fn func(*LabelSynthetics, *Response) error) error {
+	if opt == nil {
+		opt = new(PageLimitOptions)
+	}
+	return listOffsets(opt, func() (int, error) {
+		labelSynthetics, resp, err := s.GetMonitorsByLabel(ctx, opt, label)
+		if err != nil {
+			return 0, err
+		}
+		var count int
+		if labelSynthetics.PagedData != nil {
+			count = len(labelSynthetics.PagedData.MonitorRefs)
+		}
+		return count, fn(labelSynthetics, resp)
+	})
+}
+
+// GetAllMonitorsByLabel returns the references of all monitors having label.
+func (s *LabelsSyntheticsService) GetAllMonitorsByLabel(ctx context.Context, label string) (*MonitorRefList, error) {
+	all := new(MonitorRefList)
+	err := s.GetMonitorsByLabelPages(ctx, nil, label, func(labelSynthetics *LabelSynthetics, resp *Response) error {
+		if labelSynthetics.PagedData != nil {
+			all.MonitorRefs = append(all.MonitorRefs, labelSynthetics.PagedData.MonitorRefs...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 func (s *LabelsSyntheticsService) AddLabelToMonitor(ctx context.Context, monitorId string, monitorLabel *MonitorLabel) (*Response, error) {
 	u := fmt.Sprintf("%v/labels", monitorId)
 
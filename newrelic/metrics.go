@@ -0,0 +1,182 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MetricsService handles communication with the APM metric timeslice data
+// related methods of the NewRelic API.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apis/rest-api-v2/get-started/get-metric-timeslice-data
+type MetricsService service
+
+// MetricName is a metric of an application or a host, with the names of its
+// values.
+type MetricName struct {
+	Name   *string   `json:"name,omitempty"`
+	Values []*string `json:"values,omitempty"`
+}
+
+// MetricNameList represents a collection of metric names.
+type MetricNameList struct {
+	Metrics []*MetricName `json:"metrics,omitempty"`
+}
+
+// MetricNameListOptions specifies optional parameters to the
+// MetricsService.ListNames methods. NameOptions matches the metric names
+// containing it.
+type MetricNameListOptions struct {
+	NameOptions string `url:"name,omitempty"`
+
+	PageOptions
+}
+
+// MetricDataOptions specifies the metric data returned by the
+// MetricsService.GetData methods. Without From and To it is the data of the
+// last 30 minutes.
+type MetricDataOptions struct {
+	Names     []string  `url:"names,brackets,omitempty"`
+	Values    []string  `url:"values,brackets,omitempty"`
+	From      time.Time `url:"from,omitempty"`
+	To        time.Time `url:"to,omitempty"`
+	Period    int64     `url:"period,omitempty"`
+	Summarize bool      `url:"summarize,omitempty"`
+}
+
+// MetricTimeslice holds the values of a metric over a period of time.
+type MetricTimeslice struct {
+	From   *string             `json:"from,omitempty"`
+	To     *string             `json:"to,omitempty"`
+	Values map[string]*float64 `json:"values,omitempty"`
+}
+
+// MetricTimeslices holds the timeslices of one metric.
+type MetricTimeslices struct {
+	Name       *string            `json:"name,omitempty"`
+	Timeslices []*MetricTimeslice `json:"timeslices,omitempty"`
+}
+
+// MetricData holds the timeslices of the metrics found.
+type MetricData struct {
+	From            *string             `json:"from,omitempty"`
+	To              *string             `json:"to,omitempty"`
+	MetricsNotFound []*string           `json:"metrics_not_found,omitempty"`
+	MetricsFound    []*string           `json:"metrics_found,omitempty"`
+	Metrics         []*MetricTimeslices `json:"metrics,omitempty"`
+}
+
+// MetricDataEntity corresponds to a JSON payload returned by NewRelic API
+type MetricDataEntity struct {
+	MetricData *MetricData `json:"metric_data,omitempty"`
+}
+
+// ListNames returns the metric names of the application applicationID
+// matching opt.
+func (s *MetricsService) ListNames(ctx context.Context, applicationID int64, opt *MetricNameListOptions) (*MetricNameList, *Response, error) {
+	return s.listNames(ctx, fmt.Sprintf("applications/%v", applicationID), opt)
+}
+
+// ListNamesForHost returns the metric names of the host hostID of the
+// application applicationID matching opt.
+func (s *MetricsService) ListNamesForHost(ctx context.Context, applicationID int64, hostID int64, opt *MetricNameListOptions) (*MetricNameList, *Response, error) {
+	return s.listNames(ctx, fmt.Sprintf("applications/%v/hosts/%v", applicationID, hostID), opt)
+}
+
+// ListAllNamesPages returns the metric names of the application
+// applicationID, or of its host hostID if it is not 0, matching opt from all
+// pages.
+func (s *MetricsService) ListAllNamesPages(ctx context.Context, applicationID int64, hostID int64, opt *MetricNameListOptions) (*MetricNameList, error) {
+	if opt == nil {
+		opt = new(MetricNameListOptions)
+	}
+	all := new(MetricNameList)
+	err := listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		var list *MetricNameList
+		var resp *Response
+		var err error
+		if hostID != 0 {
+			list, resp, err = s.ListNamesForHost(ctx, applicationID, hostID, opt)
+		} else {
+			list, resp, err = s.ListNames(ctx, applicationID, opt)
+		}
+		if err != nil {
+			return resp, 0, err
+		}
+		all.Metrics = append(all.Metrics, list.Metrics...)
+		return resp, len(list.Metrics), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// GetData returns the timeslices of the metrics of the application
+// applicationID given by opt.
+func (s *MetricsService) GetData(ctx context.Context, applicationID int64, opt *MetricDataOptions) (*MetricDataEntity, *Response, error) {
+	return s.getData(ctx, fmt.Sprintf("applications/%v", applicationID), opt)
+}
+
+// GetDataForHost returns the timeslices of the metrics of the host hostID of
+// the application applicationID given by opt.
+func (s *MetricsService) GetDataForHost(ctx context.Context, applicationID int64, hostID int64, opt *MetricDataOptions) (*MetricDataEntity, *Response, error) {
+	return s.getData(ctx, fmt.Sprintf("applications/%v/hosts/%v", applicationID, hostID), opt)
+}
+
+func (s *MetricsService) listNames(ctx context.Context, path string, opt *MetricNameListOptions) (*MetricNameList, *Response, error) {
+	u, err := addOptions(path+"/metrics.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nameList := new(MetricNameList)
+	resp, err := s.client.Do(ctx, req, nameList)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return nameList, resp, nil
+}
+
+func (s *MetricsService) getData(ctx context.Context, path string, opt *MetricDataOptions) (*MetricDataEntity, *Response, error) {
+	if opt == nil || len(opt.Names) == 0 {
+		return nil, nil, fmt.Errorf("metric names are required")
+	}
+	u, err := addOptions(path+"/metrics/data.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := new(MetricDataEntity)
+	resp, err := s.client.Do(ctx, req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMetricsListAllNamesPages(t *testing.T) {
+	var paths []string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `{"metrics":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"metrics":[{"name":"HttpDispatcher","values":["average_call_time","average_response_time","call_count","min_call_time","max_call_time"]}]}`)
+	})
+
+	names, err := client.Metrics.ListAllNamesPages(context.Background(), 12345, 67890, &MetricNameListOptions{NameOptions: "Http"})
+	if err != nil || len(names.Metrics) != 1 || len(names.Metrics[0].Values) != 5 {
+		t.Fatalf("ListAllNamesPages returned %+v, %v", names, err)
+	}
+	if paths[0] != "/applications/12345/hosts/67890/metrics.json?name=Http&page=1" {
+		t.Errorf("got %q", paths)
+	}
+}
+
+func TestMetricsGetData(t *testing.T) {
+	var query string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/12345/metrics/data.json" {
+			t.Errorf("got path %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		// Answer of GET /v2/applications/{id}/metrics/data.json in the
+		// metric timeslice data documentation.
+		fmt.Fprint(w, `{
+  "metric_data": {
+    "from": "2018-06-12T09:00:00+00:00",
+    "to": "2018-06-12T10:00:00+00:00",
+    "metrics_not_found": ["Missing"],
+    "metrics_found": ["HttpDispatcher"],
+    "metrics": [
+      {
+        "name": "HttpDispatcher",
+        "timeslices": [
+          {"from": "2018-06-12T09:00:00+00:00", "to": "2018-06-12T09:30:00+00:00", "values": {"average_response_time": 12.5, "call_count": 300}},
+          {"from": "2018-06-12T09:30:00+00:00", "to": "2018-06-12T10:00:00+00:00", "values": {"average_response_time": 14, "call_count": 280}}
+        ]
+      }
+    ]
+  }
+}`)
+	})
+	ctx := context.Background()
+
+	to := time.Date(2018, 6, 12, 10, 0, 0, 0, time.UTC)
+	opt := &MetricDataOptions{
+		Names:  []string{"HttpDispatcher", "Missing"},
+		Values: []string{"average_response_time", "call_count"},
+		From:   to.Add(-time.Hour),
+		To:     to,
+		Period: 1800,
+	}
+	data, _, err := client.Metrics.GetData(ctx, 12345, opt)
+	if err != nil {
+		t.Fatalf("GetData returned error: %v", err)
+	}
+	want := "from=2018-06-12T09%3A00%3A00Z&names%5B%5D=HttpDispatcher&names%5B%5D=Missing&period=1800&to=2018-06-12T10%3A00%3A00Z&values%5B%5D=average_response_time&values%5B%5D=call_count"
+	if query != want {
+		t.Errorf("got query %s, want %s", query, want)
+	}
+	metricData := data.MetricData
+	if len(metricData.Metrics) != 1 || *metricData.MetricsNotFound[0] != "Missing" || len(metricData.Metrics[0].Timeslices) != 2 {
+		t.Fatalf("got %+v", metricData)
+	}
+	if slice := metricData.Metrics[0].Timeslices[1]; *slice.Values["average_response_time"] != 14 || *slice.Values["call_count"] != 280 {
+		t.Errorf("got values %v", slice.Values)
+	}
+
+	if _, _, err := client.Metrics.GetData(ctx, 12345, &MetricDataOptions{}); err == nil {
+		t.Error("GetData without names returned no error")
+	}
+}
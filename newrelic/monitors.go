@@ -63,20 +63,11 @@ type Monitor struct {
 	Tags         []*Tag         `json:"tags,omitempty"`
 }
 
-type MonitorTagsGraphqlVar struct {
-	Cursor *string `json:"CURSOR,omitempty"`
-}
-
 type Tag struct {
 	Key    *string   `json:"key"`
 	Values []*string `json:"values"`
 }
 
-type MonitorTagsQueryBody struct {
-	Query     *string                `json:"query"`
-	Variables *MonitorTagsGraphqlVar `json:"variables,omitempty"`
-}
-
 type MonitorOptions struct {
 	ValidationString       *string `json:"validationString,omitempty"`
 	VerifySSL              bool    `json:"verifySSL,omitempty"`
@@ -120,6 +111,29 @@ type EntitySearchResults struct {
 	NextCursor *string                       `json:"nextCursor"`
 }
 
+// Entities returns the monitors found in one page of tags, nil if the
+// response holds no search results.
+func (r *MonitorTagsResp) Entities() []*EntitySearchResultsMonitor {
+	if results := r.results(); results != nil {
+		return results.Entities
+	}
+	return nil
+}
+
+func (r *MonitorTagsResp) nextCursor() *string {
+	if results := r.results(); results != nil {
+		return results.NextCursor
+	}
+	return nil
+}
+
+func (r *MonitorTagsResp) results() *EntitySearchResults {
+	if r.Data == nil || r.Data.Actor == nil || r.Data.Actor.EntitySearch == nil {
+		return nil
+	}
+	return r.Data.Actor.EntitySearch.Results
+}
+
 type EntitySearchResultsMonitor struct {
 	Guid      *string `json:"guid"`
 	MonitorId *string `json:"monitorId"`
@@ -150,31 +164,78 @@ func (s *SyntheticsService) ListAll(ctx context.Context, opt *MonitorListOptions
 	return monitorList, resp, nil
 }
 
-func (s *SyntheticsService) ListTags(ctx context.Context, cursor *string) (*MonitorTagsResp, *Response, error) {
-	var q string = monitorTagsQueryString
-	var body *MonitorTagsQueryBody = &MonitorTagsQueryBody{
-		Query: &q}
-
-	if cursor != nil {
-		body.Variables = &MonitorTagsGraphqlVar{
-			Cursor: cursor}
+// ListPages calls fn with every page of the monitors, walking them by the
+// limit and offset set in opt.
+func (s *SyntheticsService) ListPages(ctx context.Context, opt *MonitorListOptions, fn func(*MonitorList, *Response) error) error {
+	if opt == nil {
+		opt = new(MonitorListOptions)
 	}
+	return listOffsets(&opt.PageLimitOptions, func() (int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return 0, err
+		}
+		return len(list.Monitors), fn(list, resp)
+	})
+}
 
-	req, err := s.client.NewRequest("POST", "", body)
-
+// ListAllPages returns the monitors from all pages.
+func (s *SyntheticsService) ListAllPages(ctx context.Context, opt *MonitorListOptions) (*MonitorList, error) {
+	all := new(MonitorList)
+	err := s.ListPages(ctx, opt, func(list *MonitorList, resp *Response) error {
+		all.Monitors = append(all.Monitors, list.Monitors...)
+		return nil
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	return all, nil
+}
 
-	monitorTags := new(MonitorTagsResp)
+// ListTags returns one page of the monitors and their tags, the first one
+// if cursor is nil.
+func (s *SyntheticsService) ListTags(ctx context.Context, cursor *string) (*MonitorTagsResp, *Response, error) {
+	var variables map[string]interface{}
+	if cursor != nil {
+		variables = map[string]interface{}{"CURSOR": *cursor}
+	}
 
-	resp, err := s.client.Do(ctx, req, monitorTags)
+	monitorTags := &MonitorTagsResp{Data: new(Data)}
+	resp, err := s.client.GraphQL.Query(ctx, monitorTagsQueryString, variables, monitorTags.Data)
 	if err != nil {
 		return nil, resp, err
 	}
 	return monitorTags, resp, nil
 }
 
+// ListTagsPages calls fn with every page of the monitor tags, following the
+// GraphQL cursor.
+func (s *SyntheticsService) ListTagsPages(ctx context.Context, fn func(*MonitorTagsResp, *Response) error) error {
+	return listCursor(func(cursor *string) (*string, error) {
+		monitorTags, resp, err := s.ListTags(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(monitorTags, resp); err != nil {
+			return nil, err
+		}
+		return monitorTags.nextCursor(), nil
+	})
+}
+
+// ListAllTags returns the tags of all monitors.
+func (s *SyntheticsService) ListAllTags(ctx context.Context) ([]*EntitySearchResultsMonitor, error) {
+	var all []*EntitySearchResultsMonitor
+	err := s.ListTagsPages(ctx, func(monitorTags *MonitorTagsResp, resp *Response) error {
+		all = append(all, monitorTags.Entities()...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 func (s *SyntheticsService) GetByID(ctx context.Context, id string) (*Monitor, *Response, error) {
 	req, err := s.client.NewRequest("GET", id, nil)
 	if err != nil {
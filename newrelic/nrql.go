@@ -0,0 +1,84 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NRQLService runs NRQL queries through NerdGraph.
+//
+// NewRelic API docs: https://docs.newrelic.com/docs/apis/nerdgraph/examples/nerdgraph-nrql-tutorial
+type NRQLService service
+
+// NRQLResult is the result set of a NRQL query. Results holds one object per
+// row, like {"facet": "web", "count": 10}, with the fields in the order of
+// the query.
+type NRQLResult struct {
+	Results     []json.RawMessage `json:"results"`
+	TotalResult json.RawMessage   `json:"totalResult,omitempty"`
+	Metadata    *NRQLMetadata     `json:"metadata,omitempty"`
+}
+
+type NRQLMetadata struct {
+	EventTypes []string        `json:"eventTypes,omitempty"`
+	Facets     []string        `json:"facets,omitempty"`
+	Messages   []string        `json:"messages,omitempty"`
+	TimeWindow *NRQLTimeWindow `json:"timeWindow,omitempty"`
+}
+
+// NRQLTimeWindow is the time range a query ran over, in milliseconds since
+// the epoch.
+type NRQLTimeWindow struct {
+	Begin *int64 `json:"begin,omitempty"`
+	End   *int64 `json:"end,omitempty"`
+}
+
+const nrqlQueryString = `query($accountId: Int!, $nrql: Nrql!) {
+  actor {
+    account(id: $accountId) {
+      nrql(query: $nrql) {
+        results
+        totalResult
+        metadata { eventTypes facets messages timeWindow { begin end } }
+      }
+    }
+  }
+}`
+
+type nrqlData struct {
+	Actor struct {
+		Account struct {
+			NRQL *NRQLResult `json:"nrql"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+// Query runs the NRQL query in the account accountID.
+func (s *NRQLService) Query(ctx context.Context, accountID int64, query string) (*NRQLResult, *Response, error) {
+	variables := map[string]interface{}{"accountId": accountID, "nrql": query}
+	data := new(nrqlData)
+	resp, err := s.client.GraphQL.Query(ctx, nrqlQueryString, variables, data)
+	if err != nil {
+		return nil, resp, fmt.Errorf("Failed to run the NRQL query: %w", err)
+	}
+	if data.Actor.Account.NRQL == nil {
+		return nil, resp, fmt.Errorf("Failed to run the NRQL query: no result")
+	}
+	return data.Actor.Account.NRQL, resp, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNRQLQuery(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["accountId"] != float64(1234567) || body.Variables["nrql"] != "SELECT count(*) FROM Transaction FACET appName SINCE 1 hour ago" {
+			t.Errorf("got variables %v", body.Variables)
+		}
+		// Answer of the nrql field in the NerdGraph NRQL tutorial.
+		fmt.Fprint(w, `{
+  "data": {
+    "actor": {
+      "account": {
+        "nrql": {
+          "results": [
+            {"appName": "web", "count": 1024, "facet": "web"},
+            {"appName": "api", "count": 80, "facet": "api"}
+          ],
+          "totalResult": {"count": 1104},
+          "metadata": {
+            "eventTypes": ["Transaction"],
+            "facets": ["appName"],
+            "messages": [],
+            "timeWindow": {"begin": 1528794000000, "end": 1528797600000}
+          }
+        }
+      }
+    }
+  }
+}`)
+	})
+
+	result, _, err := client.NRQL.Query(context.Background(), 1234567, "SELECT count(*) FROM Transaction FACET appName SINCE 1 hour ago")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(result.Results) != 2 || string(result.TotalResult) != `{"count": 1104}` {
+		t.Errorf("got results %s, total %s", result.Results, result.TotalResult)
+	}
+	if m := result.Metadata; m.Facets[0] != "appName" || m.EventTypes[0] != "Transaction" || *m.TimeWindow.End != 1528797600000 {
+		t.Errorf("got metadata %+v", m)
+	}
+}
+
+func TestNRQLQueryError(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"actor":{"account":{"nrql":null}}},
+			"errors":[{"message":"NRQL Syntax Error: Error at line 1 position 8, unexpected 'FORM'","path":["actor","account","nrql"],"extensions":{"errorClass":"INVALID_INPUT"}}]}`)
+	})
+
+	_, _, err := client.NRQL.Query(context.Background(), 1234567, "SELECT * FORM Transaction")
+	var graphQLErrs GraphQLErrors
+	if !errors.As(err, &graphQLErrs) || graphQLErrs[0].Class() != "INVALID_INPUT" {
+		t.Errorf("got %v, want the GraphQL error", err)
+	}
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// entity is an entity of the fake NerdGraph, built from a stored object.
+type entity struct {
+	obj object
+	// tagsKey is the key of its tags in Server.tags: the monitor ID for
+	// monitors, the GUID for the others.
+	tagsKey string
+	// seededTags are the tags of a seeded entity, until they are changed.
+	seededTags map[string][]string
+}
+
+// entities returns the entities of the fake NerdGraph: the monitors, the APM
+// applications and the objects of the "entities" collection, seeded with
+// their guid, name, type, domain and tags as a list of keys and values.
+func (s *Server) entities() []entity {
+	var entities []entity
+	for _, rec := range s.collections["monitors"] {
+		id := toString(rec.obj["id"])
+		entities = append(entities, entity{tagsKey: id, obj: object{
+			"guid": entityGUID(id), "name": rec.obj["name"], "type": "MONITOR",
+			"entityType": "SYNTHETIC_MONITOR_ENTITY", "domain": "SYNTH",
+		}})
+	}
+	for _, rec := range s.collections["applications"] {
+		guid := base64.RawStdEncoding.EncodeToString([]byte(strconv.FormatInt(s.AccountID, 10) + "|APM|APPLICATION|" + toString(rec.obj["id"])))
+		entities = append(entities, entity{tagsKey: guid, obj: object{
+			"guid": guid, "name": rec.obj["name"], "type": "APPLICATION",
+			"entityType": "APM_APPLICATION_ENTITY", "domain": "APM", "reporting": rec.obj["reporting"],
+		}})
+	}
+	for _, rec := range s.collections["entities"] {
+		obj := object{}
+		for key, value := range rec.obj {
+			if key != "id" && key != "tags" {
+				obj[key] = value
+			}
+		}
+		seeded := map[string][]string{}
+		tags, _ := rec.obj["tags"].([]interface{})
+		for _, tag := range tags {
+			t, _ := tag.(object)
+			values, _ := t["values"].([]interface{})
+			for _, value := range values {
+				seeded[toString(t["key"])] = append(seeded[toString(t["key"])], toString(value))
+			}
+		}
+		entities = append(entities, entity{tagsKey: toString(obj["guid"]), obj: obj, seededTags: seeded})
+	}
+	for _, e := range entities {
+		e.obj["accountId"] = s.AccountID
+		e.obj["tags"] = tagObjects(s.entityTags(e))
+	}
+	return entities
+}
+
+func (s *Server) entityTags(e entity) map[string][]string {
+	if tags, ok := s.tags[e.tagsKey]; ok {
+		return tags
+	}
+	return e.seededTags
+}
+
+// tagObjects returns tags as NerdGraph lists them, sorted by key.
+func tagObjects(tags map[string][]string) []object {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	objs := []object{}
+	for _, key := range keys {
+		objs = append(objs, object{"key": key, "values": tags[key]})
+	}
+	return objs
+}
+
+var (
+	searchAnd       = regexp.MustCompile(`(?i)\s+AND\s+`)
+	searchCondition = regexp.MustCompile("^\\(*\\s*(name|type|domain|guid|tags\\.(?:`[^`]+`|[\\w.-]+))\\s+(=|LIKE)\\s+'((?:[^'\\\\]|\\\\.)*)'\\s*\\)*$")
+)
+
+// searchTerm is one condition of an entity search query.
+type searchTerm struct {
+	field string
+	like  bool
+	value string
+}
+
+// parseEntitySearch parses the subset of entity search queries the fake
+// answers: conditions like name = 'x', name LIKE 'x', type, domain, guid or
+// tags.key compared to a value, joined by AND.
+func parseEntitySearch(query string) ([]searchTerm, bool) {
+	var terms []searchTerm
+	if strings.TrimSpace(query) == "" {
+		return terms, true
+	}
+	for _, part := range searchAnd.Split(strings.TrimSpace(query), -1) {
+		m := searchCondition.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, false
+		}
+		field := strings.Replace(m[1], "`", "", -1)
+		value := strings.Replace(m[3], `\'`, "'", -1)
+		terms = append(terms, searchTerm{field: field, like: strings.EqualFold(m[2], "LIKE"), value: value})
+	}
+	return terms, true
+}
+
+func (t searchTerm) matches(e entity, tags map[string][]string) bool {
+	var texts []string
+	if strings.HasPrefix(t.field, "tags.") {
+		texts = tags[strings.TrimPrefix(t.field, "tags.")]
+	} else {
+		texts = []string{toString(e.obj[t.field])}
+	}
+	for _, text := range texts {
+		if t.like && strings.Contains(strings.ToLower(text), strings.ToLower(t.value)) {
+			return true
+		}
+		if !t.like && strings.EqualFold(text, t.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchEntities answers an entity search by query with the matching
+// entities, by pages of s.PageSize. The cursor is the offset of the page.
+func (s *Server) searchEntities(w http.ResponseWriter, req graphqlRequest) {
+	terms, ok := parseEntitySearch(toString(req.Variables["query"]))
+	if !ok {
+		writeGraphQLError(w, "Entity search query not supported by the fake server")
+		return
+	}
+	var matching []object
+	for _, e := range s.entities() {
+		tags := s.entityTags(e)
+		matches := true
+		for _, term := range terms {
+			matches = matches && term.matches(e, tags)
+		}
+		if matches {
+			matching = append(matching, e.obj)
+		}
+	}
+
+	offset, _ := strconv.Atoi(toString(req.Variables["cursor"]))
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	entities := []object{}
+	for i := offset; i < len(matching) && i < offset+size; i++ {
+		entities = append(entities, matching[i])
+	}
+	var nextCursor interface{}
+	if offset+size < len(matching) {
+		nextCursor = strconv.Itoa(offset + size)
+	}
+
+	writeJSON(w, http.StatusOK, object{"data": object{"actor": object{"entitySearch": object{
+		"count": len(matching),
+		"results": object{
+			"entities":   entities,
+			"nextCursor": nextCursor,
+		},
+	}}}})
+}
+
+func (s *Server) findEntity(guid string) *entity {
+	for _, e := range s.entities() {
+		if toString(e.obj["guid"]) == guid {
+			return &e
+		}
+	}
+	return nil
+}
+
+// getEntity answers the lookup of an entity by GUID, with a null entity if
+// there is none.
+func (s *Server) getEntity(w http.ResponseWriter, req graphqlRequest) {
+	var found interface{}
+	if e := s.findEntity(toString(req.Variables["guid"])); e != nil {
+		found = e.obj
+	}
+	writeJSON(w, http.StatusOK, object{"data": object{"actor": object{"entity": found}}})
+}
+
+// taggingMutations are the tagging mutations the fake answers.
+var taggingMutations = []string{
+	"taggingAddTagsToEntity",
+	"taggingReplaceTagsOnEntity",
+	"taggingDeleteTagFromEntity",
+	"taggingDeleteTagValuesFromEntity",
+}
+
+// tagEntity runs a tagging mutation on the tags of the entity of the guid
+// variable. Failures are answered as the errors of the mutation, like
+// NerdGraph does.
+func (s *Server) tagEntity(w http.ResponseWriter, req graphqlRequest, mutation string) {
+	answer := func(errors []object) {
+		writeJSON(w, http.StatusOK, object{"data": object{mutation: object{"errors": errors}}})
+	}
+	e := s.findEntity(toString(req.Variables["guid"]))
+	if e == nil {
+		answer([]object{{"message": "Entity not found", "type": "NOT_FOUND"}})
+		return
+	}
+
+	tags := map[string][]string{}
+	if mutation != "taggingReplaceTagsOnEntity" {
+		for key, values := range s.entityTags(*e) {
+			tags[key] = append([]string(nil), values...)
+		}
+	}
+	switch mutation {
+	case "taggingAddTagsToEntity", "taggingReplaceTagsOnEntity":
+		inputs, _ := req.Variables["tags"].([]interface{})
+		for _, input := range inputs {
+			tag, _ := input.(map[string]interface{})
+			key := toString(tag["key"])
+			if key == "" {
+				answer([]object{{"message": "Tag keys can't be empty", "type": "INVALID_KEY"}})
+				return
+			}
+			values, _ := tag["values"].([]interface{})
+			for _, value := range values {
+				if !hasString(tags[key], toString(value)) {
+					tags[key] = append(tags[key], toString(value))
+				}
+			}
+		}
+	case "taggingDeleteTagFromEntity":
+		keys, _ := req.Variables["tagKeys"].([]interface{})
+		for _, key := range keys {
+			delete(tags, toString(key))
+		}
+	case "taggingDeleteTagValuesFromEntity":
+		inputs, _ := req.Variables["tagValues"].([]interface{})
+		for _, input := range inputs {
+			tagValue, _ := input.(map[string]interface{})
+			key := toString(tagValue["key"])
+			var kept []string
+			for _, value := range tags[key] {
+				if value != toString(tagValue["value"]) {
+					kept = append(kept, value)
+				}
+			}
+			if len(kept) == 0 {
+				delete(tags, key)
+			} else {
+				tags[key] = kept
+			}
+		}
+	}
+	s.tags[e.tagsKey] = tags
+	answer([]object{})
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// writeGraphQLError answers with a GraphQL error, which NerdGraph sends
+// with status 200.
+func writeGraphQLError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusOK, object{"errors": []object{{"message": message}}})
+}
+
+// serveGraphQL serves NerdGraph. Queries are not parsed, only the entity
+// searches and tagging mutations, the accounts list and the NRQL queries the
+// CLI sends are answered, and the other queries seeded with their answer as described by
+// answerSeededQuery.
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if strings.ToUpper(r.Method) != "POST" {
+		writeGraphQLError(w, "Only POST is supported")
+		return
+	}
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, object{"errors": []object{{"message": "Invalid JSON: " + err.Error()}}})
+		return
+	}
+
+	for _, mutation := range taggingMutations {
+		if strings.Contains(req.Query, mutation) {
+			s.tagEntity(w, req, mutation)
+			return
+		}
+	}
+	switch {
+	case strings.Contains(req.Query, "entity(guid:"):
+		s.getEntity(w, req)
+	case strings.Contains(req.Query, "entitySearch(query:"):
+		s.searchEntities(w, req)
+	case strings.Contains(req.Query, "entitySearch"):
+		s.searchMonitorEntities(w, req)
+	case strings.Contains(req.Query, "nrql("):
+		s.queryNRQL(w, req)
+	case strings.Contains(req.Query, "accounts"):
+		writeJSON(w, http.StatusOK, object{"data": object{"actor": object{"accounts": []object{
+			{"id": s.AccountID, "name": "Account " + strconv.FormatInt(s.AccountID, 10)},
+		}}}})
+	default:
+		s.answerSeededQuery(w, req)
+	}
+}
+
+// answerSeededQuery answers a query seeded in the "graphql" collection, as
+// an object with the query, its data and optionally errors. If the object
+// has variables, they must all match the ones of the request. Queries are
+// compared ignoring spaces.
+func (s *Server) answerSeededQuery(w http.ResponseWriter, req graphqlRequest) {
+	query := strings.Join(strings.Fields(req.Query), " ")
+	for _, rec := range s.collections["graphql"] {
+		if strings.Join(strings.Fields(toString(rec.obj["query"])), " ") != query {
+			continue
+		}
+		variables, _ := rec.obj["variables"].(map[string]interface{})
+		matches := true
+		for name, value := range variables {
+			if toString(req.Variables[name]) != toString(value) {
+				matches = false
+			}
+		}
+		if !matches {
+			continue
+		}
+		answer := object{"data": rec.obj["data"]}
+		if rec.obj["errors"] != nil {
+			answer["errors"] = rec.obj["errors"]
+		}
+		writeJSON(w, http.StatusOK, answer)
+		return
+	}
+	writeGraphQLError(w, "Query not supported by the fake server")
+}
+
+// searchMonitorEntities answers an entity search with the monitors and
+// their tags, by pages of s.PageSize. The cursor is the offset of the page.
+func (s *Server) searchMonitorEntities(w http.ResponseWriter, req graphqlRequest) {
+	offset, _ := strconv.Atoi(toString(req.Variables["CURSOR"]))
+	monitors := s.collections["monitors"]
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+
+	entities := []object{}
+	for _, rec := range window(monitors, size, offset) {
+		entities = append(entities, s.monitorEntity(rec.obj))
+	}
+	var nextCursor interface{}
+	if offset+size < len(monitors) {
+		nextCursor = strconv.Itoa(offset + size)
+	}
+
+	writeJSON(w, http.StatusOK, object{"data": object{"actor": object{"entitySearch": object{
+		"count": len(monitors),
+		"results": object{
+			"entities":   entities,
+			"nextCursor": nextCursor,
+		},
+	}}}})
+}
+
+func (s *Server) monitorEntity(monitor object) object {
+	id := toString(monitor["id"])
+	return object{
+		"guid":      entityGUID(id),
+		"name":      monitor["name"],
+		"monitorId": id,
+		"tags":      tagObjects(s.tags[id]),
+	}
+}
+
+// entityGUID returns a GUID in the format of NewRelic entity GUIDs for the
+// monitor with ID id.
+func entityGUID(id string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte("1|SYNTH|MONITOR|" + id))
+}
+
+// queryNRQL answers a NRQL query with the result set seeded for it in the
+// "nrql" collection, as an object with the query and its results, and
+// optionally its totalResult and metadata. Queries are compared case
+// insensitively, ignoring spaces.
+func (s *Server) queryNRQL(w http.ResponseWriter, req graphqlRequest) {
+	if toString(req.Variables["accountId"]) != strconv.FormatInt(s.AccountID, 10) {
+		writeGraphQLError(w, "Not authorized to access account "+toString(req.Variables["accountId"]))
+		return
+	}
+	query := normalizeNRQL(toString(req.Variables["nrql"]))
+	for _, rec := range s.collections["nrql"] {
+		if normalizeNRQL(toString(rec.obj["query"])) != query {
+			continue
+		}
+		result := object{"results": rec.obj["results"], "totalResult": rec.obj["totalResult"], "metadata": rec.obj["metadata"]}
+		if result["results"] == nil {
+			result["results"] = []object{}
+		}
+		writeJSON(w, http.StatusOK, object{"data": object{"actor": object{"account": object{"nrql": result}}}})
+		return
+	}
+	writeGraphQLError(w, "NRQL query not seeded in the fake server")
+}
+
+func normalizeNRQL(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// writeInfraError answers with an error in the format of the
+// Infrastructure alerts API.
+func writeInfraError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, object{"errors": []object{{"status": fmt.Sprint(status), "detail": detail}}})
+}
+
+// serveInfrastructure serves the Infrastructure alerts API paths conditions
+// and conditions/{id}.
+func (s *Server) serveInfrastructure(w http.ResponseWriter, r *http.Request, path string) {
+	const collection = "infrastructure_conditions"
+	segments := strings.Split(path, "/")
+	if segments[0] != "conditions" || len(segments) > 2 {
+		writeInfraError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	method := strings.ToUpper(r.Method)
+
+	if len(segments) == 1 {
+		switch method {
+		case "GET":
+			query := r.URL.Query()
+			policyID := query.Get("policy_id")
+			if policyID == "" {
+				writeInfraError(w, http.StatusBadRequest, "policy_id is required")
+				return
+			}
+			var matching []*record
+			for _, rec := range s.collections[collection] {
+				if rec.policyID == policyID {
+					matching = append(matching, rec)
+				}
+			}
+			limit, offset := s.limitOffset(query)
+			writeJSON(w, http.StatusOK, object{
+				"data":  objects(window(matching, limit, offset)),
+				"meta":  object{"limit": limit, "offset": offset, "total": len(matching)},
+				"links": object{},
+			})
+		case "POST":
+			body, err := decodeBody(r)
+			if err != nil {
+				writeInfraError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+				return
+			}
+			condition, ok := body["data"].(object)
+			if !ok || toString(condition["name"]) == "" || toString(condition["type"]) == "" {
+				writeInfraError(w, http.StatusBadRequest, "data.name and data.type are required")
+				return
+			}
+			policyID := toString(condition["policy_id"])
+			if _, policy := s.find("alerts_policies", policyID); policy == nil {
+				writeInfraError(w, http.StatusNotFound, "Policy not found")
+				return
+			}
+			now := json.Number(fmt.Sprint(time.Now().UnixNano() / int64(time.Millisecond)))
+			condition["id"] = s.newID(collection)
+			condition["created_at_epoch_millis"] = now
+			condition["updated_at_epoch_millis"] = now
+			s.collections[collection] = append(s.collections[collection], &record{policyID: policyID, obj: condition})
+			writeJSON(w, http.StatusCreated, object{"data": condition})
+		default:
+			writeInfraError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	index, rec := s.find(collection, segments[1])
+	if rec == nil {
+		writeInfraError(w, http.StatusNotFound, "Condition not found")
+		return
+	}
+	switch method {
+	case "GET":
+		writeJSON(w, http.StatusOK, object{"data": rec.obj})
+	case "PUT":
+		body, err := decodeBody(r)
+		if err != nil {
+			writeInfraError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		condition, ok := body["data"].(object)
+		if !ok {
+			writeInfraError(w, http.StatusBadRequest, "data is required")
+			return
+		}
+		merge(rec.obj, condition)
+		rec.obj["updated_at_epoch_millis"] = json.Number(fmt.Sprint(time.Now().UnixNano() / int64(time.Millisecond)))
+		writeJSON(w, http.StatusOK, object{"data": rec.obj})
+	case "DELETE":
+		s.remove(collection, index)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeInfraError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
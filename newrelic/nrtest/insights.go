@@ -0,0 +1,60 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// serveInsights serves the Insights insert API path {account id}/events,
+// which takes one custom event or an array of them.
+func (s *Server) serveInsights(w http.ResponseWriter, r *http.Request, path string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != 2 || segments[1] != "events" || strings.ToUpper(r.Method) != "POST" {
+		writeJSON(w, http.StatusNotFound, object{"success": false, "error": "Not found"})
+		return
+	}
+
+	var raw json.RawMessage
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		writeJSON(w, http.StatusBadRequest, object{"success": false, "error": "Invalid JSON: " + err.Error()})
+		return
+	}
+	var events []object
+	if err := json.Unmarshal(raw, &events); err != nil {
+		event, err := decodeObject(raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, object{"success": false, "error": "Events must be JSON objects"})
+			return
+		}
+		events = []object{event}
+	}
+	for _, event := range events {
+		if toString(event["eventType"]) == "" {
+			writeJSON(w, http.StatusBadRequest, object{"success": false, "error": "eventType is required"})
+			return
+		}
+	}
+
+	s.events[segments[0]] = append(s.events[segments[0]], events...)
+	s.nextID++
+	writeJSON(w, http.StatusOK, object{"success": true, "uuid": fmt.Sprintf("00000000-0000-4000-9000-%012d", s.nextID)})
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxTimeslices bounds the timeslices of one metric, like the real API
+// does by raising the period.
+const maxTimeslices = 1000
+
+// serveMetrics serves the metric names and data of the application
+// applicationID, or of its host hostID if it is set: path is metrics or
+// metrics/data. The metrics are seeded in the "metrics" collection with their
+// name, the names of their values, links.application and a data object
+// holding the constant value of each of them.
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request, applicationID string, hostID string, path string) {
+	if _, app := s.find("applications", applicationID); app == nil {
+		writeError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	if hostID != "" {
+		_, host := s.find("application_hosts", hostID)
+		var links object
+		if host != nil {
+			links, _ = host.obj["links"].(object)
+		}
+		if toString(links["application"]) != applicationID {
+			writeError(w, http.StatusNotFound, "Application host not found")
+			return
+		}
+	}
+	if strings.ToUpper(r.Method) != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var metrics []*record
+	for _, rec := range s.collections["metrics"] {
+		if links, _ := rec.obj["links"].(object); toString(links["application"]) == applicationID {
+			metrics = append(metrics, rec)
+		}
+	}
+	switch path {
+	case "metrics":
+		s.listMetricNames(w, r, metrics)
+	case "metrics/data":
+		s.writeMetricData(w, r, metrics)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) listMetricNames(w http.ResponseWriter, r *http.Request, metrics []*record) {
+	query := r.URL.Query()
+	var matching []*record
+	for _, rec := range metrics {
+		if strings.Contains(toString(rec.obj["name"]), query.Get("name")) {
+			matching = append(matching, &record{obj: object{"name": rec.obj["name"], "values": rec.obj["values"]}})
+		}
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil {
+		page = p
+	}
+	items, pages := s.page(matching, page)
+	setLinkHeader(w, r, page, pages)
+	writeJSON(w, http.StatusOK, object{"metrics": objects(items)})
+}
+
+func (s *Server) writeMetricData(w http.ResponseWriter, r *http.Request, metrics []*record) {
+	query := r.URL.Query()
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-30 * time.Minute)
+	for _, bound := range []struct {
+		param string
+		t     *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if value := query.Get(bound.param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, "Invalid "+bound.param)
+				return
+			}
+			*bound.t = t.UTC()
+		}
+	}
+	period := 60 * time.Second
+	if p, err := strconv.Atoi(query.Get("period")); err == nil && p > 0 {
+		period = time.Duration(p) * time.Second
+	}
+	if to.Sub(from) > period*maxTimeslices {
+		period = to.Sub(from) / maxTimeslices
+	}
+	summarize := query.Get("summarize") == "true"
+
+	found, notFound, data := []interface{}{}, []interface{}{}, []object{}
+	for _, name := range query["names[]"] {
+		var metric *record
+		for _, rec := range metrics {
+			if toString(rec.obj["name"]) == name {
+				metric = rec
+			}
+		}
+		if metric == nil {
+			notFound = append(notFound, name)
+			continue
+		}
+		found = append(found, name)
+
+		// Without values[], all the values of the metric are returned.
+		valueNames := query["values[]"]
+		if len(valueNames) == 0 {
+			all, _ := metric.obj["values"].([]interface{})
+			for _, value := range all {
+				valueNames = append(valueNames, toString(value))
+			}
+		}
+		constants, _ := metric.obj["data"].(object)
+		values := object{}
+		for _, value := range valueNames {
+			values[value] = constants[value]
+			if values[value] == nil {
+				values[value] = 0
+			}
+		}
+		var timeslices []object
+		for start := from; start.Before(to); start = start.Add(period) {
+			end := start.Add(period)
+			if summarize || end.After(to) {
+				end = to
+			}
+			timeslices = append(timeslices, object{"from": start.Format(time.RFC3339), "to": end.Format(time.RFC3339), "values": values})
+			if summarize {
+				break
+			}
+		}
+		data = append(data, object{"name": name, "timeslices": timeslices})
+	}
+
+	writeJSON(w, http.StatusOK, object{"metric_data": object{
+		"from":              from.Format(time.RFC3339),
+		"to":                to.Format(time.RFC3339),
+		"metrics_not_found": notFound,
+		"metrics_found":     found,
+		"metrics":           data,
+	}})
+}
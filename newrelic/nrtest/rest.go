@@ -0,0 +1,501 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// restCollection describes a REST API v2 collection.
+type restCollection struct {
+	// list and single are the keys wrapping a list of objects and one
+	// object in requests and responses.
+	list   string
+	single string
+	// byPolicy collections are listed and created for an alert policy.
+	byPolicy bool
+	// readOnly collections are only listed, their objects are seeded.
+	readOnly bool
+}
+
+var restCollections = map[string]restCollection{
+	"users":                              {list: "users", single: "user", readOnly: true},
+	"alerts_policies":                    {list: "policies", single: "policy"},
+	"alerts_channels":                    {list: "channels", single: "channel"},
+	"alerts_conditions":                  {list: "conditions", single: "condition", byPolicy: true},
+	"alerts_plugins_conditions":          {list: "plugins_conditions", single: "plugins_condition", byPolicy: true},
+	"alerts_external_service_conditions": {list: "external_service_conditions", single: "external_service_condition", byPolicy: true},
+	"alerts_synthetics_conditions":       {list: "synthetics_conditions", single: "synthetics_condition", byPolicy: true},
+	"alerts_nrql_conditions":             {list: "nrql_conditions", single: "nrql_condition", byPolicy: true},
+	"alerts_location_failure_conditions": {list: "location_failure_conditions", single: "location_failure_condition", byPolicy: true},
+	"labels":                             {list: "labels", single: "label"},
+	"dashboards":                         {list: "dashboards", single: "dashboard"},
+	"alerts_incidents":                   {list: "incidents", single: "incident", readOnly: true},
+	"alerts_violations":                  {list: "violations", single: "violation", readOnly: true},
+	"alerts_events":                      {list: "recent_events", single: "recent_event", readOnly: true},
+	"applications":                       {list: "applications", single: "application", readOnly: true},
+	"application_hosts":                  {list: "application_hosts", single: "application_host", readOnly: true},
+	"application_instances":              {list: "application_instances", single: "application_instance", readOnly: true},
+	"deployments":                        {list: "deployments", single: "deployment", readOnly: true},
+}
+
+// applicationLists are the lists of objects of an application, served as
+// applications/{id}/{list}.json and seeded with links.application set.
+var applicationLists = map[string]string{
+	"hosts":       "application_hosts",
+	"instances":   "application_instances",
+	"deployments": "deployments",
+}
+
+// serveREST serves the REST API v2 paths, like alerts_policies.json,
+// alerts_policies/1.json or alerts_conditions/policies/1.json.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request, path string) {
+	if path == "alerts_policy_channels.json" {
+		s.updatePolicyChannels(w, r)
+		return
+	}
+
+	segments := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+	name := segments[0]
+	c, ok := restCollections[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	method := strings.ToUpper(r.Method)
+	switch {
+	case len(segments) == 1 && method == "GET":
+		policyID := r.URL.Query().Get("policy_id")
+		if c.byPolicy && policyID == "" {
+			writeError(w, http.StatusUnprocessableEntity, "policy_id is required")
+			return
+		}
+		s.listREST(w, r, name, c, policyID)
+	case len(segments) == 1 && (method == "POST" || (method == "PUT" && name == "labels")) && !c.readOnly && !c.byPolicy:
+		s.createREST(w, r, name, c, "")
+	case len(segments) == 3 && segments[1] == "policies" && c.byPolicy && method == "GET":
+		s.listREST(w, r, name, c, segments[2])
+	case len(segments) == 3 && segments[1] == "policies" && c.byPolicy && method == "POST":
+		if _, policy := s.find("alerts_policies", segments[2]); policy == nil {
+			writeError(w, http.StatusNotFound, "Policy not found")
+			return
+		}
+		s.createREST(w, r, name, c, segments[2])
+	case len(segments) == 2:
+		s.serveRESTObject(w, r, name, c, segments[1])
+	case len(segments) >= 3 && name == "applications" && segments[2] == "metrics":
+		s.serveMetrics(w, r, segments[1], "", strings.Join(segments[2:], "/"))
+	case len(segments) >= 5 && name == "applications" && segments[2] == "hosts" && segments[4] == "metrics":
+		s.serveMetrics(w, r, segments[1], segments[3], strings.Join(segments[4:], "/"))
+	case len(segments) >= 3 && name == "applications" && applicationLists[segments[2]] != "":
+		s.serveApplicationObjects(w, r, segments[1], applicationLists[segments[2]], segments[3:])
+	case len(segments) == 3 && name == "alerts_incidents" && method == "PUT":
+		s.updateIncident(w, segments[1], segments[2])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) listREST(w http.ResponseWriter, r *http.Request, name string, c restCollection, policyID string) {
+	query := r.URL.Query()
+	var matching []*record
+	for _, rec := range s.collections[name] {
+		if c.byPolicy && rec.policyID != policyID {
+			continue
+		}
+		if !matchFilters(rec.obj, query) {
+			continue
+		}
+		if query.Get("only_open") == "true" && rec.obj["closed_at"] != nil {
+			continue
+		}
+		if !matchDates(rec.obj, query) {
+			continue
+		}
+		matching = append(matching, rec)
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil {
+		page = p
+	}
+	items, pages := s.page(matching, page)
+	setLinkHeader(w, r, page, pages)
+	writeJSON(w, http.StatusOK, object{c.list: objects(items)})
+}
+
+// serveApplicationObjects serves the objects of collection, like
+// application_hosts, linked to the application applicationID: their list
+// and, for deployments, creating and deleting one.
+func (s *Server) serveApplicationObjects(w http.ResponseWriter, r *http.Request, applicationID string, collection string, id []string) {
+	if _, app := s.find("applications", applicationID); app == nil {
+		writeError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	c := restCollections[collection]
+	method := strings.ToUpper(r.Method)
+	switch {
+	case len(id) == 0 && method == "GET":
+		s.listApplicationObjects(w, r, applicationID, collection)
+	case len(id) == 0 && method == "POST" && collection == "deployments":
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		obj, ok := body[c.single].(object)
+		if !ok || toString(obj["revision"]) == "" {
+			writeError(w, http.StatusUnprocessableEntity, "Revision can't be blank")
+			return
+		}
+		obj["id"] = s.newID(collection)
+		obj["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+		obj["links"] = object{"application": toNumber(applicationID)}
+		// Like the real API, the latest deployment is listed first.
+		s.collections[collection] = append([]*record{{obj: obj}}, s.collections[collection]...)
+		writeJSON(w, http.StatusCreated, object{c.single: obj})
+	case len(id) == 1 && method == "DELETE" && collection == "deployments":
+		index, rec := s.find(collection, id[0])
+		var links object
+		if rec != nil {
+			links, _ = rec.obj["links"].(object)
+		}
+		if rec == nil || toString(links["application"]) != applicationID {
+			writeError(w, http.StatusNotFound, "No deployment found with id "+id[0])
+			return
+		}
+		s.remove(collection, index)
+		writeJSON(w, http.StatusOK, object{c.single: rec.obj})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// listApplicationObjects lists the objects of collection linked to the
+// application applicationID.
+func (s *Server) listApplicationObjects(w http.ResponseWriter, r *http.Request, applicationID string, collection string) {
+	query := r.URL.Query()
+	var matching []*record
+	for _, rec := range s.collections[collection] {
+		links, _ := rec.obj["links"].(object)
+		if toString(links["application"]) != applicationID || !matchFilters(rec.obj, query) {
+			continue
+		}
+		matching = append(matching, rec)
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil {
+		page = p
+	}
+	items, pages := s.page(matching, page)
+	setLinkHeader(w, r, page, pages)
+	writeJSON(w, http.StatusOK, object{restCollections[collection].list: objects(items)})
+}
+
+// matchFilters reports whether obj matches the filter[field] parameters of
+// query. filter[ids] is a comma separated list of IDs, any other filter must
+// be equal to the field.
+func matchFilters(obj object, query url.Values) bool {
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+		field := key[len("filter[") : len(key)-1]
+		if field == "hostname" {
+			// The hosts of an application are filtered by hostname.
+			field = "host"
+		}
+		if field == "ids" {
+			var found bool
+			for _, id := range strings.Split(values[0], ",") {
+				found = found || strings.TrimSpace(id) == toString(obj["id"])
+			}
+			if !found {
+				return false
+			}
+			continue
+		}
+		if toString(obj[field]) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// setLinkHeader sets the Link header the REST API v2 sends for lists of more
+// than one page.
+func setLinkHeader(w http.ResponseWriter, r *http.Request, page int, pages int) {
+	if pages <= 1 {
+		return
+	}
+	link := func(p int, rel string) string {
+		u := *r.URL
+		u.Scheme = "http"
+		u.Host = r.Host
+		query := u.Query()
+		query.Set("page", strconv.Itoa(p))
+		u.RawQuery = query.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+
+	var links []string
+	if page < pages {
+		links = append(links, link(page+1, "next"), link(pages, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(page-1, "prev"))
+	}
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+func (s *Server) createREST(w http.ResponseWriter, r *http.Request, name string, c restCollection, policyID string) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	obj, ok := body[c.single].(object)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s is required", c.single))
+		return
+	}
+
+	if name == "labels" {
+		s.createLabel(w, obj)
+		return
+	}
+	if toString(obj["name"]) == "" && name != "dashboards" {
+		writeError(w, http.StatusUnprocessableEntity, "Name can't be blank")
+		return
+	}
+	if name == "dashboards" && toString(obj["title"]) == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Title can't be blank")
+		return
+	}
+
+	obj["id"] = s.newID(name)
+	if name == "alerts_channels" {
+		obj["links"] = object{"policy_ids": []interface{}{}}
+	}
+	s.collections[name] = append(s.collections[name], &record{policyID: policyID, obj: obj})
+
+	if name == "alerts_channels" {
+		// Like the real API, channels are created as a list.
+		writeJSON(w, http.StatusCreated, object{c.list: []object{obj}})
+		return
+	}
+	writeJSON(w, http.StatusCreated, object{c.single: obj})
+}
+
+// createLabel creates a label, or returns the existing one with the same
+// category and name.
+func (s *Server) createLabel(w http.ResponseWriter, label object) {
+	key := toString(label["category"]) + ":" + toString(label["name"])
+	if key == ":" || strings.HasPrefix(key, ":") || strings.HasSuffix(key, ":") {
+		writeError(w, http.StatusUnprocessableEntity, "Category and name are required")
+		return
+	}
+	if _, rec := s.find("labels", key); rec != nil {
+		// Like the real API, the links are added to the existing ones.
+		links, ok := rec.obj["links"].(object)
+		if !ok {
+			links = object{}
+			rec.obj["links"] = links
+		}
+		added, _ := label["links"].(object)
+		for _, field := range []string{"applications", "servers"} {
+			ids, _ := links[field].([]interface{})
+			newIDs, _ := added[field].([]interface{})
+			for _, id := range newIDs {
+				if !containsString(ids, toString(id)) {
+					ids = append(ids, id)
+				}
+			}
+			links[field] = ids
+		}
+		writeJSON(w, http.StatusOK, object{"label": rec.obj})
+		return
+	}
+
+	label["key"] = key
+	if _, ok := label["links"]; !ok {
+		label["links"] = object{"applications": []interface{}{}, "servers": []interface{}{}}
+	}
+	s.collections["labels"] = append(s.collections["labels"], &record{obj: label})
+	writeJSON(w, http.StatusOK, object{"label": label})
+}
+
+func (s *Server) serveRESTObject(w http.ResponseWriter, r *http.Request, name string, c restCollection, id string) {
+	index, rec := s.find(name, id)
+	if rec == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No %s found with id %s", strings.ReplaceAll(c.single, "_", " "), id))
+		return
+	}
+
+	switch strings.ToUpper(r.Method) {
+	case "GET":
+		writeJSON(w, http.StatusOK, object{c.single: rec.obj})
+	case "PUT":
+		if c.readOnly || name == "labels" {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		update, ok := body[c.single].(object)
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s is required", c.single))
+			return
+		}
+		merge(rec.obj, update)
+		writeJSON(w, http.StatusOK, object{c.single: rec.obj})
+	case "DELETE":
+		if c.readOnly {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		s.remove(name, index)
+		if name == "alerts_policies" {
+			s.removePolicyConditions(id)
+		}
+		writeJSON(w, http.StatusOK, object{c.single: rec.obj})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// matchDates reports whether obj was open between the start_date and
+// end_date parameters of query, if given.
+func matchDates(obj object, query url.Values) bool {
+	millis := func(field string) (int64, bool) {
+		n, err := strconv.ParseInt(toString(obj[field]), 10, 64)
+		return n, err == nil
+	}
+	if start, err := time.Parse(time.RFC3339, query.Get("start_date")); err == nil {
+		if closedAt, ok := millis("closed_at"); ok && closedAt < start.UnixNano()/int64(time.Millisecond) {
+			return false
+		}
+	}
+	if end, err := time.Parse(time.RFC3339, query.Get("end_date")); err == nil {
+		if openedAt, ok := millis("opened_at"); ok && openedAt > end.UnixNano()/int64(time.Millisecond) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateIncident serves PUT alerts_incidents/{id}/acknowledge.json and
+// close.json. Closing an incident sets its closed_at.
+func (s *Server) updateIncident(w http.ResponseWriter, id string, action string) {
+	_, rec := s.find("alerts_incidents", id)
+	if rec == nil {
+		writeError(w, http.StatusNotFound, "No incident found with id "+id)
+		return
+	}
+	if action != "acknowledge" && action != "close" {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if rec.obj["closed_at"] != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Incident is already closed")
+		return
+	}
+	if action == "close" {
+		rec.obj["closed_at"] = json.Number(fmt.Sprint(time.Now().UnixNano() / int64(time.Millisecond)))
+	}
+	writeJSON(w, http.StatusOK, object{"incident": rec.obj})
+}
+
+// removePolicyConditions deletes the conditions of a deleted policy, like
+// NewRelic does.
+func (s *Server) removePolicyConditions(policyID string) {
+	for name, c := range restCollections {
+		if !c.byPolicy {
+			continue
+		}
+		var kept []*record
+		for _, rec := range s.collections[name] {
+			if rec.policyID != policyID {
+				kept = append(kept, rec)
+			}
+		}
+		s.collections[name] = kept
+	}
+
+	var kept []*record
+	for _, rec := range s.collections["infrastructure_conditions"] {
+		if rec.policyID != policyID {
+			kept = append(kept, rec)
+		}
+	}
+	s.collections["infrastructure_conditions"] = kept
+}
+
+// updatePolicyChannels serves PUT alerts_policy_channels.json, which adds
+// the channels in channel_ids to the policy policy_id.
+func (s *Server) updatePolicyChannels(w http.ResponseWriter, r *http.Request) {
+	if strings.ToUpper(r.Method) != "PUT" {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	policyID := query.Get("policy_id")
+	if _, policy := s.find("alerts_policies", policyID); policy == nil {
+		writeError(w, http.StatusNotFound, "Policy not found")
+		return
+	}
+
+	var channelIDs []interface{}
+	for _, id := range strings.Split(query.Get("channel_ids"), ",") {
+		_, channel := s.find("alerts_channels", strings.TrimSpace(id))
+		if channel == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Channel %s not found", id))
+			return
+		}
+		links, _ := channel.obj["links"].(object)
+		if links == nil {
+			links = object{}
+			channel.obj["links"] = links
+		}
+		policyIDs, _ := links["policy_ids"].([]interface{})
+		if !containsString(policyIDs, policyID) {
+			links["policy_ids"] = append(policyIDs, toNumber(policyID))
+		}
+		channelIDs = append(channelIDs, channel.obj["id"])
+	}
+
+	writeJSON(w, http.StatusOK, object{"policy": object{"id": toNumber(policyID), "channel_ids": channelIDs}})
+}
+
+func containsString(values []interface{}, s string) bool {
+	for _, v := range values {
+		if toString(v) == s {
+			return true
+		}
+	}
+	return false
+}
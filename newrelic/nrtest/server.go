@@ -0,0 +1,333 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package nrtest provides an in-memory fake of the NewRelic APIs used by the
+// CLI, to test the SDK, the commands and scripts built on them without a
+// NewRelic account or network.
+//
+// The fake serves all APIs from one host, under these paths:
+//
+//	/v2/                           REST API v2
+//	/synthetics/api/v3/monitors/   Synthetics API v3
+//	/synthetics/api/v4/monitors/   Synthetics API v4 (monitor labels)
+//	/infra/v2/alerts/              Infrastructure alerts API
+//	/v1/accounts/                  Insights insert API
+//	/graphql                       NerdGraph
+//
+// Endpoints returns them in the form expected by newrelic.NewClientWithEndpoints.
+package nrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+const (
+	apiPath             = "/v2/"
+	syntheticsPath      = "/synthetics/api/v3/monitors/"
+	labelSyntheticsPath = "/synthetics/api/v4/monitors/"
+	infrastructurePath  = "/infra/v2/alerts/"
+	insightsPath        = "/v1/accounts/"
+	graphqlPath         = "/graphql"
+)
+
+// DefaultPageSize is the number of items per page of the lists served by a
+// new Server.
+const DefaultPageSize = 50
+
+// DefaultAccountID is the ID of the account of a new Server.
+const DefaultAccountID = 1
+
+// object is one stored NewRelic object as decoded from JSON.
+type object = map[string]interface{}
+
+type record struct {
+	// policyID is the policy an alert condition belongs to, it is not part
+	// of the REST objects.
+	policyID string
+	obj      object
+}
+
+// Server is a fake of the NewRelic APIs keeping all objects in memory.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, if set, must be sent as X-Api-Key by every call but Insights
+	// inserts, which must send InsertKey if that is set.
+	APIKey    string
+	InsertKey string
+	// PageSize is the number of items per page of REST v2 lists, Synthetics
+	// lists and GraphQL results.
+	PageSize int
+	// AccountID is the ID of the only account the API key has access to.
+	AccountID int64
+
+	mu          sync.Mutex
+	nextID      int64
+	collections map[string][]*record
+	// scripts holds the scripts of the monitors, by monitor ID.
+	scripts map[string]string
+	// tags holds the tags of the entities, by monitor ID for the monitors
+	// and by GUID for the others.
+	tags map[string]map[string][]string
+	// events holds the inserted custom events, by account ID.
+	events map[string][]map[string]interface{}
+}
+
+// NewServer starts and returns a new Server on a local port. The caller
+// should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a new Server but doesn't start it, e.g. to
+// change its Listener. The caller should call Start, then Close.
+func NewUnstartedServer() *Server {
+	s := &Server{
+		PageSize:    DefaultPageSize,
+		AccountID:   DefaultAccountID,
+		nextID:      1000,
+		collections: make(map[string][]*record),
+		scripts:     make(map[string]string),
+		tags:        make(map[string]map[string][]string),
+		events:      make(map[string][]map[string]interface{}),
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// Endpoints returns the endpoints of the started Server.
+func (s *Server) Endpoints() newrelic.Endpoints {
+	return newrelic.Endpoints{
+		API:             s.URL + apiPath,
+		Synthetics:      s.URL + syntheticsPath,
+		LabelSynthetics: s.URL + labelSyntheticsPath,
+		Insights:        s.URL + insightsPath,
+		Infrastructure:  s.URL + infrastructurePath,
+		GraphQL:         s.URL + graphqlPath,
+	}
+}
+
+// Client returns a client of endpointType calling the Server.
+func (s *Server) Client(endpointType string) *newrelic.Client {
+	client := newrelic.NewClientWithEndpoints(nil, endpointType, s.Endpoints())
+	client.XApiKey = s.APIKey
+	return client
+}
+
+// Seed stores objects, like users or incidents, in the collection named by
+// the path of its REST v2 endpoint, e.g. "users" or "alerts_incidents".
+// Objects without an id get one. The other collections are:
+//
+//	monitors                   Synthetics monitors
+//	infrastructure_conditions  infrastructure alert conditions
+//	application_hosts          hosts of the application of links.application
+//	application_instances      instances of the application of links.application
+//	deployments                deployments of the application of links.application
+//	metrics                    metrics of an application, see serveMetrics
+//	entities                   NerdGraph entities other than monitors and applications, see entities
+//	nrql                       result sets of NRQL queries, see queryNRQL
+//	graphql                    answers of other NerdGraph queries, see answerSeededQuery
+func (s *Server) Seed(collection string, objs ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range objs {
+		data, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+		obj, err := decodeObject(data)
+		if err != nil {
+			return err
+		}
+		if _, ok := obj[idField(collection)]; !ok {
+			obj["id"] = s.newID(collection)
+		}
+		s.collections[collection] = append(s.collections[collection], &record{policyID: toString(obj["policy_id"]), obj: obj})
+	}
+	return nil
+}
+
+// SetTags replaces the tags of the monitor with ID monitorID.
+func (s *Server) SetTags(monitorID string, tags map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tags[monitorID] = tags
+}
+
+// Events returns the custom events inserted for accountID.
+func (s *Server) Events(accountID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}(nil), s.events[accountID]...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	if strings.HasPrefix(path, insightsPath) {
+		if s.InsertKey != "" && r.Header.Get("X-Insert-Key") != s.InsertKey {
+			writeJSON(w, http.StatusForbidden, object{"success": false, "error": "Invalid insert key"})
+			return
+		}
+		s.serveInsights(w, r, strings.TrimPrefix(path, insightsPath))
+		return
+	}
+
+	if s.APIKey != "" && r.Header.Get("X-Api-Key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API key")
+		return
+	}
+	switch {
+	case strings.HasPrefix(path, apiPath):
+		s.serveREST(w, r, strings.TrimPrefix(path, apiPath))
+	case strings.HasPrefix(path, syntheticsPath), path+"/" == syntheticsPath:
+		s.serveSynthetics(w, r, strings.Trim(strings.TrimPrefix(path, strings.TrimSuffix(syntheticsPath, "/")), "/"))
+	case strings.HasPrefix(path, labelSyntheticsPath):
+		s.serveLabelSynthetics(w, r, strings.Trim(strings.TrimPrefix(path, labelSyntheticsPath), "/"))
+	case strings.HasPrefix(path, infrastructurePath):
+		s.serveInfrastructure(w, r, strings.Trim(strings.TrimPrefix(path, infrastructurePath), "/"))
+	case path == graphqlPath:
+		s.serveGraphQL(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// newID returns a new ID for an object of collection: a UUID for monitors,
+// a number for any other object.
+func (s *Server) newID(collection string) interface{} {
+	s.nextID++
+	if collection == "monitors" {
+		return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+	}
+	return json.Number(fmt.Sprint(s.nextID))
+}
+
+func (s *Server) find(collection string, id string) (int, *record) {
+	field := idField(collection)
+	for i, rec := range s.collections[collection] {
+		if toString(rec.obj[field]) == id {
+			return i, rec
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) remove(collection string, index int) {
+	records := s.collections[collection]
+	s.collections[collection] = append(records[:index], records[index+1:]...)
+}
+
+// idField returns the field identifying the objects of collection.
+func idField(collection string) string {
+	if collection == "labels" {
+		return "key"
+	}
+	return "id"
+}
+
+func decodeObject(data []byte) (object, error) {
+	var obj object
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		obj = object{}
+	}
+	return obj, nil
+}
+
+func decodeBody(r *http.Request) (object, error) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		return nil, err
+	}
+	return decodeObject(buf.Bytes())
+}
+
+func toNumber(s string) json.Number {
+	return json.Number(s)
+}
+
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// writeError answers with an error in the format of the REST API v2.
+func writeError(w http.ResponseWriter, status int, title string) {
+	writeJSON(w, status, object{"error": object{"title": title}})
+}
+
+// page returns the items of the page, 1 based, of size s.PageSize and the
+// number of pages.
+func (s *Server) page(records []*record, page int) ([]*record, int) {
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	pages := (len(records) + size - 1) / size
+	if page < 1 || page > pages {
+		return nil, pages
+	}
+	end := page * size
+	if end > len(records) {
+		end = len(records)
+	}
+	return records[(page-1)*size : end], pages
+}
+
+func objects(records []*record) []object {
+	objs := make([]object, 0, len(records))
+	for _, rec := range records {
+		objs = append(objs, rec.obj)
+	}
+	return objs
+}
+
+// merge copies the fields of src over the ones of dst, but the id.
+func merge(dst, src object) {
+	for k, v := range src {
+		if k != "id" {
+			dst[k] = v
+		}
+	}
+}
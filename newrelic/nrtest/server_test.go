@@ -0,0 +1,383 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+func TestAlertsPoliciesAndConditions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PageSize = 2
+	client := s.Client("default")
+	ctx := context.Background()
+
+	var policyID int64
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("policy-%d", i)
+		policy, _, err := client.AlertsPolicies.Create(ctx, &newrelic.AlertsPolicyEntity{AlertsPolicy: &newrelic.AlertsPolicy{Name: &name}})
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		policyID = *policy.AlertsPolicy.ID
+	}
+
+	policies, err := client.AlertsPolicies.ListAllPages(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if len(policies.AlertsPolicies) != 5 {
+		t.Errorf("got %d policies over pages of 2, want 5", len(policies.AlertsPolicies))
+	}
+
+	name := "cpu"
+	condition := &newrelic.AlertsConditionEntity{
+		AlertsNRQLConditionEntity: &newrelic.AlertsNRQLConditionEntity{
+			AlertsNRQLCondition: &newrelic.AlertsNRQLCondition{Name: &name},
+		},
+	}
+	if _, _, err := client.AlertsConditions.Create(ctx, newrelic.ConditionNRQL, condition, policyID); err != nil {
+		t.Fatalf("Create condition returned error: %v", err)
+	}
+	opt := &newrelic.AlertsConditionsOptions{PolicyIDOptions: fmt.Sprint(policyID)}
+	conditions, err := client.AlertsConditions.ListAllPages(ctx, opt, newrelic.ConditionNRQL)
+	if err != nil {
+		t.Fatalf("ListAllPages conditions returned error: %v", err)
+	}
+	if len(conditions.AlertsNRQLConditionList.AlertsNRQLConditions) != 1 {
+		t.Errorf("got %+v, want the created condition", conditions.AlertsNRQLConditionList)
+	}
+
+	if _, err := client.AlertsPolicies.DeleteByID(ctx, 1); !newrelic.IsNotFound(err) {
+		t.Errorf("deleting a missing policy returned %v, want a not found error", err)
+	}
+}
+
+func TestMonitorsLabelsAndTags(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	ctx := context.Background()
+	synthetics := s.Client("synthetics")
+
+	name, monitorType, frequency := "ping", "SCRIPT_API", int64(10)
+	monitor, _, err := synthetics.SyntheticsMonitors.Create(ctx, &newrelic.Monitor{Name: &name, Type: &monitorType, Frequency: &frequency})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	script := "Y29uc29sZS5sb2coMSk="
+	if _, err := synthetics.SyntheticsScript.UpdateByID(ctx, &newrelic.Script{ScriptText: &script}, *monitor.ID); err != nil {
+		t.Fatalf("UpdateByID returned error: %v", err)
+	}
+	got, _, err := synthetics.SyntheticsScript.GetByID(ctx, *monitor.ID)
+	if err != nil || *got.ScriptText != script {
+		t.Errorf("GetByID returned %v, %v", got, err)
+	}
+
+	category, label := "Team", "Web"
+	labels := s.Client("labelSynthetics")
+	if _, err := labels.LabelsSynthetics.AddLabelToMonitor(ctx, *monitor.ID, &newrelic.MonitorLabel{Category: &category, Label: &label}); err != nil {
+		t.Fatalf("AddLabelToMonitor returned error: %v", err)
+	}
+	refs, err := labels.LabelsSynthetics.GetAllMonitorsByLabel(ctx, "team:web")
+	if err != nil || len(refs.MonitorRefs) != 1 || *refs.MonitorRefs[0].ID != *monitor.ID {
+		t.Errorf("GetAllMonitorsByLabel returned %+v, %v", refs, err)
+	}
+
+	s.SetTags(*monitor.ID, map[string][]string{"env": {"prod"}})
+	entities, err := s.Client("graphql").SyntheticsMonitors.ListAllTags(ctx)
+	if err != nil || len(entities) != 1 || len(entities[0].Tags) != 1 {
+		t.Errorf("ListAllTags returned %+v, %v", entities, err)
+	}
+
+	name = ""
+	if _, _, err := synthetics.SyntheticsMonitors.Create(ctx, &newrelic.Monitor{Name: &name}); !newrelic.IsValidation(err) {
+		t.Errorf("creating a monitor without name returned %v, want a validation error", err)
+	}
+}
+
+func TestInsertCustomEvents(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.InsertKey = "secret"
+	client := s.Client("insights")
+
+	if _, _, err := client.CustomEvents.Insert(context.Background(), "wrong", "42", `[{"eventType":"Deploy"}]`); err == nil {
+		t.Error("insert with a wrong key succeeded")
+	}
+	if _, _, err := client.CustomEvents.Insert(context.Background(), "secret", "42", `[{"eventType":"Deploy"}]`); err != nil {
+		t.Fatalf("Insert returned error: %v", err)
+	}
+	if events := s.Events("42"); len(events) != 1 || events[0]["eventType"] != "Deploy" {
+		t.Errorf("got events %v", events)
+	}
+}
+
+func TestAccounts(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AccountID = 42
+
+	accounts, _, err := s.Client("graphql").Accounts.List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(accounts) != 1 || *accounts[0].ID != 42 {
+		t.Errorf("got accounts %+v", accounts)
+	}
+}
+
+func TestIncidents(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("default")
+	ctx := context.Background()
+
+	err := s.Seed("alerts_incidents",
+		map[string]interface{}{"id": 1, "opened_at": 1528794000000, "links": map[string]interface{}{"policy_id": 7, "violations": []int{10, 11}}},
+		map[string]interface{}{"id": 2, "opened_at": 1528794000000, "closed_at": 1528795000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.AlertsIncidents.Acknowledge(ctx, 1); err != nil {
+		t.Fatalf("Acknowledge returned error: %v", err)
+	}
+	incident, _, err := client.AlertsIncidents.Close(ctx, 1)
+	if err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if incident.AlertsIncident.ClosedAt == nil || len(incident.AlertsIncident.Links.Violations) != 2 {
+		t.Errorf("got closed incident %+v", incident.AlertsIncident)
+	}
+	if _, _, err := client.AlertsIncidents.Close(ctx, 1); err == nil {
+		t.Error("closing a closed incident succeeded")
+	}
+	if _, _, err := client.AlertsIncidents.Acknowledge(ctx, 3); !newrelic.IsNotFound(err) {
+		t.Errorf("acknowledging a missing incident returned %v, want a not found error", err)
+	}
+
+	open, err := client.AlertsIncidents.ListAllPages(ctx, &newrelic.AlertsIncidentListOptions{OnlyOpen: true})
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if len(open.Incidents) != 0 {
+		t.Errorf("got open incidents %+v", open.Incidents)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("default")
+	ctx := context.Background()
+
+	category, name := "Env", "Production"
+	for _, app := range []int64{1, 2} {
+		app := app
+		label := &newrelic.LabelEntity{Label: &newrelic.Label{Category: &category, Name: &name, LabelLinks: &newrelic.LabelLinks{Applications: []*int64{&app}}}}
+		if _, _, err := client.Labels.Create(ctx, label); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	label, err := client.Labels.GetByKey(ctx, "env:production")
+	if err != nil {
+		t.Fatalf("GetByKey returned error: %v", err)
+	}
+	if label == nil || *label.Key != "Env:Production" || len(label.LabelLinks.Applications) != 2 {
+		t.Errorf("got label %+v, want Env:Production linked to both applications", label)
+	}
+
+	if _, err := client.Labels.DeleteByKey(ctx, "Env:Production"); err != nil {
+		t.Fatalf("DeleteByKey returned error: %v", err)
+	}
+	if label, err := client.Labels.GetByKey(ctx, "Env:Production"); err != nil || label != nil {
+		t.Errorf("GetByKey of a deleted label returned %+v, %v", label, err)
+	}
+}
+
+func TestLocationConditions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("default")
+	ctx := context.Background()
+
+	policyName := "synthetics"
+	policy, _, err := client.AlertsPolicies.Create(ctx, &newrelic.AlertsPolicyEntity{AlertsPolicy: &newrelic.AlertsPolicy{Name: &policyName}})
+	if err != nil {
+		t.Fatalf("Create policy returned error: %v", err)
+	}
+	policyID := *policy.AlertsPolicy.ID
+
+	name, monitorID, threshold := "login", "m1", int64(2)
+	condition := &newrelic.AlertsConditionEntity{
+		AlertsLocationConditionEntity: &newrelic.AlertsLocationConditionEntity{
+			AlertsLocationCondition: &newrelic.AlertsLocationCondition{Name: &name, Entities: []*string{&monitorID}},
+		},
+	}
+	created, _, err := client.AlertsConditions.Create(ctx, newrelic.ConditionLocation, condition, policyID)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	conditionID := *created.AlertsLocationCondition.ID
+
+	condition.AlertsLocationCondition.Terms = []*newrelic.LocationTerm{{Threshold: &threshold}}
+	if _, _, err := client.AlertsConditions.Update(ctx, newrelic.ConditionLocation, condition, conditionID); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	opt := &newrelic.AlertsConditionsOptions{PolicyIDOptions: fmt.Sprint(policyID)}
+	all, err := client.AlertsConditions.ListAllPages(ctx, opt)
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if all.AlertsLocationConditionList == nil || len(all.AlertsLocationConditions) != 1 || len(all.AlertsLocationConditions[0].Terms) != 1 {
+		t.Fatalf("got %+v, want the updated location condition", all.AlertsLocationConditionList)
+	}
+
+	if _, err := client.AlertsConditions.DeleteByID(ctx, newrelic.ConditionLocation, conditionID); err != nil {
+		t.Fatalf("DeleteByID returned error: %v", err)
+	}
+	list, _, err := client.AlertsConditions.List(ctx, opt, newrelic.ConditionLocation)
+	if err != nil || len(list.AlertsLocationConditions) != 0 {
+		t.Errorf("List after delete returned %+v, %v", list.AlertsLocationConditionList, err)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PageSize = 1
+	client := s.Client("default")
+	ctx := context.Background()
+
+	s.Seed("applications", map[string]interface{}{"id": 10, "name": "web", "language": "java"}, map[string]interface{}{"id": 11, "name": "api", "language": "go"})
+	s.Seed("application_hosts", map[string]interface{}{"id": 20, "host": "web-1", "links": map[string]interface{}{"application": 10}})
+
+	apps, err := client.Applications.ListAllPages(ctx, &newrelic.ApplicationListOptions{LanguageOptions: "go"})
+	if err != nil || len(apps.Applications) != 1 || *apps.Applications[0].Name != "api" {
+		t.Errorf("ListAllPages filtered by language returned %+v, %v", apps, err)
+	}
+	hosts, err := client.Applications.ListAllHostsPages(ctx, 10, nil)
+	if err != nil || len(hosts.ApplicationHosts) != 1 {
+		t.Errorf("ListAllHostsPages returned %+v, %v", hosts, err)
+	}
+}
+
+func TestDeployments(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("default")
+	ctx := context.Background()
+
+	s.Seed("applications", map[string]interface{}{"id": 10, "name": "web"})
+	revision := "v1"
+	deployment, _, err := client.Deployments.Create(ctx, 10, &newrelic.DeploymentEntity{Deployment: &newrelic.Deployment{Revision: &revision}})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := client.Deployments.DeleteByID(ctx, 10, *deployment.Deployment.ID); err != nil {
+		t.Fatalf("DeleteByID returned error: %v", err)
+	}
+	if deployments, err := client.Deployments.ListAllPages(ctx, 10, nil); err != nil || len(deployments.Deployments) != 0 {
+		t.Errorf("ListAllPages after delete returned %+v, %v", deployments, err)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("default")
+	ctx := context.Background()
+
+	s.Seed("applications", map[string]interface{}{"id": 10, "name": "web"})
+	s.Seed("metrics", map[string]interface{}{"name": "HttpDispatcher", "values": []string{"average_response_time"},
+		"data": map[string]interface{}{"average_response_time": 12.5}, "links": map[string]interface{}{"application": 10}})
+
+	to := time.Date(2018, 6, 12, 10, 0, 0, 0, time.UTC)
+	opt := &newrelic.MetricDataOptions{Names: []string{"HttpDispatcher"}, From: to.Add(-time.Hour), To: to, Period: 600}
+	data, _, err := client.Metrics.GetData(ctx, 10, opt)
+	if err != nil {
+		t.Fatalf("GetData returned error: %v", err)
+	}
+	if metrics := data.MetricData.Metrics; len(metrics) != 1 || len(metrics[0].Timeslices) != 6 {
+		t.Errorf("got %+v, want 6 timeslices of HttpDispatcher", data.MetricData)
+	}
+}
+
+func TestNRQL(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("graphql")
+
+	s.Seed("nrql", map[string]interface{}{
+		"query":   "SELECT count(*) FROM Transaction FACET appName",
+		"results": []map[string]interface{}{{"facet": "web", "appName": "web", "count": 10}},
+	})
+
+	result, _, err := client.NRQL.Query(context.Background(), DefaultAccountID, "select count(*)  from Transaction FACET appName")
+	if err != nil || len(result.Results) != 1 {
+		t.Errorf("Query returned %+v, %v", result, err)
+	}
+}
+
+func TestSeededGraphQL(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("graphql")
+
+	s.Seed("graphql", map[string]interface{}{"query": "{ actor { user { name } } }", "data": map[string]interface{}{"actor": map[string]interface{}{"user": map[string]interface{}{"name": "jdoe"}}}})
+
+	var data json.RawMessage
+	if _, err := client.GraphQL.Query(context.Background(), "{\n  actor { user { name } }\n}", nil, &data); err != nil || string(data) != `{"actor":{"user":{"name":"jdoe"}}}` {
+		t.Errorf("Query returned %s, %v", data, err)
+	}
+}
+
+func TestEntitiesAndTagging(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := s.Client("graphql")
+	ctx := context.Background()
+
+	s.Seed("applications", map[string]interface{}{"id": 10, "name": "web"}, map[string]interface{}{"id": 11, "name": "api"})
+	apps, err := client.Entities.SearchAll(ctx, &newrelic.EntitySearchOptions{Type: "application", Name: "web"})
+	if err != nil || len(apps) != 1 {
+		t.Fatalf("SearchAll returned %+v, %v", apps, err)
+	}
+	if _, err := client.Entities.AddTags(ctx, *apps[0].GUID, []*newrelic.Tag{newTag("team", "web")}); err != nil {
+		t.Fatalf("AddTags returned error: %v", err)
+	}
+	app, _, err := client.Entities.GetByGUID(ctx, *apps[0].GUID)
+	if err != nil || app == nil || len(app.Tags) != 1 || *app.Tags[0].Key != "team" {
+		t.Errorf("GetByGUID returned %+v, %v", app, err)
+	}
+}
+
+func newTag(key string, values ...string) *newrelic.Tag {
+	tag := &newrelic.Tag{Key: &key}
+	for i := range values {
+		tag.Values = append(tag.Values, &values[i])
+	}
+	return tag
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package nrtest
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeSyntheticsError answers with an error in the format of the
+// Synthetics API.
+func writeSyntheticsError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{"errors": []object{{"error": message}}})
+}
+
+// limitOffset returns the limit and offset parameters of query, the limit
+// defaults to s.PageSize.
+func (s *Server) limitOffset(query url.Values) (int, int) {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = s.PageSize
+	}
+	offset, err := strconv.Atoi(query.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func window(records []*record, limit int, offset int) []*record {
+	if offset >= len(records) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(records) {
+		end = len(records)
+	}
+	return records[offset:end]
+}
+
+// serveSynthetics serves the Synthetics API v3 paths: the monitors list,
+// {id} and {id}/script.
+func (s *Server) serveSynthetics(w http.ResponseWriter, r *http.Request, path string) {
+	segments := strings.Split(path, "/")
+	method := strings.ToUpper(r.Method)
+
+	switch {
+	case path == "" && method == "GET":
+		limit, offset := s.limitOffset(r.URL.Query())
+		monitors := s.collections["monitors"]
+		writeJSON(w, http.StatusOK, object{
+			"monitors": objects(window(monitors, limit, offset)),
+			"count":    len(monitors),
+		})
+	case path == "" && method == "POST":
+		s.createMonitor(w, r)
+	case len(segments) == 1:
+		s.serveMonitor(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "script":
+		s.serveScript(w, r, segments[0])
+	default:
+		writeSyntheticsError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) createMonitor(w http.ResponseWriter, r *http.Request) {
+	monitor, err := decodeBody(r)
+	if err != nil {
+		writeSyntheticsError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+	for _, field := range []string{"name", "type", "frequency"} {
+		if toString(monitor[field]) == "" {
+			writeJSON(w, http.StatusBadRequest, object{"errors": []object{{"error": field + " is required", "field": field}}})
+			return
+		}
+	}
+
+	id := s.newID("monitors")
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000+0000")
+	monitor["id"] = id
+	monitor["createdAt"] = now
+	monitor["modifiedAt"] = now
+	monitor["apiVersion"] = "0.6.0"
+	if _, ok := monitor["status"]; !ok {
+		monitor["status"] = "ENABLED"
+	}
+	s.collections["monitors"] = append(s.collections["monitors"], &record{obj: monitor})
+
+	w.Header().Set("Location", "http://"+r.Host+syntheticsPath+toString(id))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) serveMonitor(w http.ResponseWriter, r *http.Request, id string) {
+	index, rec := s.find("monitors", id)
+	if rec == nil {
+		writeSyntheticsError(w, http.StatusNotFound, "Monitor not found")
+		return
+	}
+
+	switch strings.ToUpper(r.Method) {
+	case "GET":
+		writeJSON(w, http.StatusOK, rec.obj)
+	case "PUT", "PATCH":
+		monitor, err := decodeBody(r)
+		if err != nil {
+			writeSyntheticsError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		if strings.ToUpper(r.Method) == "PUT" {
+			// PUT replaces the monitor, but what NewRelic sets.
+			for k := range rec.obj {
+				if k != "id" && k != "createdAt" && k != "apiVersion" {
+					delete(rec.obj, k)
+				}
+			}
+		}
+		merge(rec.obj, monitor)
+		rec.obj["modifiedAt"] = time.Now().UTC().Format("2006-01-02T15:04:05.000+0000")
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		s.remove("monitors", index)
+		delete(s.scripts, id)
+		delete(s.tags, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeSyntheticsError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// serveScript serves the base64 encoded script of a scripted monitor.
+func (s *Server) serveScript(w http.ResponseWriter, r *http.Request, id string) {
+	_, rec := s.find("monitors", id)
+	if rec == nil || !strings.HasPrefix(toString(rec.obj["type"]), "SCRIPT") {
+		writeSyntheticsError(w, http.StatusNotFound, "Script not found")
+		return
+	}
+
+	switch strings.ToUpper(r.Method) {
+	case "GET":
+		script, ok := s.scripts[id]
+		if !ok {
+			writeSyntheticsError(w, http.StatusNotFound, "Script not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, object{"scriptText": script})
+	case "PUT":
+		body, err := decodeBody(r)
+		if err != nil {
+			writeSyntheticsError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+		s.scripts[id] = toString(body["scriptText"])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeSyntheticsError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// serveLabelSynthetics serves the Synthetics API v4 monitor label paths:
+// labels/{category:label}, {id}/labels and {id}/labels/{category:label}.
+func (s *Server) serveLabelSynthetics(w http.ResponseWriter, r *http.Request, path string) {
+	segments := strings.Split(path, "/")
+	method := strings.ToUpper(r.Method)
+
+	switch {
+	case len(segments) == 2 && segments[0] == "labels" && method == "GET":
+		var matching []*record
+		for _, rec := range s.collections["monitors"] {
+			if indexLabel(rec.obj, segments[1]) >= 0 {
+				matching = append(matching, rec)
+			}
+		}
+		limit, offset := s.limitOffset(r.URL.Query())
+		refs := []object{}
+		for _, rec := range window(matching, limit, offset) {
+			id := toString(rec.obj["id"])
+			refs = append(refs, object{"id": id, "href": "http://" + r.Host + syntheticsPath + id})
+		}
+		writeJSON(w, http.StatusOK, object{
+			"pagedData": object{"monitorRefs": refs},
+			"metadata":  object{"limit": limit, "offset": offset},
+		})
+	case len(segments) == 2 && segments[1] == "labels" && method == "POST":
+		_, rec := s.find("monitors", segments[0])
+		if rec == nil {
+			writeSyntheticsError(w, http.StatusNotFound, "Monitor not found")
+			return
+		}
+		var label bytes.Buffer
+		label.ReadFrom(r.Body)
+		if !strings.Contains(label.String(), ":") {
+			writeSyntheticsError(w, http.StatusBadRequest, "Label must be category:label")
+			return
+		}
+		if indexLabel(rec.obj, label.String()) < 0 {
+			labels, _ := rec.obj["labels"].([]interface{})
+			rec.obj["labels"] = append(labels, label.String())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 3 && segments[1] == "labels" && method == "DELETE":
+		_, rec := s.find("monitors", segments[0])
+		if rec == nil {
+			writeSyntheticsError(w, http.StatusNotFound, "Monitor not found")
+			return
+		}
+		if i := indexLabel(rec.obj, segments[2]); i >= 0 {
+			labels := rec.obj["labels"].([]interface{})
+			rec.obj["labels"] = append(labels[:i], labels[i+1:]...)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeSyntheticsError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// indexLabel returns the index of label in the labels of monitor, -1 if it
+// is not there. Labels are matched ignoring case, like NewRelic does.
+func indexLabel(monitor object, label string) int {
+	labels, _ := monitor["labels"].([]interface{})
+	for i, l := range labels {
+		if strings.EqualFold(toString(l), label) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPageLimit is the page size used to walk Synthetics lists when the
+// caller did not set one.
+const defaultPageLimit = 50
+
+func newResponse(r *http.Response) *Response {
+	response := &Response{Response: r}
+	response.populatePageValues()
+	return response
+}
+
+// populatePageValues parses the REST v2 Link header, like
+// <https://api.newrelic.com/v2/alerts_policies.json?page=2>; rel="next",
+// <https://api.newrelic.com/v2/alerts_policies.json?page=4>; rel="last"
+// and sets NextPage, PrePage, FirstPage and LastPage.
+func (r *Response) populatePageValues() {
+	for _, header := range r.Header["Link"] {
+		for _, link := range strings.Split(header, ",") {
+			segments := strings.Split(strings.TrimSpace(link), ";")
+			if len(segments) < 2 {
+				continue
+			}
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			u, err := url.Parse(target[1 : len(target)-1])
+			if err != nil {
+				continue
+			}
+			page, err := strconv.Atoi(u.Query().Get("page"))
+			if err != nil {
+				continue
+			}
+
+			for _, segment := range segments[1:] {
+				switch strings.ReplaceAll(strings.TrimSpace(segment), " ", "") {
+				case `rel="next"`:
+					r.NextPage = page
+				case `rel="prev"`:
+					r.PrePage = page
+				case `rel="first"`:
+					r.FirstPage = page
+				case `rel="last"`:
+					r.LastPage = page
+				}
+			}
+		}
+	}
+}
+
+// hasPageLinks reports whether the response carried a Link header, i.e.
+// whether NextPage can be trusted to tell if there are more pages.
+func (r *Response) hasPageLinks() bool {
+	return r != nil && r.Header.Get("Link") != ""
+}
+
+// listPages walks a REST v2 list page by page. fetch lists the page set in
+// opt and returns the number of items on it.
+//
+// Pages are followed through the Link header, once an endpoint sent one the
+// walk ends on the first page without a next link. Without Link headers, a
+// paged endpoint is walked until it returns an empty page, any other one is
+// listed once: endpoints that ignore the page parameter would return the
+// same items forever.
+func listPages(opt *PageOptions, paged bool, fetch func() (*Response, int, error)) error {
+	firstPage := opt.Page
+	defer func() { opt.Page = firstPage }()
+	if opt.Page == 0 {
+		opt.Page = 1
+	}
+
+	var linked bool
+	for {
+		resp, count, err := fetch()
+		if err != nil {
+			return err
+		}
+		linked = linked || resp.hasPageLinks()
+
+		switch {
+		case linked:
+			if resp.NextPage <= opt.Page {
+				return nil
+			}
+			opt.Page = resp.NextPage
+		case paged && count > 0:
+			opt.Page++
+		default:
+			return nil
+		}
+	}
+}
+
+// listOffsets walks a Synthetics list by limit and offset. fetch lists the
+// items at the offset set in opt and returns how many there are, the walk
+// ends with the first empty page.
+func listOffsets(opt *PageLimitOptions, fetch func() (int, error)) error {
+	first := *opt
+	defer func() { *opt = first }()
+	if opt.Limit == 0 {
+		opt.Limit = defaultPageLimit
+	}
+
+	for {
+		count, err := fetch()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+		opt.Offset += opt.Limit
+	}
+}
+
+// listCursor walks a GraphQL list. fetch lists the results after cursor, nil
+// for the first ones, and returns the cursor of the next results, nil once
+// there are none left.
+func listCursor(fetch func(cursor *string) (*string, error)) error {
+	var cursor *string
+	for {
+		next, err := fetch(cursor)
+		if err != nil {
+			return err
+		}
+		if next == nil || *next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPopulatePageValues(t *testing.T) {
+	r := &Response{Response: &http.Response{Header: http.Header{}}}
+	r.Header.Set("Link", `<https://api.newrelic.com/v2/alerts_policies.json?page=3>; rel="next", `+
+		`<https://api.newrelic.com/v2/alerts_policies.json?page=1>; rel="prev", `+
+		`<https://api.newrelic.com/v2/alerts_policies.json?page=1>; rel="first", `+
+		`<https://api.newrelic.com/v2/alerts_policies.json?page=7>; rel="last"`)
+	r.populatePageValues()
+
+	if r.NextPage != 3 || r.PrePage != 1 || r.FirstPage != 1 || r.LastPage != 7 {
+		t.Errorf("got next %d, prev %d, first %d, last %d", r.NextPage, r.PrePage, r.FirstPage, r.LastPage)
+	}
+}
+
+func TestListAllPagesFollowsLinks(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/alerts_policies.json?page=2>; rel="next"`, r.Host))
+		}
+		fmt.Fprintf(w, `{"policies":[{"id":%s}]}`, page)
+	})
+
+	list, err := client.AlertsPolicies.ListAllPages(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if len(list.AlertsPolicies) != 2 || *list.AlertsPolicies[1].ID != 2 {
+		t.Errorf("got %d policies, want the ones of page 1 and 2", len(list.AlertsPolicies))
+	}
+}
+
+func TestListAllPagesListsUnpagedCategoryOnce(t *testing.T) {
+	var calls int
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Like the real API, location conditions answer every page with the
+		// first one.
+		calls++
+		fmt.Fprint(w, `{"location_failure_conditions":[{"id":1}]}`)
+	})
+
+	opt := &AlertsConditionsOptions{PolicyIDOptions: "42"}
+	list, err := client.AlertsConditions.ListAllPages(context.Background(), opt, ConditionLocation)
+	if err != nil {
+		t.Fatalf("ListAllPages returned error: %v", err)
+	}
+	if calls != 1 || len(list.AlertsLocationConditions) != 1 {
+		t.Errorf("got %d conditions in %d calls, want 1 in 1", len(list.AlertsLocationConditions), calls)
+	}
+}
+
+func TestListAllPagesEncodesNoItemsAsEmptyList(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+	ctx := context.Background()
+
+	incidents, err := client.AlertsIncidents.ListAllPages(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListAllPages of incidents returned error: %v", err)
+	}
+	violations, err := client.AlertsViolations.ListAllPages(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListAllPages of violations returned error: %v", err)
+	}
+	applications, err := client.Applications.ListAllPages(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListAllPages of applications returned error: %v", err)
+	}
+	tests := []struct {
+		list interface{}
+		want string
+	}{
+		{incidents, `{"incidents":[]}`},
+		{violations, `{"violations":[]}`},
+		{applications, `{"applications":[]}`},
+	}
+	for _, test := range tests {
+		if got, _ := json.Marshal(test.list); string(got) != test.want {
+			t.Errorf("got %s, want %s", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the client waits between attempts of a failed call.
+// The number of attempts itself is Client.Retries.
+type RetryPolicy struct {
+	// MinBackoff is the wait before the first retry, doubled on every
+	// following attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential wait.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After the client is willing to
+	// honor, a longer one makes the call fail right away.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy is the policy set by NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MinBackoff:    1 * time.Second,
+	MaxBackoff:    30 * time.Second,
+	MaxRetryAfter: 2 * time.Minute,
+}
+
+// shouldRetry reports whether an attempt failed for a transient reason:
+// a network error, a rate limit or a server side error.
+//
+// A non idempotent request (POST, PATCH) may already have been applied when
+// the connection broke or the server answered 5xx, retrying it could create
+// a second monitor or dashboard. It is only retried when it provably never
+// reached NewRelic: the connection could not be established, or the call was
+// rejected by the rate limiter.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req.Method) || isConnectError(err)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isConnectError reports whether err happened while dialing, before any byte
+// of the request was written.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial" || opErr.Op == "proxyconnect"
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header sent with the failed response wins over the exponential backoff,
+// the returned bool is false if that hint is beyond MaxRetryAfter.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0, true
+	}
+	// Keep half of the wait and randomize the other half, so that parallel
+	// callers hitting the same rate limit do not come back in lockstep.
+	half := int64(wait / 2)
+	return time.Duration(half + rand.Int63n(half+1)), true
+}
+
+// parseRetryAfter reads a Retry-After value given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			seconds = 0
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// rewindBody makes req ready to be sent again, with a fresh copy of its body.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		// Requests not built by NewRequest: keep the payload in memory once
+		// so that every attempt can send it.
+		payload, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(payload)), nil
+		}
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+type attemptKey struct{}
+
+// AttemptFromContext returns the attempt number, 1 for the first one, of the
+// call a request sent by the client belongs to. It is meant for transports
+// wrapped around the client, e.g. to log retries.
+func AttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+// doWithRetry sends req until it succeeds, fails for a non transient reason
+// or runs out of attempts. The last response or error is returned as is.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	attempts := c.Retries
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		req = req.WithContext(context.WithValue(ctx, attemptKey{}, attempt))
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+		resp, err := c.client.Do(req)
+		if attempt >= attempts || !c.RetryPolicy.shouldRetry(ctx, req, resp, err) {
+			return resp, err
+		}
+
+		wait, ok := c.RetryPolicy.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
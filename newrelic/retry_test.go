@@ -0,0 +1,155 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package newrelic
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(nil, "default")
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	client.RetryPolicy = RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, MaxRetryAfter: time.Second}
+	return client, server
+}
+
+func TestDoRetriesTransientFailures(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"users":[]}`))
+		}
+	})
+
+	req, _ := client.NewRequest("GET", "users.json", nil)
+	resp, err := client.Do(context.Background(), req, &UserList{})
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("got status %d after %d calls, want 200 after 3", resp.StatusCode, calls)
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req, _ := client.NewRequest("GET", "users/1.json", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Fatal("expected an error for 404")
+	}
+	if calls != 1 {
+		t.Errorf("404 was sent %d times, want 1", calls)
+	}
+}
+
+func TestDoResendsBodyOnRetry(t *testing.T) {
+	var calls int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"name":"ping"`) {
+			t.Errorf("attempt %d sent body %q", atomic.LoadInt32(&calls)+1, body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	name := "ping"
+	req, _ := client.NewRequest("PUT", "monitors/1", &Monitor{Name: &name})
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("PUT was sent %d times, want 2", calls)
+	}
+}
+
+func TestDoRetriesPostOnlyWhenSafe(t *testing.T) {
+	for status, want := range map[int]int32{
+		http.StatusInternalServerError: 1,
+		http.StatusTooManyRequests:     3,
+	} {
+		var calls int32
+		client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(status)
+		})
+
+		req, _ := client.NewRequest("POST", "monitors", &Monitor{})
+		client.Do(context.Background(), req, nil)
+		if calls != want {
+			t.Errorf("POST answered with %d was sent %d times, want %d", status, calls, want)
+		}
+	}
+}
+
+func TestDoStopsWaitingOnCancel(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.RetryPolicy.MinBackoff = time.Hour
+	client.RetryPolicy.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", "users.json", nil)
+	start := time.Now()
+	_, err := client.Do(ctx, req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Do kept sleeping after the context was done")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("7"); !ok || wait != 7*time.Second {
+		t.Errorf("parseRetryAfter(7) = %v, %v", wait, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait < 59*time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, %v", date, wait, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("parseRetryAfter accepted an invalid value")
+	}
+}
@@ -75,6 +75,33 @@ func (s *UsersService) ListAll(ctx context.Context, opt *UserListOptions) (*User
 	return userList, resp, nil
 }
 
+// ListPages calls fn with every page of the users matching opt.
+func (s *UsersService) ListPages(ctx context.Context, opt *UserListOptions, fn func(*UserList, *Response) error) error {
+	if opt == nil {
+		opt = new(UserListOptions)
+	}
+	return listPages(&opt.PageOptions, true, func() (*Response, int, error) {
+		list, resp, err := s.ListAll(ctx, opt)
+		if err != nil {
+			return resp, 0, err
+		}
+		return resp, len(list.Users), fn(list, resp)
+	})
+}
+
+// ListAllPages returns the users matching opt from all pages.
+func (s *UsersService) ListAllPages(ctx context.Context, opt *UserListOptions) (*UserList, error) {
+	all := new(UserList)
+	err := s.ListPages(ctx, opt, func(list *UserList, resp *Response) error {
+		all.Users = append(all.Users, list.Users...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 // GetByID returns specfic NewRelic user by given `id`
 func (s *UsersService) GetByID(ctx context.Context, id int64) (*UserEntity, *Response, error) {
 	u := fmt.Sprintf("users/%v.json", id)
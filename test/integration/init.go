@@ -4,24 +4,44 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
+
+	"github.com/IBM/newrelic-cli/newrelic/nrtest"
 )
 
 var (
 	NEW_RELIC_APIKEY string
+
+	// fakeEnv points the nr CLI at a fake NewRelic, used when no
+	// NEW_RELIC_APIKEY is set to test against a real account.
+	fakeEnv []string
 )
 
 func init() {
-	NEW_RELIC_APIKEY = ""
+	NEW_RELIC_APIKEY = os.Getenv("NEW_RELIC_APIKEY")
+	if NEW_RELIC_APIKEY != "" {
+		return
+	}
+
+	// The fake lives as long as the test binary.
+	fake := nrtest.NewServer()
+	endpoints := fake.Endpoints()
+	fakeEnv = []string{
+		"NEW_RELIC_APIKEY=fake",
+		"NEW_RELIC_API_URL=" + endpoints.API,
+		"NEW_RELIC_SYNTHETICS_URL=" + endpoints.Synthetics,
+		"NEW_RELIC_LABEL_SYNTHETICS_URL=" + endpoints.LabelSynthetics,
+		"NEW_RELIC_INSIGHTS_URL=" + endpoints.Insights,
+		"NEW_RELIC_INFRASTRUCTURE_URL=" + endpoints.Infrastructure,
+		"NEW_RELIC_GRAPHQL_URL=" + endpoints.GraphQL,
+	}
 }
 
 func EXENRCLI(args ...string) {
 
 	cmd := exec.Command("../../../nr", args...)
-
-	// env := os.Environ()
-	// env = append(env, "NEW_RELIC_APIKEY="+NEW_RELIC_APIKEY)
-	// cmd.Env = env
+	cmd.Env = append(os.Environ(), fakeEnv...)
 
 	var out bytes.Buffer
 	cmd.Stdout = &out
@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"reflect"
 	"strconv"
 
 	"github.com/IBM/newrelic-cli/newrelic"
@@ -156,6 +155,7 @@ var OPERATION_NAME_CREATE_MONITOR = "Create Monitor"
 var OPERATION_NAME_CREATE_ALERT_POLICY = "Create Alert Policy"
 var OPERATION_NAME_CREATE_ALERT_CONDITIION = "Create Alert Condition"
 var OPERATION_NAME_CREATE_DASHBOARD = "Create Dashboard"
+var OPERATION_NAME_CREATE_LABEL = "Create Label"
 
 var OPERATION_NAME_UPDATE_MONITOR = "Update Monitor"
 var OPERATION_NAME_UPDATE_MONITOR_SCRIPT = "Create Monitor"
@@ -169,6 +169,7 @@ var OPERATION_NAME_UPDATE_DASHBOARD_BY_NAME = "Update Dashboard By Name"
 var OPERATION_NAME_PATCH_MONITOR = "Patch Monitor"
 
 var OPERATION_NAME_DELETE_MONITOR = "Delete Monitor"
+var OPERATION_NAME_DELETE_LABEL = "Delete Label"
 var OPERATION_NAME_DELETE_LABEL_FROM_MONITOR = "Delete Label From Monitor"
 var OPERATION_NAME_DELETE_ALERT_POLICY_BY_ID = "Delete Alert Policy By ID"
 var OPERATION_NAME_DELETE_ALERT_POLICY_BY_NAME = "Delete Alert Policy By Name"
@@ -181,7 +182,6 @@ var OPERATION_NAME_INSERT_CUSTOM_EVENTS = "Insert Custom Events"
 
 var ERR_CREATE_NR_CLINET = errors.New("Call NewRelic REST error")
 var ERR_REST_CALL = errors.New("Call NewRelic REST error")
-var ERR_REST_CALL_NOT_2XX = errors.New("Status code is not 2XX calling NewRelic REST")
 var ERR_REST_CALL_400 = errors.New("Status code is 400 calling NewRelic REST")
 var ERR_REST_CHANNEL_NOT_EXIST = errors.New("No any notification channels")
 
@@ -191,146 +191,9 @@ var ERR_REST_CHANNEL_NOT_EXIST = errors.New("No any notification channels")
 // var ERR_PATCH_MONITOR = errors.New("Create Monitor error")
 // var ERR_DELETE_MONITOR = errors.New("Create Monitor error")
 
-var STATUS_CODE_MAPPING_CONDITIONS = make(map[int]string)
-var STATUS_CODE_MAPPING_MONITORS = make(map[int]string)
-var STATUS_CODE_MAPPING_MONITORS_SCRIPT = make(map[int]string)
-var STATUS_CODE_MAPPING_LABELS = make(map[int]string)
-var STATUS_CODE_MAPPING_LABELS_SYNTHETICS = make(map[int]string)
-var STATUS_CODE_MAPPING_ALERT_CHANNELS = make(map[int]string)
-var STATUS_CODE_MAPPING_ALERT_POLICIES = make(map[int]string)
-var STATUS_CODE_MAPPING_ALERT_CONDITIONS = make(map[int]string)
-var STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS = make(map[int]string)
-
 var GlobalRESTCallResultList RESTCallResultList
 
 func init() {
-	/*
-		alert conditions:
-
-		401 	Invalid API key
-		401 	Invalid request, API key required
-		403 	New Relic API access has not been enabled
-		500 	A server error occurred, please contact New Relic support
-		404 	No Alerts condition was found with the given ID
-		406 	Bad entity type
-		422 	Validation error occurred while trying to update the alert condition
-	*/
-	STATUS_CODE_MAPPING_CONDITIONS[200] = "Success"
-	STATUS_CODE_MAPPING_CONDITIONS[400] = "Bad request"
-	STATUS_CODE_MAPPING_CONDITIONS[401] = "Invalid request, API key required"
-	STATUS_CODE_MAPPING_CONDITIONS[403] = "New Relic API access has not been enabled"
-	STATUS_CODE_MAPPING_CONDITIONS[500] = "A server error occurred, please contact New Relic support"
-	STATUS_CODE_MAPPING_CONDITIONS[404] = "No Alerts condition was found with the given ID"
-	STATUS_CODE_MAPPING_CONDITIONS[406] = "Bad entity type"
-	STATUS_CODE_MAPPING_CONDITIONS[422] = "Validation error occurred while trying to update the alert condition"
-
-	/*
-		synthetics monitors:
-	*/
-	STATUS_CODE_MAPPING_MONITORS[200] = "Success"
-	STATUS_CODE_MAPPING_MONITORS[201] = "Success"
-	STATUS_CODE_MAPPING_MONITORS[204] = "Success"
-
-	STATUS_CODE_MAPPING_MONITORS[400] = "The monitor values is invalid, or the format of the request is invalid."
-	STATUS_CODE_MAPPING_MONITORS[404] = "The specified monitor doesn not exist"
-	STATUS_CODE_MAPPING_MONITORS[500] = "A server error occurred, please contact New Relic support"
-
-	/*
-		synthetics monitor script:
-	*/
-	STATUS_CODE_MAPPING_MONITORS_SCRIPT[200] = "Success"
-	STATUS_CODE_MAPPING_MONITORS_SCRIPT[204] = "Success"
-
-	STATUS_CODE_MAPPING_MONITORS[400] = "The monitor values is invalid, or the format of the request is invalid."
-	STATUS_CODE_MAPPING_MONITORS[404] = "The specified monitor doesn not exist"
-	STATUS_CODE_MAPPING_MONITORS[500] = "A server error occurred, please contact New Relic support"
-
-	/*
-		labels
-
-		401 	Invalid API key
-		401 	Invalid request, API key required
-		403 	New Relic API access has not been enabled
-		500 	A server error occurred, please contact New Relic support
-	*/
-	STATUS_CODE_MAPPING_LABELS[200] = "Success"
-	STATUS_CODE_MAPPING_LABELS[400] = "Bad request"
-	STATUS_CODE_MAPPING_LABELS[401] = "Invalid request, API key required"
-	STATUS_CODE_MAPPING_LABELS[403] = "New Relic API access has not been enabled"
-	STATUS_CODE_MAPPING_LABELS[500] = "A server error occurred, please contact New Relic support"
-
-	/*
-		label synthetics
-	*/
-	STATUS_CODE_MAPPING_LABELS_SYNTHETICS[200] = "Success"
-	STATUS_CODE_MAPPING_LABELS_SYNTHETICS[204] = "Success"
-
-	STATUS_CODE_MAPPING_LABELS_SYNTHETICS[400] = "Bad request, or the format of the request is invalid."
-	STATUS_CODE_MAPPING_LABELS_SYNTHETICS[404] = "The specified label doesn not exist"
-	STATUS_CODE_MAPPING_LABELS_SYNTHETICS[500] = "A server error occurred, please contact New Relic support"
-
-	/*
-		alert channels
-
-		401 	Invalid API key
-		401 	Invalid request, API key required
-		403 	New Relic API access has not been enabled
-		500 	A server error occurred, please contact New Relic support
-		422 	Validation or internal error occurred
-	*/
-
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[200] = "Success"
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[400] = "Bad request"
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[401] = "Invalid request, API key required"
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[403] = "New Relic API access has not been enabled"
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[500] = "A server error occurred, please contact New Relic support"
-	STATUS_CODE_MAPPING_ALERT_CHANNELS[422] = "Validation or internal error occurred"
-
-	/*
-		alert policies
-
-		401 	Invalid API key
-		401 	Invalid request, API key required
-		403 	New Relic API access has not been enabled
-		500 	A server error occurred, please contact New Relic support
-		422 	Validation or internal error occurred
-	*/
-
-	STATUS_CODE_MAPPING_ALERT_POLICIES[200] = "Success"
-	STATUS_CODE_MAPPING_ALERT_POLICIES[400] = "Bad request"
-	STATUS_CODE_MAPPING_ALERT_POLICIES[401] = "Invalid request, API key required"
-	STATUS_CODE_MAPPING_ALERT_POLICIES[403] = "New Relic API access has not been enabled"
-	STATUS_CODE_MAPPING_ALERT_POLICIES[500] = "A server error occurred, please contact New Relic support"
-	STATUS_CODE_MAPPING_ALERT_POLICIES[422] = "Validation or internal error occurred"
-
-	/*
-		alert conditions
-
-		401 	Invalid API key
-		401 	Invalid request, API key required
-		403 	New Relic API access has not been enabled
-		500 	A server error occurred, please contact New Relic support
-		404 	No Alerts policy was found for the given ID
-		422 	Validation error occurred while trying to create the alert condition
-	*/
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[200] = "Success"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[400] = "Bad request"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[401] = "Invalid request, API key required"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[403] = "New Relic API access has not been enabled"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[404] = "No Alerts policy was found for the given ID"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[500] = "A server error occurred, please contact New Relic support"
-	STATUS_CODE_MAPPING_ALERT_CONDITIONS[422] = "Validation error occurred while trying to create the alert condition"
-
-	/*
-		Insights custom events
-	*/
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[200] = "Success"
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[400] = "Bad request"
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[403] = "Invalid insert key"
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[408] = "Request timed out"
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[413] = "Content too large"
-	STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[429] = "Too many requests"
-
 	GlobalRESTCallResultList = RESTCallResultList{}
 }
 
@@ -339,14 +202,29 @@ func AppendRESTCallResult(serviceInstance interface{}, operationName string, sta
 	GlobalRESTCallResultList.AllRESTCallResult = append(GlobalRESTCallResultList.AllRESTCallResult, ret)
 }
 
+// AppendRESTCallError records a failed REST call. When err is a
+// *newrelic.APIError, its status code and NewRelic error message are kept.
+func AppendRESTCallError(serviceInstance interface{}, operationName string, err error, message string) {
+	var ret RESTCallResult
+	var apiErr *newrelic.APIError
+	if errors.As(err, &apiErr) {
+		ret = ToRESTCallResult(serviceInstance, operationName, apiErr.StatusCode, message)
+		ret.Description = apiErr.Description()
+	} else {
+		ret = ToRESTCallResult(serviceInstance, operationName, 0, message)
+		ret.Description = err.Error()
+	}
+	GlobalRESTCallResultList.AllRESTCallResult = append(GlobalRESTCallResultList.AllRESTCallResult, ret)
+}
+
 func ToRESTCallResult(serviceInstance interface{}, operationName string, statusCode int, message string) RESTCallResult {
 	var ret RESTCallResult = RESTCallResult{}
 	ret.OperationName = operationName
 	ret.StatusCode = statusCode
 	ret.Message = message
-	var desc string = ""
-	desc = GetDescByStatusCode(serviceInstance, statusCode)
-	ret.Description = desc
+	if statusCode >= 200 && statusCode < 300 {
+		ret.Description = "Success"
+	}
 	return ret
 }
 
@@ -360,39 +238,6 @@ func ToReturnValue(isContinue bool, operationName string, originalErr error, typ
 	return ret
 }
 
-func GetDescByStatusCode(serviceInstance interface{}, statusCode int) string {
-	var desc string = ""
-
-	if statusCode == 200 {
-		desc = "Success"
-		return desc
-	}
-
-	var typeName string = reflect.TypeOf(serviceInstance).String()
-
-	if typeName == "" {
-
-	} else if typeName == "*newrelic.SyntheticsService" {
-		desc = STATUS_CODE_MAPPING_MONITORS[statusCode]
-	} else if typeName == "*newrelic.ScriptService" {
-		desc = STATUS_CODE_MAPPING_MONITORS_SCRIPT[statusCode]
-	} else if typeName == "*newrelic.LabelsService" {
-		desc = STATUS_CODE_MAPPING_LABELS[statusCode]
-	} else if typeName == "*newrelic.LabelsSyntheticsService" {
-		desc = STATUS_CODE_MAPPING_LABELS_SYNTHETICS[statusCode]
-	} else if typeName == "*newrelic.AlertsChannelsService" {
-		desc = STATUS_CODE_MAPPING_ALERT_CHANNELS[statusCode]
-	} else if typeName == "*newrelic.AlertsPoliciesService" {
-		desc = STATUS_CODE_MAPPING_ALERT_POLICIES[statusCode]
-	} else if typeName == "*newrelic.AlertsConditionsService" {
-		desc = STATUS_CODE_MAPPING_ALERT_CONDITIONS[statusCode]
-	} else if typeName == "*newrelic.CustomEventService" {
-		desc = STATUS_CODE_MAPPING_ALERT_CUSTOM_EVENTS[statusCode]
-	}
-
-	return desc
-}
-
 func GenerateBackupMonitorMeta(monitorList []*newrelic.Monitor, backupFolder string, singleFile bool) BackupMonitorMetaList {
 	var backupMonitorMetaList []BackupMonitorMeta
 	for _, monitor := range monitorList {
@@ -0,0 +1,285 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ProfileKeys are the settings a profile can hold, with the environment
+// variables overriding them.
+var ProfileKeys = map[string]string{
+	"api_key":    "NEW_RELIC_APIKEY",
+	"insert_key": "NEW_RELIC_INSERTKEY",
+	"account_id": "NEW_RELIC_ACCOUNT_ID",
+	"region":     "NEW_RELIC_REGION",
+	"proxy":      "NEW_RELIC_PROXY",
+	"proxy_auth": "PROXY_AUTH",
+	"retries":    "RETRIES",
+	// credential_process, credential_store and credentials_file tell where
+	// to find the api_key and insert_key missing in the profile.
+	"credential_process": "NEW_RELIC_CREDENTIAL_PROCESS",
+	"credential_store":   "NEW_RELIC_CREDENTIAL_STORE",
+	"credentials_file":   "NEW_RELIC_CREDENTIALS_FILE",
+	// ca_bundle is a PEM file of CAs trusted on top of the system ones, like
+	// the one of a TLS inspecting proxy. client_cert and client_key are the
+	// PEM certificate and key sent to proxies requiring mutual TLS.
+	"ca_bundle":   "NEW_RELIC_CA_BUNDLE",
+	"client_cert": "NEW_RELIC_CLIENT_CERT",
+	"client_key":  "NEW_RELIC_CLIENT_KEY",
+	// The tuning of the connection pool shared by all calls.
+	"max_idle_conns":          "NEW_RELIC_MAX_IDLE_CONNS",
+	"max_idle_conns_per_host": "NEW_RELIC_MAX_IDLE_CONNS_PER_HOST",
+	"idle_conn_timeout":       "NEW_RELIC_IDLE_CONN_TIMEOUT",
+	"keep_alive":              "NEW_RELIC_KEEP_ALIVE",
+	"disable_keep_alives":     "NEW_RELIC_DISABLE_KEEP_ALIVES",
+}
+
+// secretProfileKeys are the profile settings masked by nr config list.
+var secretProfileKeys = map[string]bool{
+	"api_key":    true,
+	"insert_key": true,
+	"proxy_auth": true,
+}
+
+// DefaultProfile is the profile written by nr config set when none is
+// selected yet.
+const DefaultProfile = "default"
+
+func init() {
+	viper.BindEnv("profile", "NEW_RELIC_PROFILE")
+	for key, env := range ProfileKeys {
+		viper.BindEnv(key, env)
+	}
+}
+
+// SelectedProfile returns the profile given by --profile or
+// NEW_RELIC_PROFILE, else the current_profile of the config file, else "".
+func SelectedProfile() string {
+	if name := viper.GetString("profile"); name != "" {
+		return name
+	}
+	return viper.GetString("current_profile")
+}
+
+var (
+	profileOnce sync.Once
+	profileErr  error
+)
+
+// LoadProfile overlays the settings of the selected profile on the ones at
+// the top of the config file, once. Flags and environment variables still
+// win over both.
+func LoadProfile() error {
+	profileOnce.Do(func() {
+		name := SelectedProfile()
+		if name == "" {
+			return
+		}
+		settings := viper.GetStringMap("profiles." + name)
+		if len(settings) == 0 {
+			path, _ := ConfigFilePath()
+			profileErr = fmt.Errorf("Profile %q not found in %s.", name, path)
+			return
+		}
+		data, err := yaml.Marshal(settings)
+		if err != nil {
+			profileErr = err
+			return
+		}
+		viper.SetConfigType("yaml")
+		profileErr = viper.MergeConfig(bytes.NewReader(data))
+	})
+	return profileErr
+}
+
+// ConfigFilePath returns the config file in use, $HOME/.nr.yaml by default.
+func ConfigFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nr.yaml"), nil
+}
+
+// ConfigFile is the content of the YAML config file, edited in place to keep
+// the order of the settings and the ones the CLI does not know. Comments are
+// not kept.
+type ConfigFile struct {
+	Path    string
+	content yaml.MapSlice
+}
+
+// ReadConfigFile reads the config file in use, empty if it does not exist
+// yet.
+func ReadConfigFile() (*ConfigFile, error) {
+	path, err := ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return readConfigFile(path)
+}
+
+func readConfigFile(path string) (*ConfigFile, error) {
+	f := &ConfigFile{Path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &f.content); err != nil {
+		return nil, fmt.Errorf("Invalid config file %s: %v", path, err)
+	}
+	return f, nil
+}
+
+// Get returns the value of the dotted key, like profiles.prod.region.
+func (f *ConfigFile) Get(key string) (interface{}, bool) {
+	var value interface{} = f.content
+	for _, part := range strings.Split(key, ".") {
+		m, ok := value.(yaml.MapSlice)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = mapSliceGet(m, part); !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Set sets the dotted key to value, adding the missing levels.
+func (f *ConfigFile) Set(key string, value interface{}) {
+	f.content = mapSliceSet(f.content, strings.Split(key, "."), value)
+}
+
+// Profiles returns the names of the profiles, sorted.
+func (f *ConfigFile) Profiles() []string {
+	var names []string
+	if profiles, ok := f.Get("profiles"); ok {
+		if m, ok := profiles.(yaml.MapSlice); ok {
+			for _, item := range m {
+				names = append(names, fmt.Sprint(item.Key))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write saves the config file, readable by the user only since it holds API
+// keys.
+func (f *ConfigFile) Write() error {
+	data, err := yaml.Marshal(f.content)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(f.Path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(f.Path, 0600)
+}
+
+func mapSliceGet(m yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+func mapSliceSet(m yaml.MapSlice, keys []string, value interface{}) yaml.MapSlice {
+	for i, item := range m {
+		if fmt.Sprint(item.Key) != keys[0] {
+			continue
+		}
+		if len(keys) == 1 {
+			m[i].Value = value
+		} else {
+			child, _ := item.Value.(yaml.MapSlice)
+			m[i].Value = mapSliceSet(child, keys[1:], value)
+		}
+		return m
+	}
+	if len(keys) == 1 {
+		return append(m, yaml.MapItem{Key: keys[0], Value: value})
+	}
+	return append(m, yaml.MapItem{Key: keys[0], Value: mapSliceSet(nil, keys[1:], value)})
+}
+
+// MaskSecret hides all but the last 4 characters of secret.
+func MaskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
+
+// IsSecretProfileKey reports whether the profile setting key holds a secret.
+func IsSecretProfileKey(key string) bool {
+	return secretProfileKeys[key]
+}
+
+// GetAccountID returns the account_id of the environment or the selected
+// profile, else the account the API key has access to, discovered through
+// NerdGraph.
+func GetAccountID(ctx context.Context) (string, error) {
+	if err := LoadProfile(); err != nil {
+		return "", err
+	}
+	if accountID := viper.GetString("account_id"); accountID != "" {
+		return accountID, nil
+	}
+
+	client, err := GetNewRelicClient("graphql")
+	if err != nil {
+		return "", err
+	}
+	accounts, _, err := client.Accounts.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch len(accounts) {
+	case 0:
+		return "", fmt.Errorf("The API key has access to no account, set account_id in the profile.")
+	case 1:
+		return strconv.FormatInt(*accounts[0].ID, 10), nil
+	}
+	var ids []string
+	for _, account := range accounts {
+		ids = append(ids, strconv.FormatInt(*account.ID, 10))
+	}
+	return "", fmt.Errorf("The API key has access to several accounts (%s), set account_id in the profile.", strings.Join(ids, ", "))
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFileSetKeepsOtherSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".nr.yaml")
+	content := "# accounts\nregion: US\nprofiles:\n  prod:\n    api_key: k1\n    custom: kept\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := readConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Set("profiles.prod.region", "EU")
+	f.Set("profiles.dev.api_key", "k2")
+	if err := f.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	want := "region: US\nprofiles:\n  prod:\n    api_key: k1\n    custom: kept\n    region: EU\n  dev:\n    api_key: k2\n"
+	if string(data) != want {
+		t.Errorf("got config file\n%s\nwant\n%s", data, want)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("config file mode is %v, want 0600", info.Mode().Perm())
+	}
+	if names := f.Profiles(); strings.Join(names, ",") != "dev,prod" {
+		t.Errorf("got profiles %v", names)
+	}
+	if value, ok := f.Get("profiles.prod.api_key"); !ok || value != "k1" {
+		t.Errorf("Get returned %v, %v", value, ok)
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	if got := MaskSecret("NRAK-123456789"); got != "********6789" {
+		t.Errorf("got %q", got)
+	}
+	if got := MaskSecret("abc"); got != "***" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/viper"
+)
+
+func init() {
+	viper.BindEnv("timeout", "NEW_RELIC_TIMEOUT")
+	viper.BindEnv("request-timeout", "NEW_RELIC_REQUEST_TIMEOUT")
+}
+
+var (
+	contextOnce   sync.Once
+	sharedContext context.Context
+)
+
+// GetContext returns the context shared by all the NewRelic API calls of a
+// command. It is cancelled when --timeout expires or on Ctrl-C/SIGTERM, a
+// second signal exits right away.
+func GetContext() context.Context {
+	contextOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		if timeout := viper.GetDuration("timeout"); timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		sharedContext = ctx
+
+		signals := make(chan os.Signal, 2)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			fmt.Fprintln(os.Stderr, "Interrupted, cancelling the NewRelic API calls. Press Ctrl-C again to exit right away.")
+			cancel()
+			<-signals
+			os.Exit(130)
+		}()
+	})
+	return sharedContext
+}
+
+// IsCancelled reports whether the shared context was cancelled, by a signal
+// or because --timeout expired.
+func IsCancelled() bool {
+	return GetContext().Err() != nil
+}
@@ -0,0 +1,336 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// StoreKeyring keeps the keys in the freedesktop Secret Service keyring.
+	StoreKeyring = "keyring"
+	// StoreFile keeps the keys in the credentials file.
+	StoreFile = "file"
+)
+
+// CredentialKeys are the secrets resolved through the credential sources.
+var CredentialKeys = []string{"api_key", "insert_key"}
+
+// CredentialSource resolves the secrets of a profile.
+type CredentialSource interface {
+	Name() string
+	// Get returns the secret key of profile, "" if the source has none.
+	Get(profile string, key string) (string, error)
+}
+
+// CredentialStore is a CredentialSource nr auth login can write to.
+type CredentialStore interface {
+	CredentialSource
+	Set(profile string, key string, value string) error
+	// Delete removes the secret key of profile, if any.
+	Delete(profile string, key string) error
+}
+
+// GetCredential returns the secret key, api_key or insert_key, of the
+// selected profile: from the environment or the config file, else from the
+// credential_process, the credentials file or the keyring.
+func GetCredential(key string) (string, error) {
+	if err := LoadProfile(); err != nil {
+		return "", err
+	}
+	if value := viper.GetString(key); value != "" {
+		return value, nil
+	}
+
+	profile := ProfileName()
+	for _, source := range credentialSources() {
+		value, err := source.Get(profile, key)
+		if err != nil {
+			return "", fmt.Errorf("Failed to get %s of profile %q from %s: %v", key, profile, source.Name(), err)
+		}
+		if value != "" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+// ProfileName returns the selected profile, "default" if none.
+func ProfileName() string {
+	if profile := SelectedProfile(); profile != "" {
+		return profile
+	}
+	return DefaultProfile
+}
+
+// credentialSources returns the sources of the selected profile: its
+// credential_process if set, else its credential_store, else the
+// credentials file and the keyring if available.
+func credentialSources() []CredentialSource {
+	if command := viper.GetString("credential_process"); command != "" {
+		return []CredentialSource{getProcessSource(command)}
+	}
+	switch viper.GetString("credential_store") {
+	case StoreKeyring:
+		return []CredentialSource{&KeyringStore{}}
+	case StoreFile:
+		return []CredentialSource{NewFileStore()}
+	}
+	sources := []CredentialSource{NewFileStore()}
+	if KeyringAvailable() {
+		sources = append(sources, &KeyringStore{})
+	}
+	return sources
+}
+
+// GetCredentialStore returns the store named name, StoreKeyring or
+// StoreFile.
+func GetCredentialStore(name string) (CredentialStore, error) {
+	switch name {
+	case StoreKeyring:
+		if !KeyringAvailable() {
+			return nil, fmt.Errorf("The keyring needs secret-tool, from libsecret, in the PATH.")
+		}
+		return &KeyringStore{}, nil
+	case StoreFile:
+		return NewFileStore(), nil
+	}
+	return nil, fmt.Errorf("Unsupported credential store %q, must be %s or %s.", name, StoreKeyring, StoreFile)
+}
+
+// ProcessSource runs an external command printing the secrets as JSON, like
+// {"api_key": "...", "insert_key": "..."}. The command gets the profile in
+// NEW_RELIC_PROFILE.
+type ProcessSource struct {
+	Command string
+
+	once    sync.Once
+	secrets map[string]string
+	err     error
+}
+
+var (
+	processSourcesMu sync.Mutex
+	processSources   = make(map[string]*ProcessSource)
+)
+
+// getProcessSource returns the ProcessSource of command, shared so the
+// command runs once per nr command.
+func getProcessSource(command string) *ProcessSource {
+	processSourcesMu.Lock()
+	defer processSourcesMu.Unlock()
+	if processSources[command] == nil {
+		processSources[command] = &ProcessSource{Command: command}
+	}
+	return processSources[command]
+}
+
+func (s *ProcessSource) Name() string {
+	return "credential_process"
+}
+
+func (s *ProcessSource) Get(profile string, key string) (string, error) {
+	s.once.Do(func() {
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(GetContext(), "cmd", "/C", s.Command)
+		} else {
+			cmd = exec.CommandContext(GetContext(), "sh", "-c", s.Command)
+		}
+		cmd.Env = append(os.Environ(), "NEW_RELIC_PROFILE="+profile)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			s.err = err
+			return
+		}
+		if err := json.Unmarshal(out, &s.secrets); err != nil {
+			s.err = fmt.Errorf("invalid JSON output: %v", err)
+		}
+	})
+	if s.err != nil {
+		return "", s.err
+	}
+	return s.secrets[key], nil
+}
+
+// FileStore keeps the secrets in a YAML file by profile, which must be
+// readable by the user only:
+//
+//	prod:
+//	  api_key: ...
+//	  insert_key: ...
+type FileStore struct {
+	Path string
+}
+
+// NewFileStore returns the FileStore of credentials_file, $HOME/.nr/credentials
+// by default.
+func NewFileStore() *FileStore {
+	path := viper.GetString("credentials_file")
+	if path == "" {
+		home, _ := homedir.Dir()
+		path = filepath.Join(home, ".nr", "credentials")
+	}
+	return &FileStore{Path: path}
+}
+
+func (s *FileStore) Name() string {
+	return s.Path
+}
+
+func (s *FileStore) Get(profile string, key string) (string, error) {
+	secrets, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return secrets[profile][key], nil
+}
+
+func (s *FileStore) Set(profile string, key string, value string) error {
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if secrets[profile] == nil {
+		secrets[profile] = make(map[string]string)
+	}
+	secrets[profile][key] = value
+	return s.write(secrets)
+}
+
+func (s *FileStore) Delete(profile string, key string) error {
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[profile][key]; !ok {
+		return nil
+	}
+	delete(secrets[profile], key)
+	if len(secrets[profile]) == 0 {
+		delete(secrets, profile)
+	}
+	return s.write(secrets)
+}
+
+func (s *FileStore) read() (map[string]map[string]string, error) {
+	secrets := make(map[string]map[string]string)
+	info, err := os.Stat(s.Path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%s is accessible by other users, run: chmod 600 %s", s.Path, s.Path)
+	}
+	data, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %v", err)
+	}
+	return secrets, nil
+}
+
+func (s *FileStore) write(secrets map[string]map[string]string) error {
+	data, err := yaml.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(s.Path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(s.Path, 0600)
+}
+
+// secretTool is the libsecret CLI talking to the freedesktop Secret Service,
+// like GNOME Keyring or KWallet.
+var secretTool = "secret-tool"
+
+// keyringService tags the secrets of the CLI in the keyring.
+const keyringService = "newrelic-cli"
+
+// KeyringAvailable reports whether the keyring can be used.
+func KeyringAvailable() bool {
+	_, err := exec.LookPath(secretTool)
+	return err == nil
+}
+
+// KeyringStore keeps the secrets in the freedesktop Secret Service keyring,
+// through secret-tool.
+type KeyringStore struct{}
+
+func (s *KeyringStore) Name() string {
+	return "the keyring"
+}
+
+func keyringAttributes(profile string, key string) []string {
+	return []string{"service", keyringService, "profile", profile, "key", key}
+}
+
+func (s *KeyringStore) Get(profile string, key string) (string, error) {
+	cmd := exec.Command(secretTool, append([]string{"lookup"}, keyringAttributes(profile, key)...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok && stderr.Len() == 0 {
+			// secret-tool exits with 1 and says nothing when not found.
+			return "", nil
+		}
+		return "", fmt.Errorf("%v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+func (s *KeyringStore) Set(profile string, key string, value string) error {
+	label := fmt.Sprintf("NewRelic %s of profile %s", key, profile)
+	args := append([]string{"store", "--label", label}, keyringAttributes(profile, key)...)
+	cmd := exec.Command(secretTool, args...)
+	cmd.Stdin = strings.NewReader(value)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s *KeyringStore) Delete(profile string, key string) error {
+	cmd := exec.Command(secretTool, append([]string{"clear"}, keyringAttributes(profile, key)...)...)
+	if out, err := cmd.CombinedOutput(); err != nil && len(bytes.TrimSpace(out)) > 0 {
+		return fmt.Errorf("%v %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "nr", "credentials")}
+	if value, err := store.Get("prod", "api_key"); err != nil || value != "" {
+		t.Fatalf("Get on a missing file returned %q, %v", value, err)
+	}
+	if err := store.Set("prod", "api_key", "k1"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := store.Get("prod", "api_key"); err != nil || value != "k1" {
+		t.Errorf("Get returned %q, %v", value, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		os.Chmod(store.Path, 0644)
+		if _, err := store.Get("prod", "api_key"); err == nil {
+			t.Error("Get read a credentials file readable by others")
+		}
+		os.Chmod(store.Path, 0600)
+	}
+
+	if err := store.Delete("prod", "api_key"); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := store.Get("prod", "api_key"); value != "" {
+		t.Errorf("Get returned %q after Delete", value)
+	}
+}
+
+func TestProcessSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	source := &ProcessSource{Command: `printf '{"api_key":"%s"}' "$NEW_RELIC_PROFILE"`}
+	if value, err := source.Get("prod", "api_key"); err != nil || value != "prod" {
+		t.Errorf("Get returned %q, %v", value, err)
+	}
+	if value, err := source.Get("prod", "insert_key"); err != nil || value != "" {
+		t.Errorf("Get returned %q, %v for a missing key", value, err)
+	}
+
+	failing := &ProcessSource{Command: "echo not json"}
+	if _, err := failing.Get("prod", "api_key"); err == nil {
+		t.Error("Get accepted an output which is not JSON")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"strings"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/spf13/viper"
+)
+
+// endpointOverrides maps the config keys of the endpoint overrides to the
+// env vars that can set them.
+var endpointOverrides = map[string]string{
+	"endpoints.api":              "NEW_RELIC_API_URL",
+	"endpoints.synthetics":       "NEW_RELIC_SYNTHETICS_URL",
+	"endpoints.label_synthetics": "NEW_RELIC_LABEL_SYNTHETICS_URL",
+	"endpoints.insights":         "NEW_RELIC_INSIGHTS_URL",
+	"endpoints.infrastructure":   "NEW_RELIC_INFRASTRUCTURE_URL",
+	"endpoints.graphql":          "NEW_RELIC_GRAPHQL_URL",
+}
+
+func init() {
+	viper.BindEnv("region", "NEW_RELIC_REGION")
+	for key, env := range endpointOverrides {
+		viper.BindEnv(key, env)
+	}
+}
+
+// GetEndpoints returns the endpoints of the configured region (--region,
+// NEW_RELIC_REGION or "region" in the config file, US by default), with the
+// per endpoint overrides applied on top.
+func GetEndpoints() (newrelic.Endpoints, error) {
+	endpoints, err := newrelic.EndpointsForRegion(viper.GetString("region"))
+	if err != nil {
+		return endpoints, err
+	}
+
+	overrideEndpoint(&endpoints.API, "endpoints.api", true)
+	overrideEndpoint(&endpoints.Synthetics, "endpoints.synthetics", true)
+	overrideEndpoint(&endpoints.LabelSynthetics, "endpoints.label_synthetics", true)
+	overrideEndpoint(&endpoints.Insights, "endpoints.insights", true)
+	overrideEndpoint(&endpoints.Infrastructure, "endpoints.infrastructure", true)
+	overrideEndpoint(&endpoints.GraphQL, "endpoints.graphql", false)
+
+	return endpoints, nil
+}
+
+func overrideEndpoint(endpoint *string, key string, trailingSlash bool) {
+	value := viper.GetString(key)
+	if value == "" {
+		return
+	}
+	// REST paths are resolved relative to the endpoint, which only works if
+	// it ends with a slash.
+	if trailingSlash && !strings.HasSuffix(value, "/") {
+		value += "/"
+	}
+	*endpoint = value
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+)
+
+// ParseIncidentID returns the incident id of the single argument of the
+// incident commands, like nr ack incident.
+func ParseIncidentID(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("length of [flags] should be 1 instead of %d", len(args))
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q looks like a non-number.\n", args[0])
+	}
+	return id, nil
+}
+
+// UpdateIncident applies update, like AlertsIncidentService.Close, to the
+// incident id and returns the updated incident. action names the update in
+// the error.
+func UpdateIncident(ctx context.Context, id int64, action string, update func(*newrelic.AlertsIncidentService, context.Context, int64) (*newrelic.AlertsIncidentEntity, *newrelic.Response, error)) (*newrelic.AlertsIncidentEntity, error) {
+	client, err := GetNewRelicClient()
+	if err != nil {
+		return nil, err
+	}
+	incident, _, err := update(client.AlertsIncidents, ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to %s incident %d, %v", action, id, err)
+	}
+	return incident, nil
+}
@@ -0,0 +1,346 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a path in decoded JSON, the subset of JSONPath kubectl users
+// know: .name, ['name'], [0], [-1], [*] and .*, e.g. .options.locations[0].
+type JSONPath struct {
+	expr  string
+	steps []pathStep
+}
+
+type pathStep struct {
+	// field is the name of the field to take, "" for an index.
+	field string
+	index int
+	// all takes all the items of an array or all the fields of an object.
+	all bool
+}
+
+// ParseJSONPath parses expr, with or without the braces and the leading $
+// or @.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	p := &JSONPath{expr: expr}
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	s = strings.TrimLeft(s, "$@")
+
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".*"):
+			p.steps = append(p.steps, pathStep{all: true})
+			s = s[2:]
+		case s[0] == '.':
+			end := strings.IndexAny(s[1:], ".[")
+			if end < 0 {
+				end = len(s) - 1
+			}
+			name := s[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: empty field name", expr)
+			}
+			p.steps = append(p.steps, pathStep{field: name})
+			s = s[end+1:]
+		case s[0] == '[':
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing ]", expr)
+			}
+			inside := strings.TrimSpace(s[1:end])
+			switch {
+			case inside == "*":
+				p.steps = append(p.steps, pathStep{all: true})
+			case len(inside) >= 2 && (inside[0] == '\'' || inside[0] == '"') && inside[len(inside)-1] == inside[0]:
+				p.steps = append(p.steps, pathStep{field: inside[1 : len(inside)-1]})
+			default:
+				index, err := strconv.Atoi(inside)
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSONPath %q: unsupported [%s]", expr, inside)
+				}
+				p.steps = append(p.steps, pathStep{index: index})
+			}
+			s = s[end+1:]
+		default:
+			// A path without leading dot, like "name" in custom-columns.
+			if len(p.steps) == 0 {
+				s = "." + s
+				continue
+			}
+			return nil, fmt.Errorf("invalid JSONPath %q at %q", expr, s)
+		}
+	}
+	return p, nil
+}
+
+func (p *JSONPath) String() string {
+	return p.expr
+}
+
+// Find returns the values at the path in data, decoded JSON. A missing
+// field or index matches nothing.
+func (p *JSONPath) Find(data interface{}) []interface{} {
+	values, _ := p.find(data, false)
+	return values
+}
+
+// Lookup returns the values at the path in data like Find, but a missing
+// field or index is an error, as in the JSONPath templates of kubectl. A
+// wildcard over an empty array or object matches nothing without error.
+func (p *JSONPath) Lookup(data interface{}) ([]interface{}, error) {
+	return p.find(data, true)
+}
+
+func (p *JSONPath) find(data interface{}, strict bool) ([]interface{}, error) {
+	values := []interface{}{data}
+	for _, step := range p.steps {
+		var next []interface{}
+		for _, value := range values {
+			found, ok := step.apply(value)
+			if !ok && strict {
+				return nil, fmt.Errorf("%s is not found", step)
+			}
+			next = append(next, found...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func (step pathStep) String() string {
+	switch {
+	case step.all:
+		return "[*]"
+	case step.field != "":
+		return step.field
+	}
+	return fmt.Sprintf("[%d]", step.index)
+}
+
+// apply returns the values step takes in value, and whether value has them.
+func (step pathStep) apply(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case jsonObject:
+		if step.all {
+			var values []interface{}
+			for _, field := range v {
+				values = append(values, field.Value)
+			}
+			return values, true
+		}
+		if field, ok := v.get(step.field); ok && step.field != "" {
+			return []interface{}{field}, true
+		}
+	case map[string]interface{}:
+		if step.all {
+			var values []interface{}
+			for _, field := range v {
+				values = append(values, field)
+			}
+			return values, true
+		}
+		if field, ok := v[step.field]; ok && step.field != "" {
+			return []interface{}{field}, true
+		}
+	case []interface{}:
+		if step.all {
+			return v, true
+		}
+		if step.field != "" {
+			return nil, false
+		}
+		index := step.index
+		if index < 0 {
+			index += len(v)
+		}
+		if index >= 0 && index < len(v) {
+			return []interface{}{v[index]}, true
+		}
+	}
+	return nil, false
+}
+
+// JSONPathTemplate is a kubectl like JSONPath template: text with {path},
+// {"literal"} and {range path}...{end}, e.g.
+// {range .policies[*]}{.id}{"\t"}{.name}{"\n"}{end}
+type JSONPathTemplate struct {
+	nodes []templateNode
+}
+
+type templateNode struct {
+	text string
+	path *JSONPath
+	// rangeNodes are executed for every value of path.
+	rangeNodes []templateNode
+	isRange    bool
+}
+
+// ParseJSONPathTemplate parses a JSONPath template.
+func ParseJSONPathTemplate(text string) (*JSONPathTemplate, error) {
+	nodes, rest, err := parseTemplateNodes(text, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("invalid JSONPath template: {end} without {range}")
+	}
+	return &JSONPathTemplate{nodes: nodes}, nil
+}
+
+func parseTemplateNodes(text string, inRange bool) ([]templateNode, string, error) {
+	var nodes []templateNode
+	for text != "" {
+		start := strings.Index(text, "{")
+		if start < 0 {
+			nodes = append(nodes, templateNode{text: text})
+			return nodes, "", nil
+		}
+		if start > 0 {
+			nodes = append(nodes, templateNode{text: text[:start]})
+		}
+		end := closingBrace(text, start)
+		if end < 0 {
+			return nil, "", fmt.Errorf("invalid JSONPath template: unclosed { in %q", text[start:])
+		}
+		action := strings.TrimSpace(text[start+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nodes, "{end}", nil
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := ParseJSONPath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+			children, rest, err := parseTemplateNodes(text, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{path: path, rangeNodes: children, isRange: true})
+			text = rest
+		case strings.HasPrefix(action, `"`):
+			literal, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid JSONPath template: bad string %s", action)
+			}
+			nodes = append(nodes, templateNode{text: literal})
+		default:
+			path, err := ParseJSONPath(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, templateNode{path: path})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("invalid JSONPath template: {range} without {end}")
+	}
+	return nodes, "", nil
+}
+
+// closingBrace returns the index of the } closing the { at start, skipping
+// the braces in quoted strings.
+func closingBrace(text string, start int) int {
+	inString := false
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '}':
+			if !inString {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Execute writes the template applied to data, decoded JSON, to out.
+func (t *JSONPathTemplate) Execute(out io.Writer, data interface{}) error {
+	return executeNodes(out, t.nodes, data)
+}
+
+func executeNodes(out io.Writer, nodes []templateNode, data interface{}) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			values, err := node.path.Lookup(data)
+			if err != nil {
+				return fmt.Errorf("error executing JSONPath %q: %v", node.path, err)
+			}
+			for _, value := range values {
+				if err := executeNodes(out, node.rangeNodes, value); err != nil {
+					return err
+				}
+			}
+		case node.path != nil:
+			values, err := node.path.Lookup(data)
+			if err != nil {
+				return fmt.Errorf("error executing JSONPath %q: %v", node.path, err)
+			}
+			texts := make([]string, 0, len(values))
+			for _, value := range values {
+				texts = append(texts, formatValue(value))
+			}
+			if _, err := io.WriteString(out, strings.Join(texts, " ")); err != nil {
+				return err
+			}
+		default:
+			if _, err := io.WriteString(out, node.text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatValue returns a decoded JSON value as text: scalars as is, objects
+// and arrays as JSON.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(bytes.TrimSpace(data))
+}
@@ -16,17 +16,26 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
-	"reflect"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
+	"github.com/tidwall/pretty"
 )
 
+// OutputFormats are the formats of the --output flag.
+const OutputFormats = "json|yaml|ndjson|csv|table|wide|name|custom-columns=<HEADER>:<path>,...|jsonpath=<template>|go-template=<template>"
+
 type Printer interface {
 	Print(obj interface{}, out io.Writer)
 }
@@ -46,42 +55,140 @@ func GetArg(cmd *cobra.Command, arg string) (string, error) {
 	return val, err
 }
 
+// NewPriter returns the printer of the --output flag, sorting the items by
+// the --sort-by flag and filtering them by the selector flags if set.
 func NewPriter(cmd *cobra.Command) (Printer, error) {
-	flags := cmd.Flags()
+	output, err := GetArg(cmd, "output")
+	if err != nil {
+		return nil, err
+	}
+	sortBy, err := GetArg(cmd, "sort-by")
+	if err != nil {
+		return nil, err
+	}
 
-	var output string
-	var err error
-	if flags.Lookup("output") != nil {
-		output, err = cmd.Flags().GetString("output")
+	printer, err := NewOutputPrinter(output)
+	if err != nil {
+		return nil, err
+	}
+	if sortBy != "" {
+		path, err := ParseJSONPath(sortBy)
 		if err != nil {
-			return nil, fmt.Errorf("error accessing flag %s for command %s: %v", "output", cmd.Name(), err)
+			return nil, err
 		}
+		printer = &SortingPrinter{Printer: printer, SortBy: path}
+	}
+	selector, err := NewSelector(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if selector != nil {
+		printer = &SelectingPrinter{Printer: printer, Selector: selector}
+	}
+	return printer, nil
+}
+
+// NewOutputPrinter returns the printer of output, one of OutputFormats.
+func NewOutputPrinter(output string) (Printer, error) {
+	format, arg := output, ""
+	if i := strings.Index(output, "="); i >= 0 {
+		format, arg = output[:i], output[i+1:]
 	}
 
-	var printer Printer
-	switch output {
+	switch format {
 	case "yaml", "yml":
-		printer = &YAMLPrinter{}
+		return &YAMLPrinter{}, nil
 	case "json":
-		printer = &JSONPrinter{}
-	default:
-		printer = &TablePrinter{}
+		return &JSONPrinter{}, nil
+	case "ndjson":
+		return &NDJSONPrinter{}, nil
+	case "csv":
+		return &CSVPrinter{}, nil
+	case "", "table":
+		return &TablePrinter{}, nil
+	case "wide":
+		return &TablePrinter{Wide: true}, nil
+	case "name":
+		return &NamePrinter{}, nil
+	case "custom-columns":
+		columns, err := ParseCustomColumns(arg)
+		if err != nil {
+			return nil, err
+		}
+		return &TablePrinter{Columns: columns}, nil
+	case "jsonpath":
+		t, err := ParseJSONPathTemplate(arg)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONPathPrinter{Template: t}, nil
+	case "go-template":
+		t, err := template.New("output").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid go-template: %v", err)
+		}
+		return &GoTemplatePrinter{Template: t}, nil
 	}
-
-	return printer, nil
+	return nil, fmt.Errorf("Unsupported output format %q, must be one of %s.", output, OutputFormats)
 }
 
 type JSONPrinter struct{}
 
 type YAMLPrinter struct{}
 
-type TablePrinter struct{}
+// NDJSONPrinter prints the items of a list as JSON, one by line. It can be
+// given the pages of a list one by one, see Streams.
+type NDJSONPrinter struct{}
+
+// CSVPrinter prints the items of a list as CSV with a header, the nested
+// fields flattened into dotted columns like options.verifySSL.
+type CSVPrinter struct{}
+
+// TablePrinter prints the items of a list, or a single object, one by row.
+type TablePrinter struct {
+	// Wide adds the nested fields, as dotted columns like OPTIONS.VERIFYSSL,
+	// to the top level ones.
+	Wide bool
+	// Columns, if set, are the only columns printed.
+	Columns []Column
+}
+
+// Column is a column of a TablePrinter.
+type Column struct {
+	Header string
+	Path   *JSONPath
+}
+
+// NamePrinter prints the ID of each item, one by line.
+type NamePrinter struct{}
+
+// JSONPathPrinter prints a JSONPath template applied to the whole object, as
+// printed by -o json.
+type JSONPathPrinter struct {
+	Template *JSONPathTemplate
+}
+
+// GoTemplatePrinter prints a Go template applied to the whole object, as
+// printed by -o json.
+type GoTemplatePrinter struct {
+	Template *template.Template
+}
+
+// SortingPrinter sorts the items of the lists before printing them.
+type SortingPrinter struct {
+	Printer
+	SortBy *JSONPath
+}
 
 func (p *JSONPrinter) Print(obj interface{}, out io.Writer) {
+	if raw, ok := obj.(json.RawMessage); ok {
+		fmt.Fprintf(out, "%s\n", string(pretty.Pretty(raw)))
+		return
+	}
 	if output, err := json.MarshalIndent(obj, "", "  "); err != nil {
 		fmt.Println(err)
 	} else {
-		fmt.Printf("%s\n", string(output))
+		fmt.Fprintf(out, "%s\n", string(output))
 	}
 }
 
@@ -89,75 +196,499 @@ func (p *YAMLPrinter) Print(obj interface{}, out io.Writer) {
 	if output, err := yaml.Marshal(obj); err != nil {
 		fmt.Println(err)
 	} else {
-		fmt.Printf("%s\n", string(output))
+		fmt.Fprintf(out, "%s\n", string(output))
+	}
+}
+
+func (p *NDJSONPrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, item := range listItems(data) {
+		line, err := json.Marshal(item)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Fprintf(out, "%s\n", string(line))
+	}
+}
+
+func (p *CSVPrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	names, cells := flattenItems(listItems(data), true)
+	if len(names) == 0 {
+		return
+	}
+
+	w := csv.NewWriter(out)
+	w.Write(names)
+	for _, itemCells := range cells {
+		record := make([]string, len(names))
+		for i, name := range names {
+			record[i] = itemCells[name]
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// Streams reports whether printer can be given the pages of a list one by
+// one, as they are fetched, instead of the whole list.
+func Streams(printer Printer) bool {
+	if selecting, ok := printer.(*SelectingPrinter); ok {
+		printer = selecting.Printer
+	}
+	_, ok := printer.(*NDJSONPrinter)
+	return ok
+}
+
+// IsTabular reports whether printer prints the fields of the items as
+// columns, -o table, wide or csv, so a command may give it a flatter view of
+// its resources.
+func IsTabular(printer Printer) bool {
+	for {
+		switch p := printer.(type) {
+		case *SelectingPrinter:
+			printer = p.Printer
+		case *SortingPrinter:
+			printer = p.Printer
+		case *TablePrinter:
+			return len(p.Columns) == 0
+		case *CSVPrinter:
+			return true
+		default:
+			return false
+		}
 	}
 }
 
 func (p *TablePrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	items := listItems(data)
+	if len(items) == 0 {
+		fmt.Fprintln(out, "No resources found.")
+		return
+	}
+
+	var headers []string
+	rows := make([][]string, len(items))
+	if p.Columns != nil {
+		for _, column := range p.Columns {
+			headers = append(headers, column.Header)
+		}
+		for i, item := range items {
+			for _, column := range p.Columns {
+				var texts []string
+				for _, value := range column.Path.Find(item) {
+					texts = append(texts, formatValue(value))
+				}
+				rows[i] = append(rows[i], strings.Join(texts, ","))
+			}
+		}
+	} else {
+		names, cells := flattenItems(items, p.Wide)
+		for _, name := range names {
+			headers = append(headers, strings.ToUpper(name))
+		}
+		for i := range items {
+			for _, name := range names {
+				rows[i] = append(rows[i], cells[i][name])
+			}
+		}
+	}
+
 	w := new(tabwriter.Writer)
 	w.Init(out, 5, 1, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = strings.NewReplacer("\t", " ", "\n", " ", "\r", "").Replace(cell)
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
 
-	val := reflect.ValueOf(obj)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
-
-	// smartly detect if obj is a List or plain Object
-	if val.NumField() == 1 {
-		val = val.Field(0)
-		if val.Kind() == reflect.Ptr {
-			val = val.Elem()
-		}
-		if val.Kind() == reflect.Slice {
-			// iterate each Object
-			heading := []string{}
-			rows := []string{}
-			for i := 0; i < val.Len(); i++ {
-				e := val.Index(i)
-				if e.Kind() == reflect.Ptr {
-					e = e.Elem()
-				}
-				t := e.Type()
-				row := []string{}
-				for j := 0; j < e.NumField(); j++ {
-					f := e.Field(j)
-					if f.Kind() == reflect.Ptr {
-						f = f.Elem()
-					}
-					if i == 0 {
-						heading = append(heading, fmt.Sprint(t.Field(j).Name))
-					}
-					row = append(row, fmt.Sprint(f.Interface()))
-					// fmt.Printf("%d: %s %s = %v\n", j, t.Field(j).Name, f.Type(), f.Elem().Interface())
+// nameFields are the fields identifying an item, by preference.
+var nameFields = []string{"id", "key", "guid", "name"}
+
+func (p *NamePrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, item := range listItems(data) {
+		object, ok := item.(jsonObject)
+		if !ok {
+			continue
+		}
+		for _, name := range nameFields {
+			if value, ok := object.get(name); ok && value != nil && isScalar(value) {
+				fmt.Fprintln(out, formatValue(value))
+				break
+			}
+		}
+	}
+}
+
+func (p *JSONPathPrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Like kubectl, a path matching nothing fails the command rather than
+	// printing part of the template.
+	var buf bytes.Buffer
+	if err := p.Template.Execute(&buf, data); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	buf.WriteTo(out)
+}
+
+func (p *GoTemplatePrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := p.Template.Execute(out, plainJSON(data)); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (p *SortingPrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	switch v := data.(type) {
+	case []interface{}:
+		sortItems(v, p.SortBy)
+	case jsonObject:
+		if !hasScalarField(v) {
+			for _, field := range v {
+				if items, ok := field.Value.([]interface{}); ok {
+					sortItems(items, p.SortBy)
 				}
-				rows = append(rows, strings.Join(row, "\t"))
 			}
-			if len(heading) == 0 {
-				fmt.Fprintln(w, "No resources found.")
-			} else {
-				fmt.Fprintln(w, strings.Join(heading, "\t"))
-				fmt.Fprintln(w, strings.Join(rows, "\n"))
+		}
+	}
+	p.Printer.Print(data, out)
+}
+
+// ParseCustomColumns parses the columns of -o custom-columns, like
+// NAME:.name,TYPE:.type.
+func ParseCustomColumns(spec string) ([]Column, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("custom-columns needs <HEADER>:<path>,... like NAME:.name")
+	}
+	var columns []Column
+	for _, part := range strings.Split(spec, ",") {
+		i := strings.Index(part, ":")
+		if i <= 0 || i == len(part)-1 {
+			return nil, fmt.Errorf("invalid custom column %q, must be like NAME:.name", part)
+		}
+		path, err := ParseJSONPath(part[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, Column{Header: part[:i], Path: path})
+	}
+	return columns, nil
+}
+
+func sortItems(items []interface{}, path *JSONPath) {
+	key := func(item interface{}) interface{} {
+		if values := path.Find(item); len(values) > 0 {
+			return values[0]
+		}
+		return nil
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return lessJSON(key(items[i]), key(items[j]))
+	})
+}
+
+// lessJSON orders the missing values first, the numbers by value and the
+// rest as text.
+func lessJSON(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	if x, ok := a.(json.Number); ok {
+		if y, ok := b.(json.Number); ok {
+			fx, errx := x.Float64()
+			fy, erry := y.Float64()
+			if errx == nil && erry == nil {
+				return fx < fy
 			}
-		} else if val.Kind() == reflect.Struct {
-			t := val.Type()
-			heading := []string{}
-			row := []string{}
-			for j := 0; j < val.NumField(); j++ {
-				f := val.Field(j)
-				heading = append(heading, fmt.Sprint(t.Field(j).Name))
-				row = append(row, fmt.Sprint(f.Elem().Interface()))
-				// fmt.Printf("%d: %s %s = %v\n", j, t.Field(j).Name, f.Type(), f.Elem().Interface())
+		}
+	}
+	return formatValue(a) < formatValue(b)
+}
+
+// jsonObject is a decoded JSON object keeping the order of its fields, so the
+// columns follow the order of the API.
+type jsonObject []jsonField
+
+type jsonField struct {
+	Key   string
+	Value interface{}
+}
+
+func (o jsonObject) get(key string) (interface{}, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSON returns obj as decoded JSON: jsonObject, []interface{} and
+// scalars, with the numbers as json.Number. A json.RawMessage is decoded
+// as is.
+func decodeJSON(obj interface{}) (interface{}, error) {
+	if object, ok := obj.(jsonObject); ok {
+		return object, nil
+	}
+	data, ok := obj.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(obj); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrdered(dec)
+}
+
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := jsonObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
 			}
-			if len(heading) == 0 {
-				fmt.Fprintln(w, "No resources found.")
-			} else {
-				fmt.Fprintln(w, strings.Join(heading, "\t"))
-				fmt.Fprintln(w, strings.Join(row, "\t"))
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
 			}
+			object = append(object, jsonField{Key: key.(string), Value: value})
 		}
-	} else {
-		fmt.Println("[WARNING] unsupported data format.")
+		_, err = dec.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = dec.Token()
+		return array, err
 	}
+	return token, nil
+}
 
-	w.Flush()
+// plainJSON returns data with maps for the objects and float64 or int64 for
+// the numbers, as text/template expects.
+func plainJSON(data interface{}) interface{} {
+	switch v := data.(type) {
+	case jsonObject:
+		m := make(map[string]interface{}, len(v))
+		for _, field := range v {
+			m[field.Key] = plainJSON(field.Value)
+		}
+		return m
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, value := range v {
+			array[i] = plainJSON(value)
+		}
+		return array
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return data
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case jsonObject, []interface{}:
+		return false
+	}
+	return true
+}
+
+func hasScalarField(object jsonObject) bool {
+	for _, field := range object {
+		if field.Value != nil && isScalar(field.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// listItems returns the items of data: the elements of an array, those of
+// the arrays of a list object like {"policies": [...], "links": {...}},
+// the object wrapped in one like {"dashboard": {...}}, or data itself.
+func listItems(data interface{}) []interface{} {
+	switch v := data.(type) {
+	case []interface{}:
+		return v
+	case jsonObject:
+		if hasScalarField(v) {
+			return []interface{}{v}
+		}
+		items := []interface{}{}
+		isList := false
+		for _, field := range v {
+			if array, ok := field.Value.([]interface{}); ok {
+				items = append(items, array...)
+				isList = true
+			}
+		}
+		if isList {
+			return items
+		}
+		if len(v) == 1 {
+			if object, ok := v[0].Value.(jsonObject); ok {
+				return []interface{}{object}
+			}
+		}
+		return nil
+	case nil:
+		return nil
+	}
+	return []interface{}{data}
+}
+
+// flattenItems returns the names of the fields of all the items, in order of
+// first appearance since the empty fields are omitted, and the fields of
+// each item by name.
+func flattenItems(items []interface{}, nested bool) ([]string, []map[string]string) {
+	var names []string
+	seen := make(map[string]bool)
+	cells := make([]map[string]string, len(items))
+	for i, item := range items {
+		cells[i] = make(map[string]string)
+		for _, field := range flattenJSON("", item, nested, nil) {
+			if !seen[field.Name] {
+				seen[field.Name] = true
+				names = append(names, field.Name)
+			}
+			cells[i][field.Name] = field.Value
+		}
+	}
+	return names, cells
+}
+
+// flatField is a field of a flattened object.
+type flatField struct {
+	Name  string
+	Value string
+}
+
+// flattenJSON appends the fields of value, named after their dotted path
+// like options.verifySSL, to fields. Arrays of scalars are joined by
+// commas. The nested objects and arrays of objects are only flattened if
+// nested is set, with the array indexes in the path like terms.0.duration.
+func flattenJSON(name string, value interface{}, nested bool, fields []flatField) []flatField {
+	switch v := value.(type) {
+	case jsonObject:
+		if name != "" && !nested {
+			return fields
+		}
+		for _, field := range v {
+			fields = flattenJSON(joinFieldName(name, field.Key), field.Value, nested, fields)
+		}
+		return fields
+	case []interface{}:
+		texts := make([]string, 0, len(v))
+		for _, element := range v {
+			if !isScalar(element) {
+				break
+			}
+			texts = append(texts, formatValue(element))
+		}
+		if len(texts) == len(v) {
+			return append(fields, flatField{Name: fieldName(name), Value: strings.Join(texts, ",")})
+		}
+		if nested {
+			for i, element := range v {
+				fields = flattenJSON(joinFieldName(name, strconv.Itoa(i)), element, nested, fields)
+			}
+		}
+		return fields
+	}
+	return append(fields, flatField{Name: fieldName(name), Value: formatValue(value)})
+}
+
+func joinFieldName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func fieldName(name string) string {
+	if name == "" {
+		return "value"
+	}
+	return name
 }
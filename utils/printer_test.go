@@ -0,0 +1,240 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type testMonitor struct {
+	ID      *string            `json:"id,omitempty"`
+	Name    *string            `json:"name,omitempty"`
+	Options *testMonitorOption `json:"options,omitempty"`
+	Tags    []string           `json:"tags,omitempty"`
+}
+
+type testMonitorOption struct {
+	VerifySSL bool `json:"verifySSL"`
+}
+
+type testConditionList struct {
+	Conditions     []map[string]interface{} `json:"conditions,omitempty"`
+	NRQLConditions []map[string]interface{} `json:"nrql_conditions,omitempty"`
+	Links          map[string]string        `json:"links,omitempty"`
+}
+
+func printWith(t *testing.T, output string, sortBy string, obj interface{}) string {
+	t.Helper()
+	printer, err := NewOutputPrinter(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sortBy != "" {
+		path, err := ParseJSONPath(sortBy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		printer = &SortingPrinter{Printer: printer, SortBy: path}
+	}
+	var out bytes.Buffer
+	printer.Print(obj, &out)
+	return out.String()
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestTablePrinter(t *testing.T) {
+	monitors := []*testMonitor{
+		{ID: strPtr("m1"), Name: strPtr("ping"), Options: &testMonitorOption{VerifySSL: true}, Tags: []string{"a", "b"}},
+		{ID: strPtr("m2")},
+	}
+
+	got := printWith(t, "table", "", monitors)
+	want := "ID   NAME   TAGS\n" +
+		"m1   ping   a,b\n" +
+		"m2          \n"
+	if got != want {
+		t.Errorf("table:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = printWith(t, "wide", "", monitors)
+	want = "ID   NAME   OPTIONS.VERIFYSSL   TAGS\n" +
+		"m1   ping   true                a,b\n" +
+		"m2                              \n"
+	if got != want {
+		t.Errorf("wide:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = printWith(t, "custom-columns=MONITOR:.name,SSL:.options.verifySSL", "", monitors)
+	want = "MONITOR   SSL\n" +
+		"ping      true\n" +
+		"          \n"
+	if got != want {
+		t.Errorf("custom-columns:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTablePrinterJoinsListsOfObject(t *testing.T) {
+	conditions := &testConditionList{
+		Conditions:     []map[string]interface{}{{"id": 2, "name": "apm"}},
+		NRQLConditions: []map[string]interface{}{{"id": 1, "name": "nrql"}},
+		Links:          map[string]string{"policy": "/v2/policies/{id}"},
+	}
+	got := printWith(t, "table", "", conditions)
+	want := "ID   NAME\n" +
+		"2    apm\n" +
+		"1    nrql\n"
+	if got != want {
+		t.Errorf("table:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := printWith(t, "table", "", &testConditionList{}); got != "No resources found.\n" {
+		t.Errorf("empty list printed %q", got)
+	}
+}
+
+func TestPrintersUnwrapSingleObject(t *testing.T) {
+	dashboard := json.RawMessage(`{"dashboard": {"id": 7, "title": "Ops", "widgets": [{"title": "cpu"}]}}`)
+	if got := printWith(t, "name", "", dashboard); got != "7\n" {
+		t.Errorf("name printed %q", got)
+	}
+	want := "ID   TITLE\n7    Ops\n"
+	if got := printWith(t, "table", "", dashboard); got != want {
+		t.Errorf("table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSortingPrinter(t *testing.T) {
+	policies := json.RawMessage(`{"policies": [{"id": 10, "name": "b"}, {"id": 9, "name": "c"}, {"id": 100, "name": "a"}]}`)
+	if got := printWith(t, "name", ".id", policies); got != "9\n10\n100\n" {
+		t.Errorf("sorted by id: %q", got)
+	}
+	if got := printWith(t, "name", "{.name}", policies); got != "100\n10\n9\n" {
+		t.Errorf("sorted by name: %q", got)
+	}
+	want := `{
+  "policies": [
+    {
+      "id": 100,
+      "name": "a"
+    },
+    {
+      "id": 10,
+      "name": "b"
+    },
+    {
+      "id": 9,
+      "name": "c"
+    }
+  ]
+}
+`
+	if got := printWith(t, "json", ".name", policies); got != want {
+		t.Errorf("json sorted by name:\n%s", got)
+	}
+}
+
+func TestTemplatePrinters(t *testing.T) {
+	policies := json.RawMessage(`{"policies": [{"id": 1, "name": "a", "tags": ["x", "y"]}, {"id": 2, "name": "b"}]}`)
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{`jsonpath={.policies[*].name}`, "a b"},
+		{`jsonpath={.policies[-1].id}`, "2"},
+		{`jsonpath={.policies[0]['tags'][1]}`, "y"},
+		{`jsonpath={range .policies[*]}{.id}{"\t"}{.name}{"\n"}{end}`, "1\ta\n2\tb\n"},
+		{`jsonpath={.policies[0].tags}`, `["x","y"]`},
+		{`go-template={{range .policies}}{{if eq .id 2}}{{.name}}{{end}}{{end}}`, "b"},
+	}
+	for _, test := range tests {
+		if got := printWith(t, test.output, "", policies); got != test.want {
+			t.Errorf("-o %s printed %q, want %q", test.output, got, test.want)
+		}
+	}
+}
+
+func TestJSONPathTemplateMissingKeys(t *testing.T) {
+	policies, _ := decodeJSON(json.RawMessage(`{"policies": [{"id": 1, "name": "a"}, {"id": 2}], "channels": []}`))
+	for _, text := range []string{`{.policy}`, `{.policies[2].id}`, `{.policies[*].name}`, `{[*].name}`, `{range .policies[*]}{.name}{end}`} {
+		template, err := ParseJSONPathTemplate(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := template.Execute(&out, policies); err == nil {
+			t.Errorf("%s printed %q, want an error", text, out.String())
+		}
+	}
+
+	template, _ := ParseJSONPathTemplate(`{.channels[*].name}`)
+	var out bytes.Buffer
+	if err := template.Execute(&out, policies); err != nil || out.Len() != 0 {
+		t.Errorf("wildcard over an empty list printed %q, %v", out.String(), err)
+	}
+}
+
+func TestNewOutputPrinterRejectsInvalidFormats(t *testing.T) {
+	for _, output := range []string{"xml", "custom-columns=", "custom-columns=NAME", "jsonpath={range .a}", "jsonpath={.a", "go-template={{"} {
+		if _, err := NewOutputPrinter(output); err == nil {
+			t.Errorf("-o %s was accepted", output)
+		}
+	}
+}
+
+func TestCSVPrinterFlattensNestedFields(t *testing.T) {
+	conditions := json.RawMessage(`{"conditions": [
+		{"id": 1, "name": "cpu, high", "entities": ["10", "11"], "terms": [{"duration": "5", "threshold": "90"}, {"duration": "10", "threshold": "80"}]},
+		{"id": 2, "name": "apdex", "user_defined": {"metric": "apdex"}}
+	]}`)
+	got := printWith(t, "csv", "", conditions)
+	want := "id,name,entities,terms.0.duration,terms.0.threshold,terms.1.duration,terms.1.threshold,user_defined.metric\n" +
+		"1,\"cpu, high\",\"10,11\",5,90,10,80,\n" +
+		"2,apdex,,,,,,apdex\n"
+	if got != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := printWith(t, "csv", "", json.RawMessage(`{"conditions": []}`)); got != "" {
+		t.Errorf("empty list printed %q", got)
+	}
+}
+
+func TestNDJSONPrinter(t *testing.T) {
+	printer, err := NewOutputPrinter("ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Streams(printer) {
+		t.Error("ndjson does not stream")
+	}
+	var out bytes.Buffer
+	printer.Print(json.RawMessage(`{"policies": [{"name": "b", "id": 1}], "links": {}}`), &out)
+	printer.Print(json.RawMessage(`{"policies": [{"name": "a", "id": 2}]}`), &out)
+	want := "{\"name\":\"b\",\"id\":1}\n{\"name\":\"a\",\"id\":2}\n"
+	if out.String() != want {
+		t.Errorf("ndjson printed %q, want %q", out.String(), want)
+	}
+
+	sorted := &SortingPrinter{Printer: printer}
+	if Streams(sorted) {
+		t.Error("sorted ndjson streams")
+	}
+}
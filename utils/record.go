@@ -0,0 +1,264 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Fixture is one HTTP call saved by --record and served by --replay.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+type FixtureRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type FixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+var fixtureNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// RecordTransport saves every call made through Transport in Dir, one
+// fixture file per call, with the secrets redacted.
+type RecordTransport struct {
+	Dir       string
+	Transport http.RoundTripper
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRecordTransport returns a RecordTransport saving in dir, which is
+// created if missing. Fixtures are numbered after the ones already in dir,
+// so several commands can be recorded in the same dir.
+func NewRecordTransport(dir string, transport http.RoundTripper) (*RecordTransport, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &RecordTransport{Dir: dir, Transport: transport, next: len(existing) + 1}, nil
+}
+
+func (t *RecordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		reqBody, _ = ioutil.ReadAll(body)
+		body.Close()
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	fixture := Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: RedactHeader(req.Header),
+			Body:   string(RedactBody(reqBody)),
+		},
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     RedactHeader(resp.Header),
+			Body:       string(RedactBody(respBody)),
+		},
+	}
+	if err := t.save(&fixture); err != nil {
+		return nil, fmt.Errorf("Failed to record %s %s: %v", req.Method, req.URL, err)
+	}
+	return resp, nil
+}
+
+func (t *RecordTransport) save(fixture *Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// e.g. 0001-GET-v2-alerts_policies.json
+	path := strings.TrimSuffix(fixtureURLPath(fixture.Request.URL), ".json")
+	path = strings.Trim(fixtureNameChars.ReplaceAllString(path, "-"), "-")
+	name := fmt.Sprintf("%04d-%s-%s.json", t.next, fixture.Request.Method, path)
+	t.next++
+	return ioutil.WriteFile(filepath.Join(t.Dir, name), data, 0600)
+}
+
+func fixtureURLPath(rawURL string) string {
+	if i := strings.Index(rawURL, "://"); i >= 0 {
+		rawURL = rawURL[i+3:]
+	}
+	if i := strings.IndexAny(rawURL, "/"); i >= 0 {
+		rawURL = rawURL[i:]
+	}
+	if i := strings.IndexAny(rawURL, "?"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	return rawURL
+}
+
+// ReplayTransport answers calls with the responses saved by a
+// RecordTransport, without any network. Calls are matched on their method
+// and URL, a call made several times gets the recorded responses in order.
+type ReplayTransport struct {
+	Dir string
+
+	mu        sync.Mutex
+	responses map[string][]FixtureResponse
+}
+
+// NewReplayTransport returns a ReplayTransport serving the fixtures in dir.
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No recorded calls found in %s.", dir)
+	}
+	sort.Strings(files)
+
+	t := &ReplayTransport{Dir: dir, responses: make(map[string][]FixtureResponse)}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("Invalid recorded call %s: %v", file, err)
+		}
+		key := fixture.Request.Method + " " + fixture.Request.URL
+		t.responses[key] = append(t.responses[key], fixture.Response)
+	}
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := req.Method + " " + req.URL.String()
+	t.mu.Lock()
+	responses := t.responses[key]
+	if len(responses) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("No recorded response left for %s in %s.", key, t.Dir)
+	}
+	recorded := responses[0]
+	// The last response stays to answer any later identical call.
+	if len(responses) > 1 {
+		t.responses[key] = responses[1:]
+	}
+	t.mu.Unlock()
+
+	header := recorded.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+var (
+	recordReplayMu        sync.Mutex
+	recordReplayTransport http.RoundTripper
+)
+
+// wrapRecordReplay returns httpClient with its transport wrapped to record
+// or replay the calls if --record or --replay is set, else httpClient. All
+// clients of a command share the same recording.
+func wrapRecordReplay(httpClient *http.Client) (*http.Client, error) {
+	recordDir := viper.GetString("record")
+	replayDir := viper.GetString("replay")
+	if recordDir == "" && replayDir == "" {
+		return httpClient, nil
+	}
+	if recordDir != "" && replayDir != "" {
+		return nil, fmt.Errorf("--record and --replay can not be used together.")
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	wrapped := *httpClient
+
+	recordReplayMu.Lock()
+	defer recordReplayMu.Unlock()
+	if recordReplayTransport == nil {
+		var err error
+		if recordDir != "" {
+			recordReplayTransport, err = NewRecordTransport(recordDir, httpClient.Transport)
+		} else {
+			recordReplayTransport, err = NewReplayTransport(replayDir)
+		}
+		if err != nil {
+			recordReplayTransport = nil
+			return nil, err
+		}
+	}
+	wrapped.Transport = recordReplayTransport
+	return &wrapped, nil
+}
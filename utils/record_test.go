@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/IBM/newrelic-cli/newrelic/nrtest"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := nrtest.NewServer()
+
+	recorder, err := NewRecordTransport(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newrelic.NewClientWithEndpoints(&http.Client{Transport: recorder}, "default", server.Endpoints())
+	client.XApiKey = "my-secret-key"
+
+	name, secret := "ops", "hunter2"
+	channel := &newrelic.AlertsChannelEntity{AlertsChannel: &newrelic.AlertsChannel{
+		Name:          &name,
+		Type:          "webhook",
+		Configuration: &newrelic.ChannelWebhookConfig{AuthPassword: &secret},
+	}}
+	if _, _, err := client.AlertsChannels.Create(context.Background(), channel); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	recorded, _ := client.AlertsChannels.ListAllPages(context.Background(), nil)
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 || filepath.Base(files[0]) != "0001-POST-v2-alerts_channels.json" {
+		t.Fatalf("got fixtures %v", files)
+	}
+	for _, file := range files {
+		data, _ := ioutil.ReadFile(file)
+		if strings.Contains(string(data), "my-secret-key") || strings.Contains(string(data), secret) {
+			t.Errorf("%s holds a secret:\n%s", file, data)
+		}
+	}
+
+	replayer, err := NewReplayTransport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = newrelic.NewClientWithEndpoints(&http.Client{Transport: replayer}, "default", server.Endpoints())
+	client.Retries = 1
+	replayed, err := client.AlertsChannels.ListAllPages(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("replayed ListAllPages returned error: %v", err)
+	}
+	if len(replayed.AlertsChannels) != 1 || *replayed.AlertsChannels[0].ID != *recorded.AlertsChannels[0].ID {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+	if _, err := client.Users.ListAllPages(context.Background(), nil); err == nil {
+		t.Error("a call never recorded was answered")
+	}
+}
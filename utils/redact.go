@@ -0,0 +1,131 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secrets in recorded and logged HTTP calls.
+const Redacted = "REDACTED"
+
+// secretHeaders are the headers carrying credentials.
+var secretHeaders = []string{
+	"X-Api-Key",
+	"X-Insert-Key",
+	"X-Query-Key",
+	"Api-Key",
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// secretFields are the JSON fields holding secrets wherever they are, like
+// the credentials in the configuration of alert channels.
+var secretFields = map[string]bool{
+	"auth_password": true,
+	"auth_token":    true,
+	"service_key":   true,
+	"api_key":       true,
+}
+
+// configurationSecretFields are the fields holding secrets only in the
+// configuration of an alert channel, like the key of a VictorOps channel.
+// Elsewhere, like in labels, they are plain data.
+var configurationSecretFields = map[string]bool{
+	"key":      true,
+	"token":    true,
+	"password": true,
+}
+
+// RedactHeader returns a copy of header with the credentials masked.
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range secretHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted.Set(name, Redacted)
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns body with the values of the secret fields masked if it
+// is JSON, else body unchanged.
+func RedactBody(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+	if !redactValue(v, "") {
+		return body
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// redactValue masks the secret fields in v, the value of the field named
+// parent, and reports whether there were any.
+func redactValue(v interface{}, parent string) bool {
+	var redacted bool
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, field := range value {
+			if _, ok := field.(string); ok && (secretFields[k] || parent == "configuration" && configurationSecretFields[k]) {
+				value[k] = Redacted
+				redacted = true
+				continue
+			}
+			if headers, ok := field.(map[string]interface{}); ok && parent == "configuration" && k == "headers" {
+				// The custom headers of a webhook channel.
+				redacted = redactHeaderFields(headers) || redacted
+			}
+			redacted = redactValue(field, k) || redacted
+		}
+	case []interface{}:
+		for _, item := range value {
+			redacted = redactValue(item, parent) || redacted
+		}
+	}
+	return redacted
+}
+
+func redactHeaderFields(headers map[string]interface{}) bool {
+	var redacted bool
+	for name := range headers {
+		for _, secret := range secretHeaders {
+			if strings.EqualFold(name, secret) {
+				headers[name] = Redacted
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
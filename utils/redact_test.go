@@ -0,0 +1,57 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := `{"channels":[
+		{"type":"webhook","configuration":{"base_url":"https://a","auth_password":"p1","headers":{"authorization":"p2"}}},
+		{"type":"pagerduty","configuration":{"service_key":"p3"}},
+		{"type":"opsgenie","configuration":{"api_key":"p4","teams":"ops"}},
+		{"type":"victorops","configuration":{"key":"p5","route_key":"ops"}}]}`
+
+	redacted := string(RedactBody([]byte(body)))
+	for _, secret := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		if strings.Contains(redacted, `"`+secret+`"`) {
+			t.Errorf("secret %s left in %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"teams":"ops"`) || !strings.Contains(redacted, `"base_url":"https://a"`) {
+		t.Errorf("non secret fields changed: %s", redacted)
+	}
+	labels := `{"labels":[{"key":"Env:Production","category":"Env","name":"Production"}],"token":"t"}`
+	if got := string(RedactBody([]byte(labels))); got != labels {
+		t.Errorf("got %s, want the labels unchanged", got)
+	}
+	if got := string(RedactBody([]byte("not json"))); got != "not json" {
+		t.Errorf("got %q for a non JSON body", got)
+	}
+}
+
+func TestCurlCommand(t *testing.T) {
+	req, _ := http.NewRequest("POST", "https://api.newrelic.com/v2/alerts_policies.json", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	got := curlCommand(req, []byte(`{"policy":{"name":"it's"}}`))
+	want := `curl -X POST 'https://api.newrelic.com/v2/alerts_policies.json' -H 'X-Api-Key: REDACTED' --data-raw '{"policy":{"name":"it'\''s"}}'`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
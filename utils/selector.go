@@ -0,0 +1,397 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Selector filters resources client side, on the fields of their JSON: all
+// its requirements must match.
+type Selector struct {
+	requirements []requirement
+}
+
+type requirement struct {
+	path *JSONPath
+	// op is one of =, !=, =~, !~, exists and !exists.
+	op    string
+	value string
+	re    *regexp.Regexp
+	// label and tag, if set, match the labels or the tags of a monitor
+	// instead.
+	label string
+	tag   *tagRequirement
+}
+
+type tagRequirement struct {
+	key   string
+	value string
+}
+
+// selectorOps are the operators of a selector requirement, the longest first.
+var selectorOps = []string{"==", "!=", "=~", "!~", "="}
+
+// ParseSelector parses a comma separated list of requirements on the fields
+// of a resource, given as paths like name or options.verifySSL:
+//
+//	type=SCRIPT_BROWSER,status!=DISABLED,name=~^prod-,!script
+//
+// field=value and field==value match an equal field, field!=value a
+// different or missing one, field=~regexp and field!~regexp a matching or
+// not matching one, field a set one and !field a missing or empty one. A field
+// holding a list matches if any of its elements does.
+func ParseSelector(expr string) (*Selector, error) {
+	s := &Selector{}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+func parseRequirement(part string) (requirement, error) {
+	r := requirement{}
+	key := part
+	for i := 0; i < len(part) && r.op == ""; i++ {
+		for _, op := range selectorOps {
+			if strings.HasPrefix(part[i:], op) {
+				key, r.op, r.value = part[:i], op, part[i+len(op):]
+				break
+			}
+		}
+	}
+	switch r.op {
+	case "":
+		r.op = "exists"
+		if strings.HasPrefix(key, "!") {
+			r.op, key = "!exists", key[1:]
+		}
+	case "==":
+		r.op = "="
+	case "=~", "!~":
+		re, err := regexp.Compile(r.value)
+		if err != nil {
+			return r, fmt.Errorf("invalid selector %q: %v", part, err)
+		}
+		r.re = re
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return r, fmt.Errorf("invalid selector %q: missing field", part)
+	}
+	path, err := ParseJSONPath(key)
+	if err != nil {
+		return r, fmt.Errorf("invalid selector %q: %v", part, err)
+	}
+	r.path = path
+	return r, nil
+}
+
+// AddLabel requires the monitors to have label, like Env:Production. Labels
+// are compared case insensitively.
+func (s *Selector) AddLabel(label string) error {
+	if !strings.Contains(label, ":") {
+		return fmt.Errorf("invalid label %q, must be like category:value", label)
+	}
+	s.requirements = append(s.requirements, requirement{label: label})
+	return nil
+}
+
+// AddTag requires the resources to have the tag key=value, or the tag key
+// with any value if tag has no =.
+func (s *Selector) AddTag(tag string) error {
+	t := &tagRequirement{key: tag}
+	if i := strings.Index(tag, "="); i >= 0 {
+		t.key, t.value = tag[:i], tag[i+1:]
+	}
+	if t.key == "" {
+		return fmt.Errorf("invalid tag %q, must be like key=value", tag)
+	}
+	s.requirements = append(s.requirements, requirement{tag: t})
+	return nil
+}
+
+// Matches reports whether obj, a resource or its decoded JSON, matches all
+// the requirements.
+func (s *Selector) Matches(obj interface{}) bool {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		return false
+	}
+	for _, r := range s.requirements {
+		if !r.matches(data) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r requirement) matches(item interface{}) bool {
+	if r.label != "" {
+		for _, label := range fieldTexts(item, "labels") {
+			if strings.EqualFold(label, r.label) {
+				return true
+			}
+		}
+		return false
+	}
+	if r.tag != nil {
+		tags, _ := fieldValue(item, "tags").([]interface{})
+		for _, tag := range tags {
+			if formatValue(fieldValue(tag, "key")) != r.tag.key {
+				continue
+			}
+			if r.tag.value == "" {
+				return true
+			}
+			for _, value := range fieldTexts(tag, "values") {
+				if value == r.tag.value {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var texts []string
+	for _, value := range r.path.Find(item) {
+		if values, ok := value.([]interface{}); ok {
+			for _, v := range values {
+				texts = append(texts, formatValue(v))
+			}
+		} else if value != nil {
+			texts = append(texts, formatValue(value))
+		}
+	}
+
+	anyText := func(match func(string) bool) bool {
+		for _, text := range texts {
+			if match(text) {
+				return true
+			}
+		}
+		return false
+	}
+	switch r.op {
+	case "=":
+		return anyText(func(text string) bool { return text == r.value })
+	case "!=":
+		return !anyText(func(text string) bool { return text == r.value })
+	case "=~":
+		return anyText(r.re.MatchString)
+	case "!~":
+		return !anyText(r.re.MatchString)
+	case "exists":
+		return anyText(func(text string) bool { return text != "" })
+	case "!exists":
+		return !anyText(func(text string) bool { return text != "" })
+	}
+	return false
+}
+
+func fieldValue(item interface{}, name string) interface{} {
+	if object, ok := item.(jsonObject); ok {
+		value, _ := object.get(name)
+		return value
+	}
+	return nil
+}
+
+func fieldTexts(item interface{}, name string) []string {
+	values, _ := fieldValue(item, name).([]interface{})
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, formatValue(value))
+	}
+	return texts
+}
+
+// Filter returns the items of obj, a list or a single resource, matching the
+// selector, as decoded JSON.
+func (s *Selector) Filter(obj interface{}) ([]interface{}, error) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	var items []interface{}
+	for _, item := range listItems(data) {
+		if s.Matches(item) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// ItemID returns the ID of item, an element returned by Filter.
+func ItemID(item interface{}) string {
+	for _, name := range nameFields[:3] {
+		if value := fieldValue(item, name); value != nil && isScalar(value) {
+			return formatValue(value)
+		}
+	}
+	return ""
+}
+
+// ItemName returns the name, or title, of item, an element returned by
+// Filter.
+func ItemName(item interface{}) string {
+	for _, name := range []string{"name", "title"} {
+		if value := fieldValue(item, name); value != nil && isScalar(value) {
+			return formatValue(value)
+		}
+	}
+	return ""
+}
+
+// AddSelectorFlags adds the --selector and --tag flags read by NewSelector
+// to flags.
+func AddSelectorFlags(flags *pflag.FlagSet) {
+	flags.String("selector", "", "Only the resources whose fields match, e.g. 'type=SCRIPT_BROWSER,status!=DISABLED,name=~^prod-'. Operators are =, !=, =~ (regexp), !~, field (set) and !field (not set)")
+	flags.StringArray("tag", nil, "Only the resources having this tag, like key=value or key. Can be repeated")
+}
+
+// AddLabelFlag adds the --label flag read by NewSelector to flags, for the
+// commands on Synthetics monitors.
+func AddLabelFlag(flags *pflag.FlagSet) {
+	flags.StringArray("label", nil, "Only the monitors having this label, like category:value. Can be repeated")
+}
+
+// NewSelector returns the selector of the --selector, --label and --tag
+// flags of cmd, nil if none is set.
+func NewSelector(cmd *cobra.Command) (*Selector, error) {
+	expr, err := GetArg(cmd, "selector")
+	if err != nil {
+		return nil, err
+	}
+	var labels, tags []string
+	if cmd.Flags().Lookup("label") != nil {
+		if labels, err = cmd.Flags().GetStringArray("label"); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Flags().Lookup("tag") != nil {
+		if tags, err = cmd.Flags().GetStringArray("tag"); err != nil {
+			return nil, err
+		}
+	}
+	if expr == "" && len(labels) == 0 && len(tags) == 0 {
+		return nil, nil
+	}
+
+	selector, err := ParseSelector(expr)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		if err := selector.AddLabel(label); err != nil {
+			return nil, err
+		}
+	}
+	for _, tag := range tags {
+		if err := selector.AddTag(tag); err != nil {
+			return nil, err
+		}
+	}
+	return selector, nil
+}
+
+// HasSelector reports whether the --selector, --label or --tag flag of cmd
+// is set.
+func HasSelector(cmd *cobra.Command) bool {
+	for _, name := range []string{"selector", "label", "tag"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectingPrinter only prints the items matching Selector.
+type SelectingPrinter struct {
+	Printer
+	Selector *Selector
+}
+
+func (p *SelectingPrinter) Print(obj interface{}, out io.Writer) {
+	data, err := decodeJSON(obj)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	p.Printer.Print(p.filter(data), out)
+}
+
+// Unselected returns printer without its selector, for the commands selecting
+// the items themselves before printing them as rows.
+func Unselected(printer Printer) Printer {
+	if p, ok := printer.(*SelectingPrinter); ok {
+		return p.Printer
+	}
+	return printer
+}
+
+// filter returns data without the items not matching, keeping its shape:
+// an array, a list object or a single object, an empty array if it does
+// not match.
+func (p *SelectingPrinter) filter(data interface{}) interface{} {
+	keep := func(items []interface{}) []interface{} {
+		kept := []interface{}{}
+		for _, item := range items {
+			if p.Selector.Matches(item) {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+
+	switch v := data.(type) {
+	case []interface{}:
+		return keep(v)
+	case jsonObject:
+		if hasScalarField(v) {
+			return keep([]interface{}{v})
+		}
+		filtered := jsonObject{}
+		isList := false
+		for _, field := range v {
+			if items, ok := field.Value.([]interface{}); ok {
+				field.Value = keep(items)
+				isList = true
+			}
+			filtered = append(filtered, field)
+		}
+		if isList {
+			return filtered
+		}
+		return keep(listItems(v))
+	}
+	return data
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+var selectorMonitors = json.RawMessage(`[
+	{"id": "m1", "name": "prod-login", "type": "SCRIPT_BROWSER", "status": "ENABLED", "labels": ["Env:Production"],
+	 "tags": [{"key": "team", "values": ["web", "ops"]}], "options": {"verifySSL": true}},
+	{"id": "m2", "name": "prod-api", "type": "SCRIPT_API", "status": "DISABLED", "tags": [{"key": "team", "values": ["api"]}]},
+	{"id": "m3", "name": "test-login", "type": "SCRIPT_BROWSER", "status": "ENABLED"}
+]`)
+
+func selectedIDs(t *testing.T, selector *Selector) string {
+	t.Helper()
+	items, err := selector.Filter(selectorMonitors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, ItemID(item))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSelector(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"type=SCRIPT_BROWSER", "m1,m3"},
+		{"type==SCRIPT_API", "m2"},
+		{"type=SCRIPT_BROWSER,status!=DISABLED,name=~^prod-", "m1"},
+		{"name!~login$", "m2"},
+		{"options.verifySSL=true", "m1"},
+		{"labels", "m1"},
+		{"!tags", "m3"},
+		{"tags[*].values=ops", "m1"},
+		{"", "m1,m2,m3"},
+	}
+	for _, test := range tests {
+		selector, err := ParseSelector(test.expr)
+		if err != nil {
+			t.Errorf("%q: %v", test.expr, err)
+			continue
+		}
+		if got := selectedIDs(t, selector); got != test.want {
+			t.Errorf("%q selected %q, want %q", test.expr, got, test.want)
+		}
+	}
+
+	for _, expr := range []string{"=x", "name=~(", "!"} {
+		if _, err := ParseSelector(expr); err == nil {
+			t.Errorf("%q was accepted", expr)
+		}
+	}
+}
+
+func TestSelectorLabelsAndTags(t *testing.T) {
+	selector := &Selector{}
+	if err := selector.AddLabel("env:production"); err != nil {
+		t.Fatal(err)
+	}
+	if got := selectedIDs(t, selector); got != "m1" {
+		t.Errorf("label selected %q", got)
+	}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"team", "m1,m2"},
+		{"team=api", "m2"},
+		{"team=db", ""},
+	}
+	for _, test := range tests {
+		selector := &Selector{}
+		if err := selector.AddTag(test.tag); err != nil {
+			t.Fatal(err)
+		}
+		if got := selectedIDs(t, selector); got != test.want {
+			t.Errorf("tag %q selected %q, want %q", test.tag, got, test.want)
+		}
+	}
+
+	if err := selector.AddLabel("production"); err == nil {
+		t.Error("label without category was accepted")
+	}
+	if err := selector.AddTag("=x"); err == nil {
+		t.Error("tag without key was accepted")
+	}
+}
+
+func TestNewSelectorFlags(t *testing.T) {
+	policies := &cobra.Command{Use: "alertspolicies"}
+	AddSelectorFlags(policies.Flags())
+	if err := policies.ParseFlags([]string{"--label", "env:production"}); err == nil {
+		t.Error("--label was accepted by a command not on monitors")
+	}
+
+	monitors := &cobra.Command{Use: "monitors"}
+	AddSelectorFlags(monitors.Flags())
+	AddLabelFlag(monitors.Flags())
+	if err := monitors.ParseFlags([]string{"--label", "env:production", "--tag", "team"}); err != nil {
+		t.Fatal(err)
+	}
+	selector, err := NewSelector(monitors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := selectedIDs(t, selector); got != "m1" {
+		t.Errorf("--label and --tag selected %q", got)
+	}
+}
+
+func TestSelectingPrinterKeepsShape(t *testing.T) {
+	selector, err := ParseSelector("name=a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	printer := &SelectingPrinter{Printer: &NDJSONPrinter{}, Selector: selector}
+	if !Streams(printer) {
+		t.Error("selected ndjson does not stream")
+	}
+
+	tests := []struct {
+		obj  json.RawMessage
+		want string
+	}{
+		{json.RawMessage(`{"policies": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}], "links": {}}`), `{"policies":[{"id":1,"name":"a"}],"links":{}}`},
+		{json.RawMessage(`{"id": 1, "name": "a"}`), `[{"id":1,"name":"a"}]`},
+		{json.RawMessage(`{"id": 2, "name": "b"}`), `[]`},
+		{json.RawMessage(`{"dashboard": {"id": 2, "title": "b"}}`), `[]`},
+	}
+	for _, test := range tests {
+		data, err := decodeJSON(test.obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(printer.filter(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("%s filtered to %s, want %s", test.obj, got, test.want)
+		}
+	}
+
+	var out bytes.Buffer
+	printer.Print(json.RawMessage(`{"policies": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`), &out)
+	if out.String() != "{\"id\":1,\"name\":\"a\"}\n" {
+		t.Errorf("printed %q", out.String())
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// timeLayouts are the absolute times accepted by ParseTime.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// ParseTime parses a time given as now, as a duration before now like 30m,
+// 24h, 7d or 2w, or as an absolute time like 2018-06-12T09:00:00Z or
+// 2018-06-12, in the local time zone if it has none.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "now" {
+		return now, nil
+	}
+	if ago, err := parseDuration(s); err == nil {
+		return now.Add(-ago), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, must be now, a duration like 24h or 7d, or a time like 2018-06-12T09:00:00Z", s)
+}
+
+// parseDuration parses a duration like time.ParseDuration, plus the days and
+// weeks, like 7d or 2w.
+func parseDuration(s string) (time.Duration, error) {
+	for unit, length := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if strings.HasSuffix(s, unit) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(s, unit), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(length)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, err
+}
+
+// TimeRange is the time range of the --since and --until flags. A zero
+// bound is not set.
+type TimeRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Contains reports whether t, in milliseconds since the epoch, is in the
+// range.
+func (r TimeRange) Contains(millis int64) bool {
+	t := time.Unix(0, millis*int64(time.Millisecond))
+	return (r.Since.IsZero() || !t.Before(r.Since)) && (r.Until.IsZero() || !t.After(r.Until))
+}
+
+// IsZero reports whether neither bound is set.
+func (r TimeRange) IsZero() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
+
+// NewTimeRange returns the time range of the --since and --until flags of
+// cmd, relative to now.
+func NewTimeRange(cmd *cobra.Command) (TimeRange, error) {
+	var r TimeRange
+	now := time.Now()
+	for _, bound := range []struct {
+		flag string
+		t    *time.Time
+	}{{"since", &r.Since}, {"until", &r.Until}} {
+		value, err := GetArg(cmd, bound.flag)
+		if err != nil {
+			return r, err
+		}
+		if value == "" {
+			continue
+		}
+		if *bound.t, err = ParseTime(value, now); err != nil {
+			return r, fmt.Errorf("invalid --%s: %v", bound.flag, err)
+		}
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return r, fmt.Errorf("--until %s is before --since %s", r.Until.Format(time.RFC3339), r.Since.Format(time.RFC3339))
+	}
+	return r, nil
+}
+
+// AddTimeRangeFlags adds the --since and --until flags read by NewTimeRange
+// to flags.
+func AddTimeRangeFlags(flags *pflag.FlagSet) {
+	flags.String("since", "", "Only from this time on: now, a duration ago like 30m, 24h, 7d or 2w, or a time like 2018-06-12T09:00:00Z or 2018-06-12")
+	flags.String("until", "", "Only up to this time, like --since. Default is now")
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2018, 6, 12, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		s    string
+		want time.Time
+	}{
+		{"now", now},
+		{"30m", now.Add(-30 * time.Minute)},
+		{"24h", now.Add(-24 * time.Hour)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"1.5d", now.Add(-36 * time.Hour)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"2018-06-01T08:00:00Z", time.Date(2018, 6, 1, 8, 0, 0, 0, time.UTC)},
+		{"2018-06-01T08:00:00+02:00", time.Date(2018, 6, 1, 6, 0, 0, 0, time.UTC)},
+		{"2018-06-01", time.Date(2018, 6, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, test := range tests {
+		got, err := ParseTime(test.s, now)
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%q parsed as %v, want %v", test.s, got, test.want)
+		}
+	}
+
+	for _, s := range []string{"", "yesterday", "-2h", "xd", "2018-13-01"} {
+		if _, err := ParseTime(s, now); err == nil {
+			t.Errorf("%q was accepted", s)
+		}
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	since := time.Date(2018, 6, 12, 9, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) int64 { return t.UnixNano() / int64(time.Millisecond) }
+
+	r := TimeRange{Since: since}
+	if !r.Contains(millis(since)) || r.Contains(millis(since.Add(-time.Second))) {
+		t.Errorf("%+v does not contain from its start only", r)
+	}
+	r.Until = since.Add(time.Hour)
+	if !r.Contains(millis(r.Until)) || r.Contains(millis(r.Until.Add(time.Second))) {
+		t.Errorf("%+v does not contain up to its end only", r)
+	}
+	if !(TimeRange{}).Contains(0) {
+		t.Error("an empty range does not contain everything")
+	}
+}
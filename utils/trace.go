@@ -0,0 +1,173 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/spf13/viper"
+)
+
+const (
+	// TraceCalls logs the method, URL, status, latency and attempt of every
+	// call.
+	TraceCalls = 1
+	// TraceBodies also logs the headers and bodies of the calls.
+	TraceBodies = 2
+)
+
+// TraceTransport logs the calls made through Transport to Out, with the
+// secrets redacted.
+type TraceTransport struct {
+	Transport http.RoundTripper
+	Out       io.Writer
+	// Level is TraceCalls or TraceBodies, 0 logs nothing but the curl
+	// commands.
+	Level int
+	// Curl prints every call as an equivalent curl command.
+	Curl bool
+}
+
+// traceMu keeps the lines logged by concurrent calls apart.
+var traceMu sync.Mutex
+
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil && (t.Curl || t.Level >= TraceBodies) {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		reqBody, _ = ioutil.ReadAll(body)
+		body.Close()
+	}
+	if t.Curl {
+		t.printf("%s\n", curlCommand(req, reqBody))
+	}
+
+	attempt := newrelic.AttemptFromContext(req.Context())
+	if attempt == 0 {
+		attempt = 1
+	}
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	if t.Level < TraceCalls {
+		return resp, err
+	}
+	if err != nil {
+		t.printf("%s %s -> error: %v (%v, attempt %d)\n", req.Method, req.URL, err, latency, attempt)
+		return resp, err
+	}
+	t.printf("%s %s -> %s (%v, attempt %d)\n", req.Method, req.URL, resp.Status, latency, attempt)
+
+	if t.Level >= TraceBodies {
+		respBody, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		if readErr != nil {
+			return resp, readErr
+		}
+		t.printf("%s%s%s%s",
+			formatHeader("> ", RedactHeader(req.Header)), formatBody("> ", RedactBody(reqBody)),
+			formatHeader("< ", RedactHeader(resp.Header)), formatBody("< ", RedactBody(respBody)))
+	}
+	return resp, nil
+}
+
+func (t *TraceTransport) printf(format string, a ...interface{}) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	fmt.Fprintf(t.Out, format, a...)
+}
+
+func formatHeader(prefix string, header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s%s: %s\n", prefix, name, strings.Join(header[name], ", "))
+	}
+	return b.String()
+}
+
+func formatBody(prefix string, body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	return prefix + string(body) + "\n"
+}
+
+// curlCommand returns a curl command sending req, with the secrets redacted.
+func curlCommand(req *http.Request, body []byte) string {
+	parts := []string{"curl", "-X", req.Method, shellQuote(req.URL.String())}
+	header := RedactHeader(req.Header)
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			parts = append(parts, "-H", shellQuote(name+": "+value))
+		}
+	}
+	if len(body) > 0 {
+		parts = append(parts, "--data-raw", shellQuote(strings.TrimSpace(string(RedactBody(body)))))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// wrapTrace returns httpClient with its transport wrapped to log the calls
+// to stderr if --verbose or --curl is set, else httpClient.
+func wrapTrace(httpClient *http.Client) *http.Client {
+	level := viper.GetInt("verbose")
+	curl := viper.GetBool("curl")
+	if level <= 0 && !curl {
+		return httpClient
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	wrapped := *httpClient
+	wrapped.Transport = &TraceTransport{Transport: transport, Out: os.Stderr, Level: level, Curl: curl}
+	return &wrapped
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	transportOnce sync.Once
+	transport     *http.Transport
+	transportErr  error
+)
+
+// GetTransport returns the transport shared by all NewRelic clients, so they
+// share its connection pool, built once from the proxy, TLS and connection
+// settings.
+func GetTransport() (*http.Transport, error) {
+	transportOnce.Do(func() {
+		transport, transportErr = newTransport()
+	})
+	return transport, transportErr
+}
+
+func newTransport() (*http.Transport, error) {
+	keepAlive := 30 * time.Second
+	if viper.IsSet("keep_alive") {
+		keepAlive = viper.GetDuration("keep_alive")
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = dialer.DialContext
+	t.DisableKeepAlives = viper.GetBool("disable_keep_alives")
+	if viper.IsSet("max_idle_conns") {
+		t.MaxIdleConns = viper.GetInt("max_idle_conns")
+	}
+	if viper.IsSet("max_idle_conns_per_host") {
+		t.MaxIdleConnsPerHost = viper.GetInt("max_idle_conns_per_host")
+	}
+	if viper.IsSet("idle_conn_timeout") {
+		t.IdleConnTimeout = viper.GetDuration("idle_conn_timeout")
+	}
+
+	proxy, err := proxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	t.Proxy = proxy
+	if proxyAuth := viper.GetString("proxy_auth"); proxyAuth != "" {
+		// Sent to the proxy when tunneling HTTPS, unless the proxy URL has
+		// its own credentials.
+		basic := "Basic " + base64.StdEncoding.EncodeToString([]byte(proxyAuth))
+		t.ProxyConnectHeader = http.Header{"Proxy-Authorization": {basic}}
+	}
+
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
+// proxyEnvVars are the standard proxy environment variables.
+var proxyEnvVars = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"}
+
+// proxyFunc returns the proxy of the standard HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY environment variables if set, else the proxy of NEW_RELIC_PROXY
+// or the profile, which skips the hosts of NO_PROXY too.
+func proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	for _, env := range proxyEnvVars {
+		if os.Getenv(env) != "" {
+			return http.ProxyFromEnvironment, nil
+		}
+	}
+
+	proxy := viper.GetString("proxy")
+	if proxy == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("Invalid proxy %q, must be like http://<host>:<port>.", proxy)
+	}
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if matchNoProxy(noProxy, req.URL.Hostname()) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// matchNoProxy reports whether host is in noProxy, a comma separated list of
+// hosts and domains like "localhost,.example.com", or "*" for all hosts.
+func matchNoProxy(noProxy string, host string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case host == strings.TrimPrefix(entry, "."):
+			return true
+		case strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")):
+			return true
+		}
+	}
+	return false
+}
+
+// newTLSConfig returns the TLS configuration trusting ca_bundle and sending
+// client_cert, nil if neither is set.
+func newTLSConfig() (*tls.Config, error) {
+	caBundle := viper.GetString("ca_bundle")
+	clientCert := viper.GetString("client_cert")
+	clientKey := viper.GetString("client_key")
+	if caBundle == "" && clientCert == "" && clientKey == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{}
+	if caBundle != "" {
+		pem, err := ioutil.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the CA bundle: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No PEM certificate found in the CA bundle %s.", caBundle)
+		}
+		config.RootCAs = pool
+	}
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together.")
+		}
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load the client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
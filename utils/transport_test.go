@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017-2018 IBM Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utils
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestTransportTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: mustNewTransport(t)}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("a server signed by an unknown CA was trusted")
+	}
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caBundle, cert, 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("ca_bundle", caBundle)
+	defer viper.Set("ca_bundle", "")
+
+	client = &http.Client{Transport: mustNewTransport(t)}
+	if _, err := client.Get(server.URL); err != nil {
+		t.Errorf("a server signed by the CA bundle was not trusted: %v", err)
+	}
+}
+
+func TestTransportFallsBackToNewRelicProxy(t *testing.T) {
+	for _, env := range proxyEnvVars {
+		if value, ok := os.LookupEnv(env); ok {
+			os.Unsetenv(env)
+			defer os.Setenv(env, value)
+		}
+	}
+	viper.Set("proxy", "http://proxy.example.com:3128")
+	defer viper.Set("proxy", "")
+	os.Setenv("NO_PROXY", "localhost,.internal.example.com")
+	defer os.Unsetenv("NO_PROXY")
+
+	proxy := mustNewTransport(t).Proxy
+	tests := map[string]string{
+		"https://api.newrelic.com/v2/users.json": "http://proxy.example.com:3128",
+		"http://localhost:8080/v2/users.json":    "",
+		"https://nr.internal.example.com/v2/":    "",
+	}
+	for target, want := range tests {
+		req, _ := http.NewRequest("GET", target, nil)
+		got, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got == nil && want != "") || (got != nil && got.String() != want) {
+			t.Errorf("proxy of %s is %v, want %q", target, got, want)
+		}
+	}
+}
+
+func TestMatchNoProxy(t *testing.T) {
+	tests := []struct {
+		noProxy string
+		host    string
+		want    bool
+	}{
+		{"", "api.newrelic.com", false},
+		{"*", "api.newrelic.com", true},
+		{"newrelic.com", "api.newrelic.com", true},
+		{".newrelic.com", "newrelic.com", true},
+		{"localhost, api.newrelic.com:443", "api.newrelic.com", true},
+		{"relic.com", "api.newrelic.com", false},
+	}
+	for _, tt := range tests {
+		if got := matchNoProxy(tt.noProxy, tt.host); got != tt.want {
+			t.Errorf("matchNoProxy(%q, %q) = %v, want %v", tt.noProxy, tt.host, got, tt.want)
+		}
+	}
+}
+
+func mustNewTransport(t *testing.T) *http.Transport {
+	t.Helper()
+	transport, err := newTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transport
+}
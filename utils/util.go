@@ -18,62 +18,81 @@ package utils
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/IBM/newrelic-cli/newrelic"
+	"github.com/spf13/viper"
 )
 
-// GetNewRelicClient returns a NewRelicClient, if env var NEW_RELIC_APIKEY is set
+// GetNewRelicClient returns a NewRelicClient, if env var NEW_RELIC_APIKEY or
+// api_key in the selected profile is set
 func GetNewRelicClient(ctype ...string) (*newrelic.Client, error) {
 	var client *newrelic.Client
 
-	var httpClient *http.Client
+	if err := LoadProfile(); err != nil {
+		return nil, err
+	}
 
-	proxyStr := os.Getenv("NEW_RELIC_PROXY")
-	if proxyStr != "" {
-		url, _ := url.Parse(proxyStr)
-		proxyURL := http.ProxyURL(url)
-		httpClient = &http.Client{
-			Transport: &http.Transport{
-				Proxy: proxyURL,
-			},
-		}
+	transport, err := GetTransport()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: transport}
+
+	httpClient, err = wrapRecordReplay(httpClient)
+	if err != nil {
+		return nil, err
+	}
+	httpClient = wrapTrace(httpClient)
+	if requestTimeout := viper.GetDuration("request-timeout"); requestTimeout > 0 {
+		// Bounds every attempt of a call, the retries get their own.
+		withTimeout := *httpClient
+		withTimeout.Timeout = requestTimeout
+		httpClient = &withTimeout
+	}
+
+	endpoints, err := GetEndpoints()
+	if err != nil {
+		return nil, err
 	}
 
 	var needCheckAPIKey = true
 
 	if len(ctype) == 0 {
-		client = newrelic.NewClient(httpClient, "default")
+		client = newrelic.NewClientWithEndpoints(httpClient, "default", endpoints)
 	} else {
 		if ctype[0] == "synthetics" {
-			client = newrelic.NewClient(httpClient, "synthetics")
+			client = newrelic.NewClientWithEndpoints(httpClient, "synthetics", endpoints)
 		} else if ctype[0] == "labelSynthetics" {
-			client = newrelic.NewClient(httpClient, "labelSynthetics")
+			client = newrelic.NewClientWithEndpoints(httpClient, "labelSynthetics", endpoints)
 		} else if ctype[0] == "graphql" {
-			client = newrelic.NewClient(httpClient, "graphql")
+			client = newrelic.NewClientWithEndpoints(httpClient, "graphql", endpoints)
 		} else if ctype[0] == "insights" {
-			client = newrelic.NewClient(httpClient, "insights")
+			client = newrelic.NewClientWithEndpoints(httpClient, "insights", endpoints)
 			needCheckAPIKey = false
 		} else if ctype[0] == "infrastructure" {
-			client = newrelic.NewClient(httpClient, "infrastructure")
+			client = newrelic.NewClientWithEndpoints(httpClient, "infrastructure", endpoints)
 		}
 	}
 
 	if needCheckAPIKey == true {
-		apikey := os.Getenv("NEW_RELIC_APIKEY")
+		apikey, err := GetCredential("api_key")
+		if err != nil {
+			return nil, err
+		}
 		if apikey == "" {
-			return nil, fmt.Errorf("No NEW_RELIC_APIKEY detected.")
+			return nil, fmt.Errorf("No NEW_RELIC_APIKEY detected, nor api_key in the profile. Run 'nr auth login' to store one.")
 		}
 
 		client.XApiKey = apikey
 	}
 
-	proxyAuth := os.Getenv("PROXY_AUTH")
+	proxyAuth := viper.GetString("proxy_auth")
 	client.ProxyAuth = proxyAuth
 
-	retries := os.Getenv("RETRIES")
+	retries := viper.GetString("retries")
 	if retries != "" {
 		var err error
 		client.Retries, err = strconv.Atoi(retries)
@@ -82,213 +101,12 @@ func GetNewRelicClient(ctype ...string) (*newrelic.Client, error) {
 		}
 	}
 
-	return client, nil
-}
-
-func MergeMonitorList(s1 []*newrelic.Monitor, s2 []*newrelic.Monitor) (slice []*newrelic.Monitor) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.Monitor, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return
-}
-
-func MergeLabelList(s1 []*newrelic.Label, s2 []*newrelic.Label) (slice []*newrelic.Label) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.Label, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return
-}
-
-func MergeMonitorReflList(s1 []*newrelic.MonitorRef, s2 []*newrelic.MonitorRef) (slice []*newrelic.MonitorRef) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.MonitorRef, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
+	if backoff, err := time.ParseDuration(os.Getenv("RETRY_BACKOFF")); err == nil {
+		client.RetryPolicy.MinBackoff = backoff
 	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
+	if maxBackoff, err := time.ParseDuration(os.Getenv("RETRY_MAX_BACKOFF")); err == nil {
+		client.RetryPolicy.MaxBackoff = maxBackoff
 	}
 
-	return
-}
-
-func MergeStringList(s1 []*string, s2 []*string) (slice []*string) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*string, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return
-}
-
-func MergeAlertPolicyList(s1 []*newrelic.AlertsPolicy, s2 []*newrelic.AlertsPolicy) (slice []*newrelic.AlertsPolicy) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsPolicy, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertDefaultConditionsList(s1 []*newrelic.AlertsDefaultCondition, s2 []*newrelic.AlertsDefaultCondition) (slice []*newrelic.AlertsDefaultCondition) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsDefaultCondition, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertExternalServiceConditionsList(s1 []*newrelic.AlertsExternalServiceCondition, s2 []*newrelic.AlertsExternalServiceCondition) (slice []*newrelic.AlertsExternalServiceCondition) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsExternalServiceCondition, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertNRQLConditionsList(s1 []*newrelic.AlertsNRQLCondition, s2 []*newrelic.AlertsNRQLCondition) (slice []*newrelic.AlertsNRQLCondition) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsNRQLCondition, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertPluginsConditionsList(s1 []*newrelic.AlertsPluginsCondition, s2 []*newrelic.AlertsPluginsCondition) (slice []*newrelic.AlertsPluginsCondition) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsPluginsCondition, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertSyntheticsConditionsList(s1 []*newrelic.AlertsSyntheticsCondition, s2 []*newrelic.AlertsSyntheticsCondition) (slice []*newrelic.AlertsSyntheticsCondition) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsSyntheticsCondition, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
-}
-
-func MergeAlertConditionList(s1 *newrelic.AlertsConditionList, s2 *newrelic.AlertsConditionList) *newrelic.AlertsConditionList {
-	var newList *newrelic.AlertsConditionList = &newrelic.AlertsConditionList{}
-	newList.AlertsDefaultConditionList = &newrelic.AlertsDefaultConditionList{}
-	newList.AlertsExternalServiceConditionList = &newrelic.AlertsExternalServiceConditionList{}
-	newList.AlertsNRQLConditionList = &newrelic.AlertsNRQLConditionList{}
-	newList.AlertsPluginsConditionList = &newrelic.AlertsPluginsConditionList{}
-	newList.AlertsSyntheticsConditionList = &newrelic.AlertsSyntheticsConditionList{}
-
-	//default
-	if s1.AlertsDefaultConditionList != nil && s2.AlertsDefaultConditionList != nil {
-		newList.AlertsDefaultConditions = MergeAlertDefaultConditionsList(s1.AlertsDefaultConditionList.AlertsDefaultConditions, s2.AlertsDefaultConditionList.AlertsDefaultConditions)
-	}
-
-	//ExternalService
-	if s1.AlertsExternalServiceConditionList != nil && s2.AlertsExternalServiceConditionList != nil {
-		newList.AlertsExternalServiceConditions = MergeAlertExternalServiceConditionsList(s1.AlertsExternalServiceConditionList.AlertsExternalServiceConditions, s2.AlertsExternalServiceConditionList.AlertsExternalServiceConditions)
-	}
-	//nrql
-	if s1.AlertsNRQLConditionList != nil && s2.AlertsNRQLConditionList != nil {
-		newList.AlertsNRQLConditions = MergeAlertNRQLConditionsList(s1.AlertsNRQLConditionList.AlertsNRQLConditions, s2.AlertsNRQLConditionList.AlertsNRQLConditions)
-	}
-	//plugin
-	if s1.AlertsPluginsConditionList != nil && s2.AlertsPluginsConditionList != nil {
-		newList.AlertsPluginsConditions = MergeAlertPluginsConditionsList(s1.AlertsPluginsConditionList.AlertsPluginsConditions, s2.AlertsPluginsConditionList.AlertsPluginsConditions)
-	}
-	//synthetics
-	if s1.AlertsSyntheticsConditionList != nil && s2.AlertsSyntheticsConditionList != nil {
-		newList.AlertsSyntheticsConditions = MergeAlertSyntheticsConditionsList(s1.AlertsSyntheticsConditionList.AlertsSyntheticsConditions, s2.AlertsSyntheticsConditionList.AlertsSyntheticsConditions)
-	}
-	return newList
-}
-
-func MergeAlertChannelList(s1 []*newrelic.AlertsChannel, s2 []*newrelic.AlertsChannel) (slice []*newrelic.AlertsChannel) {
-	var len1 = len(s1)
-	var len2 = len(s2)
-	var len3 = len1 + len2
-	slice = make([]*newrelic.AlertsChannel, len3)
-
-	for i := 0; i < len1; i++ {
-		slice[i] = s1[i]
-	}
-	for j := 0; j < len2; j++ {
-		slice[j+len1] = s2[j]
-	}
-
-	return slice
+	return client, nil
 }